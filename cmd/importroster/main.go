@@ -0,0 +1,89 @@
+// Command importroster imports a CSV or XLSX student roster into an
+// organization, the same way POST /api/v1/roster/import does. Use -dry-run
+// to only validate the file. Invitations are not sent from the command line;
+// students can use the password reset flow.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/app"
+	cohortPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/cohort/repository/postgres"
+	orgPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/repository/postgres"
+	rosterHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/delivery/http"
+	rosterPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/repository/postgres"
+	rosterService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/service"
+	sectionPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/section/repository/postgres"
+	userPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/repository/postgres"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/spreadsheet"
+)
+
+func main() {
+	orgSlug := flag.String("org", "", "slug of the organization to import into")
+	file := flag.String("file", "", "path to the .csv or .xlsx roster")
+	dryRun := flag.Bool("dry-run", false, "validate the roster without importing it")
+	flag.Parse()
+
+	v := app.NewViper()
+	logger := app.NewLogger(v)
+
+	if *orgSlug == "" || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		logger.Fatalf("failed to read roster: %v", err)
+	}
+	records, err := spreadsheet.Read(*file, data)
+	if err != nil {
+		logger.Fatalf("failed to parse roster: %v", err)
+	}
+
+	db := app.NewDatabase(v, logger)
+	defer db.Close()
+	tenantDB := database.NewTenantDB(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	org, err := orgPostgres.NewOrganizationRepo(tenantDB, logger).GetBySlug(ctx, *orgSlug)
+	if err != nil {
+		logger.Fatalf("failed to look up organization: %v", err)
+	}
+	if org == nil {
+		logger.Fatalf("organization %q not found", *orgSlug)
+	}
+	ctx = context.WithValue(ctx, auth.OrgIDKey, org.ID)
+
+	importer := rosterService.NewRosterService(
+		rosterPostgres.NewRosterRepository(tenantDB, logger),
+		userPostgres.NewUserRepo(tenantDB, logger),
+		userPostgres.NewRoleRepository(tenantDB, logger),
+		orgPostgres.NewAcademicPeriodRepository(tenantDB),
+		cohortPostgres.NewCohortRepository(tenantDB, logger),
+		sectionPostgres.NewSectionRepository(tenantDB, logger),
+		nil, // only used for invitations
+		logger,
+	)
+
+	report, err := importer.Import(ctx, org.ID, records, rosterService.ImportOptions{DryRun: *dryRun})
+	if err != nil {
+		logger.Fatalf("import failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(rosterHttp.ToImportReportResponse(report))
+
+	if report.HasErrors() {
+		os.Exit(1)
+	}
+}
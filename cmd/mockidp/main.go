@@ -0,0 +1,36 @@
+// Command mockidp runs a throwaway OpenID Connect provider for trying SSO
+// locally. Every authorization request is approved for the user given by
+// login_hint, or the default user when the hint is unknown.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/oidc/oidctest"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL, must match how the LMS reaches this server")
+	clientID := flag.String("client-id", "chimera-lms", "client id the LMS is configured with")
+	email := flag.String("email", "student@example.edu", "email of the default user")
+	flag.Parse()
+
+	provider, err := oidctest.NewProvider(*issuer, *clientID, oidctest.User{
+		Subject:       "mock-" + *email,
+		Email:         *email,
+		EmailVerified: true,
+		GivenName:     "Mock",
+		FamilyName:    "User",
+	})
+	if err != nil {
+		log.Fatalf("failed to create provider: %v", err)
+	}
+
+	log.Printf("mock OIDC provider %s listening on %s", *issuer, *addr)
+	if err := http.ListenAndServe(*addr, provider); err != nil {
+		log.Fatalf("server failed: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Command onboard sets up a new organization from its type's template, the
+// same way POST /api/v1/system/onboarding does. Running it again with the
+// same slug changes nothing. Without -admin-password a random password is
+// generated and printed once, since no reset email is sent from the command
+// line.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/app"
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	systemHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/delivery/http"
+	systemPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/repository/postgres"
+	systemService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/service"
+)
+
+const dateLayout = "2006-01-02"
+
+func main() {
+	name := flag.String("name", "", "organization name")
+	slug := flag.String("slug", "", "organization slug")
+	orgType := flag.String("type", "", "university, high_school, middle_school or grade_school")
+	address := flag.String("address", "", "organization address (optional)")
+	adminEmail := flag.String("admin-email", "", "email of the first administrator")
+	adminFirstName := flag.String("admin-first-name", "", "first name of the first administrator")
+	adminLastName := flag.String("admin-last-name", "", "last name of the first administrator")
+	adminPassword := flag.String("admin-password", "", "password of the first administrator (generated when empty)")
+	periodName := flag.String("period", "", "name of the initial academic period, e.g. \"2025/2026 Ganjil\"")
+	periodStart := flag.String("period-start", "", "start date of the academic period (YYYY-MM-DD)")
+	periodEnd := flag.String("period-end", "", "end date of the academic period (YYYY-MM-DD)")
+	flag.Parse()
+
+	v := app.NewViper()
+	logger := app.NewLogger(v)
+
+	if *name == "" || *slug == "" || *orgType == "" || *adminEmail == "" || *periodName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	start, err := time.Parse(dateLayout, *periodStart)
+	if err != nil {
+		logger.Fatalf("invalid -period-start: %v", err)
+	}
+	end, err := time.Parse(dateLayout, *periodEnd)
+	if err != nil {
+		logger.Fatalf("invalid -period-end: %v", err)
+	}
+
+	password := *adminPassword
+	if password == "" {
+		b := make([]byte, 12)
+		if _, err := rand.Read(b); err != nil {
+			logger.Fatalf("failed to generate password: %v", err)
+		}
+		password = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	db := app.NewDatabase(v, logger)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	onboarding := systemService.NewOnboardingService(
+		systemPostgres.NewOnboardingRepository(db, logger),
+		nil, // the password is always set here
+		logger,
+	)
+	summary, err := onboarding.Onboard(ctx, systemService.OnboardingInput{
+		Name:           *name,
+		Slug:           *slug,
+		Type:           o.OrgType(*orgType),
+		Address:        *address,
+		AdminEmail:     *adminEmail,
+		AdminFirstName: *adminFirstName,
+		AdminLastName:  *adminLastName,
+		AdminPassword:  password,
+		PeriodName:     *periodName,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+	})
+	if err != nil {
+		logger.Fatalf("onboarding failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(systemHttp.ToOnboardingResponse(summary))
+
+	if !summary.Created {
+		fmt.Fprintf(os.Stderr, "organization %q already exists; nothing was changed\n", summary.Organization.Slug)
+	} else if *adminPassword == "" {
+		fmt.Fprintf(os.Stderr, "administrator password: %s\n", password)
+	}
+}
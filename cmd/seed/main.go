@@ -23,6 +23,7 @@ import (
 	eventr "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/repository/postgres"
 	event "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/seed"
 	or "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/repository/postgres"
+	orgDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
 	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/seed"
 	prog "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/program/repository/postgres"
 	pr "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/program/seed"
@@ -37,6 +38,7 @@ import (
 	ur "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/repository/postgres"
 	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/seed"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
@@ -45,21 +47,22 @@ func main() {
 	logger := app.NewLogger(v)
 	db := app.NewDatabase(v, logger)
 	defer db.Close()
+	tenantDB := database.NewTenantDB(db)
 
-	roleRepo := ur.NewRoleRepository(db, logger)
+	roleRepo := ur.NewRoleRepository(tenantDB, logger)
 	roleSeeder := u.NewRoleSeeder(roleRepo)
 
-	organizationRepo := or.NewOrganizationRepo(db, logger)
-	acadPeriodRepo := or.NewAcademicPeriodRepository(db)
+	organizationRepo := or.NewOrganizationRepo(tenantDB, logger)
+	acadPeriodRepo := or.NewAcademicPeriodRepository(tenantDB)
 	organizationSeeder := o.NewOrganizationSeeder(organizationRepo, acadPeriodRepo)
 
-	educationLevelRepo := elr.NewEducationLevelRepository(db)
+	educationLevelRepo := elr.NewEducationLevelRepository(tenantDB)
 	educationLevelSeeder := el.NewEducationLevelRepository(educationLevelRepo)
 
-	programRepo := prog.NewProgramRepository(db)
+	programRepo := prog.NewProgramRepository(tenantDB)
 	programSeeder := pr.NewProgramSeeder(programRepo)
 
-	subjectRepo := subj.NewSubjectRepository(db)
+	subjectRepo := subj.NewSubjectRepository(tenantDB)
 	subjectSeeder := sub.NewSubjectSeeder(subjectRepo)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -101,7 +104,7 @@ func main() {
 	}
 
 	// User Seeder
-	userRepo := ur.NewUserRepo(db, logger)
+	userRepo := ur.NewUserRepo(tenantDB, logger)
 	userSeeder := u.NewUserSeeder(userRepo, roleRepo)
 	logger.Info("Starting user seeding...")
 	seededUsers, err := userSeeder.SeedUsers(ctx, seededOrg.ID)
@@ -111,6 +114,13 @@ func main() {
 		logger.Info("User seeding complete...")
 	}
 
+	logger.Info("Starting system administrator seeding...")
+	if _, err := userSeeder.SeedSystemAdmin(ctx, orgDomain.SystemOrganizationID); err != nil {
+		logger.Info("System administrator seeding failed: ", err.Error())
+	} else {
+		logger.Info("System administrator seeding complete...")
+	}
+
 	// Subject Seeder
 	logger.Info("Starting subject seeding...")
 	var highSchoolLevelID uuid.UUID
@@ -135,10 +145,10 @@ func main() {
 	}
 
 	// Cohort & Section Seeder
-	cohortRepo := cohr.NewCohortRepository(db, logger)
+	cohortRepo := cohr.NewCohortRepository(tenantDB, logger)
 	cohortSeeder := coh.NewCohortSeeder(cohortRepo)
 
-	sectionRepo := secr.NewSectionRepository(db, logger)
+	sectionRepo := secr.NewSectionRepository(tenantDB, logger)
 	sectionSeeder := sec.NewSectionSeeder(sectionRepo)
 
 	logger.Info("Starting cohort seeding...")
@@ -158,13 +168,13 @@ func main() {
 	}
 
 	// Course, Module, Lesson Seeder
-	courseRepo := cr.NewCourseRepository(db)
+	courseRepo := cr.NewCourseRepository(tenantDB)
 	courseSeeder := crs.NewCourseSeeder(courseRepo)
 
-	moduleRepo := mr.NewModuleRepository(db)
+	moduleRepo := mr.NewModuleRepository(tenantDB)
 	moduleSeeder := modules.NewModuleSeeder(moduleRepo)
 
-	lessonRepo := lr.NewLessonRepository(db)
+	lessonRepo := lr.NewLessonRepository(tenantDB)
 	lessonSeeder := lessons.NewLessonSeeder(lessonRepo)
 
 	logger.Info("Starting course seeding...")
@@ -198,7 +208,7 @@ func main() {
 	}
 
 	// Event Seeder (Holidays + Lesson Schedules)
-	eventRepo := eventr.NewEventRepository(db, logger)
+	eventRepo := eventr.NewEventRepository(tenantDB, logger)
 	eventSeeder := event.NewEventSeeder(eventRepo)
 
 	logger.Info("Starting Indonesia holiday seeding...")
@@ -267,7 +277,7 @@ func main() {
 	}
 
 	// Enrollment Seeder
-	enrollmentRepo := er.NewEnrollmentRepository(db, logger)
+	enrollmentRepo := er.NewEnrollmentRepository(tenantDB, logger)
 	enrollmentSeeder := enroll.NewEnrollmentSeeder(enrollmentRepo)
 
 	logger.Info("Starting enrollment seeding...")
@@ -279,8 +289,8 @@ func main() {
 	}
 
 	// Assessment Seeder (with content for attachments)
-	assessmentRepository := assessmentRepo.NewAssessmentRepoPostgres(db, logger)
-	contentRepository := contentRepo.NewContentRepository(db)
+	assessmentRepository := assessmentRepo.NewAssessmentRepoPostgres(tenantDB, logger)
+	contentRepository := contentRepo.NewContentRepository(tenantDB)
 	assessmentSeeder := assessmentSeed.NewAssessmentSeeder(assessmentRepository, contentRepository)
 
 	logger.Info("Starting assessment seeding...")
@@ -292,7 +302,7 @@ func main() {
 	}
 
 	// Submission Seeder
-	submissionRepository := submissionRepo.NewSubmissionRepoPostgres(db)
+	submissionRepository := submissionRepo.NewSubmissionRepoPostgres(tenantDB)
 	submissionSeeder := submissionSeed.NewSubmissionSeeder(submissionRepository)
 
 	logger.Info("Starting submission seeding...")
@@ -304,7 +314,7 @@ func main() {
 	}
 
 	// Cohort Member Seeder
-	cohortMemberRepo := cohr.NewCohortMemberRepository(db)
+	cohortMemberRepo := cohr.NewCohortMemberRepository(tenantDB)
 	cohortMemberSeeder := coh.NewCohortMemberSeeder(cohortMemberRepo)
 
 	logger.Info("Starting cohort member seeding...")
@@ -316,7 +326,7 @@ func main() {
 	}
 
 	// Section Member Seeder
-	sectionMemberRepo := secr.NewSectionMemberRepository(db)
+	sectionMemberRepo := secr.NewSectionMemberRepository(tenantDB)
 	sectionMemberSeeder := sec.NewSectionMemberSeeder(sectionMemberRepo)
 
 	logger.Info("Starting section member seeding...")
@@ -328,7 +338,7 @@ func main() {
 	}
 
 	// Program Course Seeder
-	programCourseRepo := prog.NewProgramCourseRepository(db)
+	programCourseRepo := prog.NewProgramCourseRepository(tenantDB)
 	programCourseSeeder := pr.NewProgramCourseSeeder(programCourseRepo)
 
 	logger.Info("Starting program course seeding...")
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+	// Organization timezones are validated against the embedded database,
+	// so slim container images need no system tzdata.
+	_ "time/tzdata"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/app"
 )
@@ -26,11 +26,24 @@ import (
 	eventHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/delivery/http"
 	eventPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/repository/postgres"
 	eventService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/service"
+	guardianHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/guardian/delivery/http"
+	guardianService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/guardian/service"
+	orgHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/delivery/http"
 	orgPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/repository/postgres"
+	orgService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	rosterHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/delivery/http"
+	rosterPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/repository/postgres"
+	rosterService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/service"
+	systemHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/delivery/http"
+	systemPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/repository/postgres"
+	systemService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/service"
 	sectionPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/section/repository/postgres"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/mailer"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/oidc"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/storage"
 )
 
@@ -44,21 +57,41 @@ type BootstrapConfig struct {
 
 func Bootstrap(config *BootstrapConfig) {
 	// 1. Setup Repositories
-	userRepo := postgres.NewUserRepo(config.DB, config.Log)
-	roleRepo := postgres.NewRoleRepository(config.DB, config.Log)
+	// Tenant statements run under row-level security; the system
+	// administration API reads across tenants and keeps the plain pool.
+	tenantDB := database.NewTenantDB(config.DB)
+	userRepo := postgres.NewUserRepo(tenantDB, config.Log)
+	roleRepo := postgres.NewRoleRepository(tenantDB, config.Log)
+	recoveryCodeRepo := postgres.NewRecoveryCodeRepository(tenantDB, config.Log)
+	loginHistoryRepo := postgres.NewLoginHistoryRepository(tenantDB, config.Log)
+	sessionRepo := postgres.NewSessionRepository(tenantDB, config.Log)
+	impersonationRepo := postgres.NewImpersonationRepository(tenantDB, config.Log)
+	apiKeyRepo := postgres.NewAPIKeyRepository(tenantDB, config.Log)
+	membershipRepo := postgres.NewMembershipRepository(tenantDB, config.Log)
+	invitationRepo := postgres.NewInvitationRepository(tenantDB, config.Log)
+	identityProviderRepo := postgres.NewIdentityProviderRepository(tenantDB, config.Log)
 
 	// Event Dependencies
-	eventRepo := eventPostgres.NewEventRepository(config.DB, config.Log)
-	orgRepo := orgPostgres.NewOrganizationRepo(config.DB, config.Log)
-	enrollmentRepo := enrollmentPostgres.NewEnrollmentRepository(config.DB, config.Log)
-	cohortRepo := cohortPostgres.NewCohortRepository(config.DB, config.Log)
-	sectionRepo := sectionPostgres.NewSectionRepository(config.DB, config.Log)
+	eventRepo := eventPostgres.NewEventRepository(tenantDB, config.Log)
+	orgRepo := orgPostgres.NewOrganizationRepo(tenantDB, config.Log)
+	enrollmentRepo := enrollmentPostgres.NewEnrollmentRepository(tenantDB, config.Log)
+	cohortRepo := cohortPostgres.NewCohortRepository(tenantDB, config.Log)
+	sectionRepo := sectionPostgres.NewSectionRepository(tenantDB, config.Log)
+	academicPeriodRepo := orgPostgres.NewAcademicPeriodRepository(tenantDB)
+	rolloverRepo := orgPostgres.NewRolloverRepository(tenantDB, config.Log)
+
+	// Roster Dependencies
+	rosterRepo := rosterPostgres.NewRosterRepository(tenantDB, config.Log)
 
 	// Assessment Dependencies
-	assessmentRepo := assessmentPostgres.NewAssessmentRepoPostgres(config.DB, config.Log)
+	assessmentRepo := assessmentPostgres.NewAssessmentRepoPostgres(tenantDB, config.Log)
+
+	// System Administration Dependencies
+	usageRepo := systemPostgres.NewUsageRepository(config.DB, config.Log)
+	onboardingRepo := systemPostgres.NewOnboardingRepository(config.DB, config.Log)
 
 	// Attachment Dependencies
-	attachmentRepo := attachmentPostgres.NewAttachmentRepoPostgres(config.DB, config.Log)
+	attachmentRepo := attachmentPostgres.NewAttachmentRepoPostgres(tenantDB, config.Log)
 
 	// Storage backend
 	storageType := config.Config.GetString("STORAGE_TYPE")
@@ -86,6 +119,44 @@ func Bootstrap(config *BootstrapConfig) {
 		log.Fatalf("unsupported storage type: %s", storageType)
 	}
 
+	// Mail delivery
+	mailDriver := config.Config.GetString("MAIL_DRIVER")
+	if mailDriver == "" {
+		mailDriver = "outbox"
+	}
+	mailFrom := config.Config.GetString("MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = "no-reply@chimera-lms.local"
+	}
+
+	var mail mailer.Mailer
+	switch mailDriver {
+	case "smtp":
+		smtpPort := config.Config.GetInt("SMTP_PORT")
+		if smtpPort == 0 {
+			smtpPort = 587
+		}
+		mail = mailer.NewSMTPMailer(
+			config.Config.GetString("SMTP_HOST"),
+			smtpPort,
+			config.Config.GetString("SMTP_USERNAME"),
+			config.Config.GetString("SMTP_PASSWORD"),
+			mailFrom,
+		)
+	case "outbox":
+		outboxPath := config.Config.GetString("MAIL_OUTBOX_PATH")
+		if outboxPath == "" {
+			outboxPath = "./outbox"
+		}
+		var err error
+		mail, err = mailer.NewOutboxMailer(outboxPath, mailFrom)
+		if err != nil {
+			log.Fatalf("failed to initialize mail outbox: %v", err)
+		}
+	default:
+		log.Fatalf("unsupported mail driver: %s", mailDriver)
+	}
+
 	secret := config.Config.GetString("JWT_SECRET_KEY")
 	expiryMinutes := config.Config.GetInt("ACCESS_TOKEN_EXPIRE_MINUTES")
 	if expiryMinutes == 0 {
@@ -93,10 +164,120 @@ func Bootstrap(config *BootstrapConfig) {
 	}
 	expiryDuration := time.Duration(expiryMinutes) * time.Minute
 
-	tokenProvider := auth.NewJWTProvider(secret, expiryDuration, config.Redis)
+	refreshExpiryDays := config.Config.GetInt("REFRESH_TOKEN_EXPIRE_DAYS")
+	if refreshExpiryDays == 0 {
+		refreshExpiryDays = 30
+	}
+	refreshExpiryDuration := time.Duration(refreshExpiryDays) * 24 * time.Hour
+
+	// Tokens are signed with the shared secret unless a key set is configured,
+	// in which case RS256/EdDSA keys are used and published as JWKS.
+	signingKeys := auth.NewHMACKeySet(secret)
+	if keySetPath := config.Config.GetString("JWT_KEYSET_FILE"); keySetPath != "" {
+		var err error
+		signingKeys, err = auth.LoadKeySet(keySetPath)
+		if err != nil {
+			log.Fatalf("failed to load jwt key set: %v", err)
+		}
+	}
+
+	tokenProvider := auth.NewJWTProvider(signingKeys, expiryDuration, refreshExpiryDuration, config.Redis)
 
 	// 2. Setup Services/UseCases
-	authService := service.NewAuthService(userRepo, roleRepo, tokenProvider, config.Log)
+	sessionService := service.NewSessionService(sessionRepo, orgRepo, tokenProvider, config.Log)
+
+	verificationSecret := config.Config.GetString("EMAIL_VERIFICATION_SECRET")
+	if verificationSecret == "" {
+		// The service derives its own key from whatever it is given, so
+		// reusing the JWT secret here does not make the two interchangeable.
+		config.Log.Warn("EMAIL_VERIFICATION_SECRET is not set, deriving the verification key from JWT_SECRET_KEY")
+		verificationSecret = secret
+	}
+	verificationExpiryHours := config.Config.GetInt("EMAIL_VERIFICATION_EXPIRE_HOURS")
+	if verificationExpiryHours == 0 {
+		verificationExpiryHours = 48
+	}
+	verificationService := service.NewVerificationService(
+		userRepo,
+		mail,
+		verificationSecret,
+		config.Config.GetString("APP_BASE_URL")+"/verify-email",
+		time.Duration(verificationExpiryHours)*time.Hour,
+		config.Log,
+	)
+
+	totpIssuer := config.Config.GetString("TOTP_ISSUER")
+	if totpIssuer == "" {
+		totpIssuer = "Chimera LMS"
+	}
+	twoFactorService := service.NewTwoFactorService(
+		userRepo,
+		recoveryCodeRepo,
+		orgRepo,
+		sessionService,
+		config.Redis,
+		totpIssuer,
+		config.Log,
+	)
+
+	loginPolicy := service.LoginPolicy{
+		MaxAttempts:   config.Config.GetInt("LOGIN_MAX_ATTEMPTS"),
+		IPMaxAttempts: config.Config.GetInt("LOGIN_IP_MAX_ATTEMPTS"),
+		FreeAttempts:  3,
+		Window:        time.Duration(config.Config.GetInt("LOGIN_ATTEMPT_WINDOW_MINUTES")) * time.Minute,
+		LockoutPeriod: time.Duration(config.Config.GetInt("LOGIN_LOCKOUT_MINUTES")) * time.Minute,
+		BackoffBase:   time.Second,
+		BackoffMax:    5 * time.Minute,
+	}
+	if loginPolicy.MaxAttempts == 0 {
+		loginPolicy.MaxAttempts = 10
+	}
+	if loginPolicy.IPMaxAttempts == 0 {
+		loginPolicy.IPMaxAttempts = 100
+	}
+	if loginPolicy.Window == 0 {
+		loginPolicy.Window = 15 * time.Minute
+	}
+	if loginPolicy.LockoutPeriod == 0 {
+		loginPolicy.LockoutPeriod = 15 * time.Minute
+	}
+	loginGuard := service.NewLoginGuard(userRepo, loginHistoryRepo, config.Redis, loginPolicy, config.Log)
+
+	authService := service.NewAuthService(userRepo, roleRepo, tokenProvider, verificationService, twoFactorService, loginGuard, sessionService, config.Log)
+
+	ssoRedirectURL := config.Config.GetString("OIDC_REDIRECT_URL")
+	if ssoRedirectURL == "" {
+		ssoRedirectURL = config.Config.GetString("APP_BASE_URL") + "/sso/callback"
+	}
+	ssoService := service.NewSSOService(
+		identityProviderRepo,
+		userRepo,
+		roleRepo,
+		orgRepo,
+		oidc.NewClient(oidc.NewPublicHTTPClient(config.Config.GetBool("OIDC_ALLOW_INSECURE_ISSUERS")), time.Hour),
+		sessionService,
+		twoFactorService,
+		config.Redis,
+		ssoRedirectURL,
+		config.Log,
+	)
+
+	resetExpiryMinutes := config.Config.GetInt("PASSWORD_RESET_EXPIRE_MINUTES")
+	if resetExpiryMinutes == 0 {
+		resetExpiryMinutes = 30
+	}
+	passwordService := service.NewPasswordService(
+		userRepo,
+		sessionService,
+		mail,
+		config.Redis,
+		config.Config.GetString("APP_BASE_URL")+"/reset-password",
+		time.Duration(resetExpiryMinutes)*time.Minute,
+		config.Log,
+	)
+
+	orgSettingsSvc := orgService.NewSettingsService(orgRepo, config.Redis, config.Log)
+	tenantResolver := orgService.NewTenantResolver(orgRepo, config.Redis, config.Log)
 
 	eventService := eventService.NewEventService(
 		eventRepo,
@@ -104,35 +285,106 @@ func Bootstrap(config *BootstrapConfig) {
 		enrollmentRepo,
 		cohortRepo,
 		sectionRepo,
+		orgSettingsSvc,
 		config.Redis,
 		config.Log,
 	)
 
 	assessmentSvc := assessmentService.NewAssessmentService(assessmentRepo, config.Log)
+	guardianSvc := service.NewGuardianService(userRepo, config.Log)
+	userAdminService := service.NewUserAdminService(userRepo, roleRepo, membershipRepo, invitationRepo, sessionService, verificationService, config.Log)
+	roleService := service.NewRoleService(roleRepo, userRepo, config.Log)
+	serviceAccountService := service.NewServiceAccountService(userRepo, apiKeyRepo, config.Log)
+	membershipService := service.NewMembershipService(membershipRepo, invitationRepo, userRepo, twoFactorService, sessionService, config.Log)
+	scimService := service.NewSCIMService(userRepo, roleRepo, membershipRepo, sessionService, config.Log)
+
+	impersonationMinutes := config.Config.GetInt("IMPERSONATION_EXPIRE_MINUTES")
+	if impersonationMinutes == 0 {
+		impersonationMinutes = 15
+	}
+	impersonationService := service.NewImpersonationService(
+		userRepo,
+		impersonationRepo,
+		tokenProvider,
+		time.Duration(impersonationMinutes)*time.Minute,
+		config.Log,
+	)
+	parentPortal := guardianService.NewParentPortal(guardianSvc, eventService, assessmentSvc, config.Log)
+	rosterSvc := rosterService.NewRosterService(
+		rosterRepo,
+		userRepo,
+		roleRepo,
+		academicPeriodRepo,
+		cohortRepo,
+		sectionRepo,
+		passwordService,
+		config.Log,
+	)
 	attachmentSvc := attachmentService.NewAttachmentService(attachmentRepo, fileStorage, config.Log)
+	organizationSvc := orgService.NewOrganizationService(orgRepo, config.Log)
+	academicPeriodSvc := orgService.NewAcademicPeriodService(academicPeriodRepo, rolloverRepo, config.Log)
+	systemAdminSvc := systemService.NewAdminService(usageRepo, orgRepo, organizationSvc, userRepo, passwordService, config.DB, config.Redis, config.Log)
+	onboardingSvc := systemService.NewOnboardingService(onboardingRepo, passwordService, config.Log)
 
 	// 3. Setup Controllers/Handlers
-	userHandler := userHttp.NewUserHandler(authService, config.Log)
+	userHandler := userHttp.NewUserHandler(authService, passwordService, verificationService, twoFactorService, loginGuard, sessionService, ssoService, guardianSvc, userAdminService, impersonationService, membershipService, config.Log)
+	roleHandler := userHttp.NewRoleHandler(roleService, config.Log)
+	serviceAccountHandler := userHttp.NewServiceAccountHandler(serviceAccountService, config.Log)
+	scimHandler := userHttp.NewSCIMHandler(scimService, config.Log)
 	eventHandler := eventHttp.NewEventHandler(eventService, config.Log)
 	assessmentHandler := assessmentHttp.NewAssessmentHandler(assessmentSvc, config.Log)
+	guardianHandler := guardianHttp.NewGuardianHandler(parentPortal, config.Log)
+	rosterHandler := rosterHttp.NewRosterHandler(rosterSvc, config.Log)
 	attachmentHandler := attachmentHttp.NewAttachmentHandler(attachmentSvc, config.Log)
+	organizationHandler := orgHttp.NewOrganizationHandler(organizationSvc, academicPeriodSvc, orgSettingsSvc, config.Log)
+	systemHandler := systemHttp.NewSystemHandler(systemAdminSvc, onboardingSvc, config.Log)
 
 	// 4. Setup Routes
+	// Organizations are addressed as {slug}.TENANT_BASE_DOMAIN or with a
+	// /o/{slug} prefix; both are resolved before routing.
+	config.Router.Use(middleware.ResolveTenant(tenantResolver, config.Config.GetString("TENANT_BASE_DOMAIN")))
+	config.Router.Get("/.well-known/jwks.json", auth.JWKSHandler(tokenProvider))
+
 	config.Router.Route("/api/v1", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Mount("/auth", userHandler.PublicRoutes())
 		})
 
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware(tokenProvider))
+			r.Use(middleware.AuthMiddleware(tokenProvider, serviceAccountService))
+			r.Use(middleware.RequireTenantMatch)
+			r.Use(middleware.RequireActiveOrganization(orgRepo))
+			r.Use(middleware.LoadPrincipal(userRepo))
+			r.Use(middleware.AuditImpersonation(impersonationRepo, config.Log))
 
 			r.Mount("/users", userHandler.ProtectedRoutes())
+			r.Mount("/roles", roleHandler.ProtectedRoutes())
+			r.Mount("/service-accounts", serviceAccountHandler.ProtectedRoutes())
 			r.Mount("/events", eventHandler.ProtectedRoutes())
 			r.Mount("/assessments", assessmentHandler.ProtectedRoutes())
+			r.Mount("/guardian", guardianHandler.ProtectedRoutes())
+			r.Mount("/roster", rosterHandler.ProtectedRoutes())
 			r.Mount("/attachments", attachmentHandler.ProtectedRoutes())
+			r.Mount("/organizations", organizationHandler.ProtectedRoutes(middleware.RequireSystemAdmin(orgRepo)))
+
+			// Cross-tenant administration for superusers of the system
+			// organization only.
+			r.With(middleware.RequireSystemAdmin(orgRepo)).Mount("/system", systemHandler.ProtectedRoutes())
 		})
 	})
 
+	// Identity providers provision users and groups with an API key scoped
+	// to scim:provision.
+	config.Router.Route("/scim/v2", func(r chi.Router) {
+		r.Use(middleware.AuthMiddleware(tokenProvider, serviceAccountService))
+		r.Use(middleware.RequireTenantMatch)
+		r.Use(middleware.RequireActiveOrganization(orgRepo))
+		r.Use(middleware.LoadPrincipal(userRepo))
+		r.Use(middleware.RequirePermission("scim", "provision"))
+
+		r.Mount("/", scimHandler.ProtectedRoutes())
+	})
+
 	// Serve uploaded files as static content
 	localPath := config.Config.GetString("STORAGE_LOCAL_PATH")
 	if localPath == "" {
@@ -31,3 +31,28 @@ type StudentAssessmentsResponse struct {
 	Summary     AssessmentSummary `json:"summary"`
 	Assessments []AssessmentItem  `json:"assessments"`
 }
+
+// GradeItem is a single graded assessment
+type GradeItem struct {
+	AssessmentID uuid.UUID  `json:"assessment_id"`
+	Subject      string     `json:"subject"`
+	Title        string     `json:"title"`
+	Type         string     `json:"type"`
+	SubType      string     `json:"sub_type"`
+	Score        float32    `json:"score"`
+	DueDate      time.Time  `json:"due_date"`
+	SubmittedAt  *time.Time `json:"submitted_at,omitempty"`
+}
+
+// SubjectGrade is the average score in one subject
+type SubjectGrade struct {
+	Subject string  `json:"subject"`
+	Average float32 `json:"average"`
+	Graded  int     `json:"graded"`
+}
+
+// StudentGradesResponse is the response structure for the student grades endpoint
+type StudentGradesResponse struct {
+	Subjects []SubjectGrade `json:"subjects"`
+	Grades   []GradeItem    `json:"grades"`
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/domain"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
 	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
 	"github.com/go-chi/chi/v5"
@@ -33,7 +34,8 @@ func (h *AssessmentHandler) PublicRoutes() chi.Router {
 func (h *AssessmentHandler) ProtectedRoutes() chi.Router {
 	r := chi.NewRouter()
 
-	r.Get("/student", h.GetStudentAssessments)
+	r.With(middleware.RequirePermission("assessment", "read")).Get("/student", h.GetStudentAssessments)
+	r.With(middleware.RequirePermission("assessment", "read")).Get("/student/grades", h.GetStudentGrades)
 
 	return r
 }
@@ -46,7 +48,39 @@ func (h *AssessmentHandler) GetStudentAssessments(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Parse query parameters
+	filter := ParseStudentAssessmentFilter(r)
+
+	result, err := h.assessmentService.GetStudentAssessments(r.Context(), userID, filter)
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to get student assessments")
+		response.InternalServerError(w, err.Error())
+		return
+	}
+
+	response.OK(w, result)
+}
+
+func (h *AssessmentHandler) GetStudentGrades(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		h.log.Warn("user not found in context for get student grades")
+		response.Unauthorized(w, "User not found in context")
+		return
+	}
+
+	result, err := h.assessmentService.GetStudentGrades(r.Context(), userID, ParseStudentAssessmentFilter(r))
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to get student grades")
+		response.InternalServerError(w, err.Error())
+		return
+	}
+
+	response.OK(w, result)
+}
+
+// ParseStudentAssessmentFilter reads the type, start_date, end_date, limit and
+// offset query parameters. Malformed values are ignored.
+func ParseStudentAssessmentFilter(r *http.Request) domain.StudentAssessmentFilter {
 	filter := domain.StudentAssessmentFilter{
 		Limit:  20, // default
 		Offset: 0,
@@ -86,12 +120,5 @@ func (h *AssessmentHandler) GetStudentAssessments(w http.ResponseWriter, r *http
 		}
 	}
 
-	result, err := h.assessmentService.GetStudentAssessments(r.Context(), userID, filter)
-	if err != nil {
-		h.log.WithError(err).WithField("user_id", userID).Error("failed to get student assessments")
-		response.InternalServerError(w, err.Error())
-		return
-	}
-
-	response.OK(w, result)
+	return filter
 }
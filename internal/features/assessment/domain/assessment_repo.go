@@ -10,4 +10,5 @@ type AssessmentRepo interface {
 	Create(ctx context.Context, assessment *Assessment) error
 	GetStudentAssessments(ctx context.Context, userID uuid.UUID, filter StudentAssessmentFilter) ([]StudentAssessmentItem, error)
 	GetStudentAssessmentSummary(ctx context.Context, userID uuid.UUID, filter StudentAssessmentFilter) (*StudentAssessmentSummary, error)
+	GetStudentGrades(ctx context.Context, userID uuid.UUID, filter StudentAssessmentFilter) ([]StudentGrade, error)
 }
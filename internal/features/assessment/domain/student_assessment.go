@@ -44,3 +44,46 @@ type StudentAssessmentSummary struct {
 	Done      int
 	Overdue   int
 }
+
+// StudentGrade is a graded submission of a student
+type StudentGrade struct {
+	AssessmentID uuid.UUID
+	Subject      string
+	Title        string
+	Type         AssessmentType
+	SubType      AssessmentSubType
+	Score        float32
+	DueDate      time.Time
+	SubmittedAt  *time.Time
+}
+
+// SubjectGradeSummary is the average score of a student in one subject
+type SubjectGradeSummary struct {
+	Subject string
+	Average float32
+	Graded  int
+}
+
+// SummariseGrades averages grades per subject, keeping the order in which
+// subjects first appear.
+func SummariseGrades(grades []StudentGrade) []SubjectGradeSummary {
+	var summaries []SubjectGradeSummary
+	index := make(map[string]int)
+	totals := make(map[string]float64)
+
+	for _, g := range grades {
+		i, ok := index[g.Subject]
+		if !ok {
+			i = len(summaries)
+			index[g.Subject] = i
+			summaries = append(summaries, SubjectGradeSummary{Subject: g.Subject})
+		}
+		summaries[i].Graded++
+		totals[g.Subject] += float64(g.Score)
+	}
+
+	for i := range summaries {
+		summaries[i].Average = float32(totals[summaries[i].Subject] / float64(summaries[i].Graded))
+	}
+	return summaries
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummariseGrades(t *testing.T) {
+	tests := []struct {
+		name   string
+		grades []StudentGrade
+		want   []SubjectGradeSummary
+	}{
+		{
+			name:   "no grades",
+			grades: nil,
+			want:   nil,
+		},
+		{
+			name: "single subject",
+			grades: []StudentGrade{
+				{Subject: "Math", Score: 80},
+				{Subject: "Math", Score: 90},
+			},
+			want: []SubjectGradeSummary{{Subject: "Math", Average: 85, Graded: 2}},
+		},
+		{
+			name: "keeps first-seen subject order",
+			grades: []StudentGrade{
+				{Subject: "Physics", Score: 70},
+				{Subject: "Math", Score: 100},
+				{Subject: "Physics", Score: 80},
+			},
+			want: []SubjectGradeSummary{
+				{Subject: "Physics", Average: 75, Graded: 2},
+				{Subject: "Math", Average: 100, Graded: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SummariseGrades(tt.grades)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SummariseGrades() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,16 +8,17 @@ import (
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type AssessmentRepoPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewAssessmentRepoPostgres(db *sql.DB, log *logrus.Logger) domain.AssessmentRepo {
+func NewAssessmentRepoPostgres(db database.DB, log *logrus.Logger) domain.AssessmentRepo {
 	return &AssessmentRepoPostgres{
 		db:  db,
 		log: log,
@@ -237,5 +238,88 @@ func (r *AssessmentRepoPostgres) GetStudentAssessmentSummary(ctx context.Context
 	return &summary, nil
 }
 
+func (r *AssessmentRepoPostgres) GetStudentGrades(ctx context.Context, userID uuid.UUID, filter domain.StudentAssessmentFilter) ([]domain.StudentGrade, error) {
+	var args []interface{}
+	argIndex := 1
+
+	query := `
+		SELECT 
+			a.id,
+			COALESCE(subj.name, '') as subject,
+			a.title,
+			a.assessment_type,
+			a.assessment_sub_type,
+			s.final_score,
+			a.due_date,
+			s.submitted_at
+		FROM submissions s
+		INNER JOIN assessments a ON s.assessment_id = a.id
+		INNER JOIN courses cr ON a.course_id = cr.id
+		LEFT JOIN subjects subj ON cr.subject_id = subj.id
+		WHERE s.user_id = $1 AND s.final_score IS NOT NULL AND a.deleted_at IS NULL`
+
+	args = append(args, userID)
+	argIndex++
+
+	if filter.Type != nil {
+		query += fmt.Sprintf(" AND a.assessment_type = $%d", argIndex)
+		args = append(args, string(*filter.Type))
+		argIndex++
+	}
+
+	if filter.StartDate != nil {
+		query += fmt.Sprintf(" AND a.due_date >= $%d", argIndex)
+		args = append(args, *filter.StartDate)
+		argIndex++
+	}
+
+	if filter.EndDate != nil {
+		query += fmt.Sprintf(" AND a.due_date <= $%d", argIndex)
+		args = append(args, *filter.EndDate)
+		argIndex++
+	}
+
+	query += " ORDER BY subject ASC, a.due_date ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to query student grades")
+		return nil, fmt.Errorf("failed to query student grades: %w", err)
+	}
+	defer rows.Close()
+
+	var grades []domain.StudentGrade
+	for rows.Next() {
+		var g domain.StudentGrade
+		var assessmentType string
+		var subType string
+
+		if err := rows.Scan(
+			&g.AssessmentID,
+			&g.Subject,
+			&g.Title,
+			&assessmentType,
+			&subType,
+			&g.Score,
+			&g.DueDate,
+			&g.SubmittedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan student grade: %w", err)
+		}
+
+		g.Type = domain.AssessmentType(assessmentType)
+		g.SubType = domain.AssessmentSubType(subType)
+
+		grades = append(grades, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("error iterating student grades")
+		return nil, fmt.Errorf("error iterating student grades: %w", err)
+	}
+
+	return grades, nil
+}
+
 // ensure time package is used
 var _ = time.Now
@@ -62,3 +62,40 @@ func (s *assessmentService) GetStudentAssessments(ctx context.Context, userID uu
 		Assessments: assessments,
 	}, nil
 }
+
+func (s *assessmentService) GetStudentGrades(ctx context.Context, userID uuid.UUID, filter domain.StudentAssessmentFilter) (*dto.StudentGradesResponse, error) {
+	grades, err := s.repo.GetStudentGrades(ctx, userID, filter)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to get student grades")
+		return nil, err
+	}
+
+	items := make([]dto.GradeItem, len(grades))
+	for i, g := range grades {
+		items[i] = dto.GradeItem{
+			AssessmentID: g.AssessmentID,
+			Subject:      g.Subject,
+			Title:        g.Title,
+			Type:         string(g.Type),
+			SubType:      string(g.SubType),
+			Score:        g.Score,
+			DueDate:      g.DueDate,
+			SubmittedAt:  g.SubmittedAt,
+		}
+	}
+
+	summaries := domain.SummariseGrades(grades)
+	subjects := make([]dto.SubjectGrade, len(summaries))
+	for i, sg := range summaries {
+		subjects[i] = dto.SubjectGrade{
+			Subject: sg.Subject,
+			Average: sg.Average,
+			Graded:  sg.Graded,
+		}
+	}
+
+	return &dto.StudentGradesResponse{
+		Subjects: subjects,
+		Grades:   items,
+	}, nil
+}
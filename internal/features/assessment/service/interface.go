@@ -11,4 +11,5 @@ import (
 // AssessmentService defines the interface for assessment business logic
 type AssessmentService interface {
 	GetStudentAssessments(ctx context.Context, userID uuid.UUID, filter domain.StudentAssessmentFilter) (*dto.StudentAssessmentsResponse, error)
+	GetStudentGrades(ctx context.Context, userID uuid.UUID, filter domain.StudentAssessmentFilter) (*dto.StudentGradesResponse, error)
 }
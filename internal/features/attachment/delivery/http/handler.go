@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/attachment/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
 	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
 	"github.com/go-chi/chi/v5"
@@ -26,11 +27,11 @@ func NewAttachmentHandler(attachmentService service.AttachmentService, log *logr
 func (h *AttachmentHandler) ProtectedRoutes() chi.Router {
 	r := chi.NewRouter()
 
-	r.Post("/assessment/{assessmentID}", h.UploadAssessmentAttachment)
-	r.Post("/submission/{submissionID}", h.UploadSubmissionAttachment)
-	r.Delete("/{id}", h.DeleteAttachment)
-	r.Get("/assessment/{assessmentID}", h.ListAssessmentAttachments)
-	r.Get("/submission/{submissionID}", h.ListSubmissionAttachments)
+	r.With(middleware.RequirePermission("attachment", "create")).Post("/assessment/{assessmentID}", h.UploadAssessmentAttachment)
+	r.With(middleware.RequirePermission("attachment", "create")).Post("/submission/{submissionID}", h.UploadSubmissionAttachment)
+	r.With(middleware.RequirePermission("attachment", "delete")).Delete("/{id}", h.DeleteAttachment)
+	r.With(middleware.RequirePermission("attachment", "read")).Get("/assessment/{assessmentID}", h.ListAssessmentAttachments)
+	r.With(middleware.RequirePermission("attachment", "read")).Get("/submission/{submissionID}", h.ListSubmissionAttachments)
 
 	return r
 }
@@ -7,16 +7,17 @@ import (
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/attachment/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type AttachmentRepoPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewAttachmentRepoPostgres(db *sql.DB, log *logrus.Logger) domain.AttachmentRepo {
+func NewAttachmentRepoPostgres(db database.DB, log *logrus.Logger) domain.AttachmentRepo {
 	return &AttachmentRepoPostgres{
 		db:  db,
 		log: log,
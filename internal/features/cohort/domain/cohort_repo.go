@@ -9,4 +9,5 @@ type CohortRepository interface {
     Create(ctx context.Context, cohort *Cohort) error
     GetIDsByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
     GetByID(ctx context.Context, id uuid.UUID) (*Cohort, error)
+    ListByAcademicPeriod(ctx context.Context, academicPeriodID uuid.UUID) ([]Cohort, error)
 }
\ No newline at end of file
@@ -2,18 +2,18 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/cohort/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 )
 
 type CohortMemberRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewCohortMemberRepository(db *sql.DB) domain.CohortMemberRepository {
+func NewCohortMemberRepository(db database.DB) domain.CohortMemberRepository {
 	return &CohortMemberRepoPostgres{db: db}
 }
 
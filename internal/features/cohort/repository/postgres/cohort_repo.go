@@ -6,16 +6,17 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/cohort/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type CohortRepositoryPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewCohortRepository(db *sql.DB, log *logrus.Logger) domain.CohortRepository {
+func NewCohortRepository(db database.DB, log *logrus.Logger) domain.CohortRepository {
 	return &CohortRepositoryPostgres{
 		db:  db,
 		log: log,
@@ -104,3 +105,33 @@ func (r *CohortRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*
 
 	return cohort, nil
 }
+
+func (r *CohortRepositoryPostgres) ListByAcademicPeriod(ctx context.Context, academicPeriodID uuid.UUID) ([]domain.Cohort, error) {
+	query := `
+			SELECT id, organization_id, academic_period_id, education_level_id, name
+			FROM cohorts
+			WHERE academic_period_id = $1 AND deleted_at IS NULL
+			ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, academicPeriodID)
+	if err != nil {
+		r.log.WithError(err).WithField("academic_period_id", academicPeriodID).Error("failed to list cohorts by academic period")
+		return nil, fmt.Errorf("failed to list cohorts: %w", err)
+	}
+	defer rows.Close()
+
+	var cohorts []domain.Cohort
+	for rows.Next() {
+		var c domain.Cohort
+		if err := rows.Scan(&c.ID, &c.OrganizationID, &c.AcademicPeriodID, &c.EducationLevelID, &c.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan cohort: %w", err)
+		}
+		cohorts = append(cohorts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohorts: %w", err)
+	}
+
+	return cohorts, nil
+}
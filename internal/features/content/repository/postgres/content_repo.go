@@ -7,14 +7,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/content/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type ContentRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewContentRepository(db *sql.DB) domain.ContentRepository {
+func NewContentRepository(db database.DB) domain.ContentRepository {
 	return &ContentRepoPostgres{db: db}
 }
 
@@ -6,14 +6,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/course/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type CourseRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewCourseRepository(db *sql.DB) domain.CourseRepository {
+func NewCourseRepository(db database.DB) domain.CourseRepository {
 	return &CourseRepoPostgres{db: db}
 }
 
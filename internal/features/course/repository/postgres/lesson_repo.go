@@ -6,14 +6,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/course/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type LessonRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewLessonRepository(db *sql.DB) domain.LessonRepository {
+func NewLessonRepository(db database.DB) domain.LessonRepository {
 	return &LessonRepoPostgres{db: db}
 }
 
@@ -6,14 +6,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/course/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type ModuleRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewModuleRepository(db *sql.DB) domain.ModuleRepository {
+func NewModuleRepository(db database.DB) domain.ModuleRepository {
 	return &ModuleRepoPostgres{db: db}
 }
 
@@ -6,14 +6,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/education_level/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type EducationLevelRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewEducationLevelRepository(db *sql.DB) domain.EducationLevelRepository {
+func NewEducationLevelRepository(db database.DB) domain.EducationLevelRepository {
 	return &EducationLevelRepoPostgres{db: db}
 }
 
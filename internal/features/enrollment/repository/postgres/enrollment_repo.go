@@ -2,20 +2,20 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/enrollment/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type EnrollmentRepositoryPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewEnrollmentRepository(db *sql.DB, log *logrus.Logger) domain.EnrollmentRepository {
+func NewEnrollmentRepository(db database.DB, log *logrus.Logger) domain.EnrollmentRepository {
 	return &EnrollmentRepositoryPostgres{
 		db:  db,
 		log: log,
@@ -9,6 +9,7 @@ import (
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/delivery/dto"
 	e "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/domain"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
 	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
 	"github.com/go-chi/chi/v5"
@@ -36,11 +37,16 @@ func (h *EventHandler) PublicRoutes() chi.Router {
 func (h *EventHandler) ProtectedRoutes() chi.Router {
 	r := chi.NewRouter()
 
-	r.Post("/", h.CreateEvent)
-	r.Get("/calendar", h.GetCalendar)
-	r.Get("/sections/{sectionID}/schedule", h.GetSectionSchedule)
-	r.Get("/announcements", h.GetAnnouncements)
-	r.Get("/", h.GetEvents) // General list, maybe for admin or global view
+	r.With(middleware.RequirePermission("event", "create")).Post("/", h.CreateEvent)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RequirePermission("event", "read"))
+
+		r.Get("/calendar", h.GetCalendar)
+		r.Get("/sections/{sectionID}/schedule", h.GetSectionSchedule)
+		r.Get("/announcements", h.GetAnnouncements)
+		r.Get("/", h.GetEvents) // General list, maybe for admin or global view
+	})
 	return r
 }
 
@@ -9,17 +9,18 @@ import (
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 type EventRepoPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewEventRepository(db *sql.DB, log *logrus.Logger) domain.EventRepository {
+func NewEventRepository(db database.DB, log *logrus.Logger) domain.EventRepository {
 	return &EventRepoPostgres{
 		db:  db,
 		log: log,
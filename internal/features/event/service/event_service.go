@@ -24,6 +24,7 @@ type eventService struct {
 	enrollmentRepo en.EnrollmentRepository
 	cohortRepo     c.CohortRepository
 	sectionRepo    sec.SectionRepository
+	settings       o.SettingsProvider
 	redis          *redis.Client
 	log            *logrus.Logger
 }
@@ -34,6 +35,7 @@ func NewEventService(
 	enrollmentRepo en.EnrollmentRepository,
 	cohortRepo c.CohortRepository,
 	sectionRepo sec.SectionRepository,
+	settings o.SettingsProvider,
 	redis *redis.Client,
 	log *logrus.Logger,
 ) EventService {
@@ -43,12 +45,19 @@ func NewEventService(
 		enrollmentRepo: enrollmentRepo,
 		cohortRepo:     cohortRepo,
 		sectionRepo:    sectionRepo,
+		settings:       settings,
 		redis:          redis,
 		log:            log,
 	}
 }
 
 func (s *eventService) CreateEvent(ctx context.Context, e *e.Event) (*e.Event, error) {
+	if e.Color == "" {
+		if settings, err := s.settings.Get(ctx, e.OrganizationID); err == nil {
+			e.Color = settings.DefaultEventColor
+		}
+	}
+
 	if err := e.Validate(); err != nil {
 		s.log.WithError(err).Warn("event validation failed")
 		return nil, err
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+type ChildResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
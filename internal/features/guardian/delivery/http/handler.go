@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	assessmentHttp "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/delivery/http"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/guardian/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/guardian/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
+	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type GuardianHandler struct {
+	portal service.ParentPortal
+	log    *logrus.Logger
+}
+
+func NewGuardianHandler(portal service.ParentPortal, log *logrus.Logger) *GuardianHandler {
+	return &GuardianHandler{
+		portal: portal,
+		log:    log,
+	}
+}
+
+func (h *GuardianHandler) ProtectedRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(middleware.RequirePermission("child", "read"))
+
+	r.Get("/children", h.ListChildren)
+	r.Get("/children/{childID}/calendar", h.GetCalendar)
+	r.Get("/children/{childID}/assessments", h.GetAssessments)
+	r.Get("/children/{childID}/grades", h.GetGrades)
+
+	return r
+}
+
+func (h *GuardianHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	children, err := h.portal.ListChildren(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to list children")
+		response.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := make([]dto.ChildResponse, 0, len(children))
+	for _, child := range children {
+		res = append(res, dto.ChildResponse{
+			ID:        child.ID,
+			Email:     child.Email,
+			FirstName: child.FirstName,
+			LastName:  child.LastName,
+		})
+	}
+
+	response.OK(w, res)
+}
+
+func (h *GuardianHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	childID, ok := childIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	startStr := r.URL.Query().Get("start")
+	endStr := r.URL.Query().Get("end")
+
+	if startStr == "" || endStr == "" {
+		response.BadRequest(w, "start and end query parameters are required")
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, startStr)
+	if err != nil {
+		response.BadRequest(w, "Invalid start time format (RFC3339 required)")
+		return
+	}
+
+	endTime, err := time.Parse(time.RFC3339, endStr)
+	if err != nil {
+		response.BadRequest(w, "Invalid end time format (RFC3339 required)")
+		return
+	}
+
+	events, err := h.portal.GetCalendar(r.Context(), childID, startTime, endTime)
+	if err != nil {
+		h.writeError(w, err, childID, "failed to get calendar for child")
+		return
+	}
+
+	response.OK(w, events)
+}
+
+func (h *GuardianHandler) GetAssessments(w http.ResponseWriter, r *http.Request) {
+	childID, ok := childIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.portal.GetAssessments(r.Context(), childID, assessmentHttp.ParseStudentAssessmentFilter(r))
+	if err != nil {
+		h.writeError(w, err, childID, "failed to get assessments for child")
+		return
+	}
+
+	response.OK(w, result)
+}
+
+func (h *GuardianHandler) GetGrades(w http.ResponseWriter, r *http.Request) {
+	childID, ok := childIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.portal.GetGrades(r.Context(), childID, assessmentHttp.ParseStudentAssessmentFilter(r))
+	if err != nil {
+		h.writeError(w, err, childID, "failed to get grades for child")
+		return
+	}
+
+	response.OK(w, result)
+}
+
+func (h *GuardianHandler) writeError(w http.ResponseWriter, err error, childID uuid.UUID, msg string) {
+	if errors.Is(err, service.ErrChildNotFound) {
+		response.NotFound(w, err.Error())
+		return
+	}
+
+	h.log.WithError(err).WithField("child_id", childID).Error(msg)
+	response.InternalServerError(w, err.Error())
+}
+
+func childIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	childID, err := uuid.Parse(chi.URLParam(r, "childID"))
+	if err != nil {
+		response.BadRequest(w, "Invalid child ID")
+		return uuid.Nil, false
+	}
+	return childID, true
+}
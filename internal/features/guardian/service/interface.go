@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	assessmentDto "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/delivery/dto"
+	assessmentDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/domain"
+	eventDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/google/uuid"
+)
+
+// ParentPortal is what a signed-in guardian can see about their children.
+// Every method taking a childID fails with ErrChildNotFound unless the child
+// is linked to the guardian in the context.
+type ParentPortal interface {
+	ListChildren(ctx context.Context) ([]userDomain.User, error)
+	GetCalendar(ctx context.Context, childID uuid.UUID, start, end time.Time) ([]*eventDomain.Event, error)
+	GetAssessments(ctx context.Context, childID uuid.UUID, filter assessmentDomain.StudentAssessmentFilter) (*assessmentDto.StudentAssessmentsResponse, error)
+	GetGrades(ctx context.Context, childID uuid.UUID, filter assessmentDomain.StudentAssessmentFilter) (*assessmentDto.StudentGradesResponse, error)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	assessmentDto "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/delivery/dto"
+	assessmentDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/domain"
+	assessmentService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/assessment/service"
+	eventDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/domain"
+	eventService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/event/service"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	userService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrChildNotFound is returned for children that do not exist as well as for
+// children linked to someone else, so guardians cannot probe for students.
+var ErrChildNotFound = userService.ErrChildNotFound
+
+type parentPortal struct {
+	guardians   userService.Guardianship
+	events      eventService.EventService
+	assessments assessmentService.AssessmentService
+	log         *logrus.Logger
+}
+
+func NewParentPortal(
+	gs userService.Guardianship,
+	es eventService.EventService,
+	as assessmentService.AssessmentService,
+	log *logrus.Logger,
+) ParentPortal {
+	return &parentPortal{
+		guardians:   gs,
+		events:      es,
+		assessments: as,
+		log:         log,
+	}
+}
+
+func (s *parentPortal) ListChildren(ctx context.Context) ([]userDomain.User, error) {
+	guardianID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, errors.New("user id not found")
+	}
+	return s.guardians.ListChildren(ctx, guardianID)
+}
+
+func (s *parentPortal) GetCalendar(ctx context.Context, childID uuid.UUID, start, end time.Time) ([]*eventDomain.Event, error) {
+	if err := s.authorize(ctx, childID); err != nil {
+		return nil, err
+	}
+	return s.events.GetCalendarForUser(ctx, childID, start, end)
+}
+
+func (s *parentPortal) GetAssessments(ctx context.Context, childID uuid.UUID, filter assessmentDomain.StudentAssessmentFilter) (*assessmentDto.StudentAssessmentsResponse, error) {
+	if err := s.authorize(ctx, childID); err != nil {
+		return nil, err
+	}
+	return s.assessments.GetStudentAssessments(ctx, childID, filter)
+}
+
+func (s *parentPortal) GetGrades(ctx context.Context, childID uuid.UUID, filter assessmentDomain.StudentAssessmentFilter) (*assessmentDto.StudentGradesResponse, error) {
+	if err := s.authorize(ctx, childID); err != nil {
+		return nil, err
+	}
+	return s.assessments.GetStudentGrades(ctx, childID, filter)
+}
+
+func (s *parentPortal) authorize(ctx context.Context, childID uuid.UUID) error {
+	guardianID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return errors.New("user id not found")
+	}
+
+	_, err := s.guardians.GetChild(ctx, guardianID, childID)
+	return err
+}
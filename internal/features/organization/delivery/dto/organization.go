@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RegisterOrganizationRequest struct {
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name    *string `json:"name"`
+	Type    *string `json:"type"`
+	Address *string `json:"address"`
+}
+
+type OrganizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Type      string    `json:"type"`
+	Address   string    `json:"address"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AcademicPeriodRequest takes dates as YYYY-MM-DD.
+type AcademicPeriodRequest struct {
+	Name      string `json:"name"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type AcademicPeriodResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	StartDate        string     `json:"start_date"`
+	EndDate          string     `json:"end_date"`
+	Status           string     `json:"status"`
+	IsActive         bool       `json:"is_active"`
+	PreviousPeriodID *uuid.UUID `json:"previous_period_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// RolloverRequest describes the next period. level_order lists education
+// level IDs from the first to the last grade; promote_to maps a cohort to
+// the cohort whose copy receives its students.
+type RolloverRequest struct {
+	Name       string                  `json:"name"`
+	StartDate  string                  `json:"start_date"`
+	EndDate    string                  `json:"end_date"`
+	LevelOrder []uuid.UUID             `json:"level_order"`
+	PromoteTo  map[uuid.UUID]uuid.UUID `json:"promote_to"`
+	Preview    bool                    `json:"preview"`
+	Activate   bool                    `json:"activate"`
+}
+
+type RolloverResponse struct {
+	Preview   bool                     `json:"preview"`
+	Period    RolloverPeriodResponse   `json:"period"`
+	Summary   RolloverSummary          `json:"summary"`
+	Cohorts   []RolloverCohortResponse `json:"cohorts"`
+	Graduates []uuid.UUID              `json:"graduates"`
+	Unplaced  []uuid.UUID              `json:"unplaced"`
+}
+
+// RolloverPeriodResponse has no ID in a preview.
+type RolloverPeriodResponse struct {
+	ID               *uuid.UUID `json:"id,omitempty"`
+	Name             string     `json:"name"`
+	StartDate        string     `json:"start_date"`
+	EndDate          string     `json:"end_date"`
+	Status           string     `json:"status"`
+	PreviousPeriodID *uuid.UUID `json:"previous_period_id"`
+}
+
+type RolloverSummary struct {
+	Cohorts     int `json:"cohorts"`
+	Sections    int `json:"sections"`
+	Promotions  int `json:"promotions"`
+	Enrollments int `json:"enrollments"`
+	Graduates   int `json:"graduates"`
+	Unplaced    int `json:"unplaced"`
+}
+
+type RolloverCohortResponse struct {
+	SourceID         uuid.UUID                 `json:"source_id"`
+	Name             string                    `json:"name"`
+	EducationLevelID uuid.UUID                 `json:"education_level_id"`
+	Students         int                       `json:"students"`
+	Sections         []RolloverSectionResponse `json:"sections"`
+}
+
+type RolloverSectionResponse struct {
+	SourceID uuid.UUID `json:"source_id"`
+	Name     string    `json:"name"`
+	Teachers int       `json:"teachers"`
+	Courses  int       `json:"courses"`
+	Students int       `json:"students"`
+}
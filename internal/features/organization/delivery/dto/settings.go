@@ -0,0 +1,30 @@
+package dto
+
+// Settings is used both to read and to replace an organization's settings.
+type Settings struct {
+	Timezone          string               `json:"timezone"`
+	Locale            string               `json:"locale"`
+	WeekStartDay      string               `json:"week_start_day"`
+	GradingScale      []GradeBand          `json:"grading_scale"`
+	DefaultEventColor string               `json:"default_event_color"`
+	LateSubmission    LateSubmissionPolicy `json:"late_submission"`
+	Branding          Branding             `json:"branding"`
+}
+
+type GradeBand struct {
+	Grade    string  `json:"grade"`
+	MinScore float64 `json:"min_score"`
+}
+
+type LateSubmissionPolicy struct {
+	Allowed            bool    `json:"allowed"`
+	GracePeriodMinutes int     `json:"grace_period_minutes"`
+	PenaltyPerDay      float64 `json:"penalty_per_day"`
+	MaxPenalty         float64 `json:"max_penalty"`
+}
+
+type Branding struct {
+	LogoURL        string `json:"logo_url"`
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+}
@@ -0,0 +1,305 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+func (h *OrganizationHandler) ListAcademicPeriods(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	periods, err := h.periodService.List(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err, "failed to list academic periods")
+		return
+	}
+
+	res := make([]dto.AcademicPeriodResponse, 0, len(periods))
+	for i := range periods {
+		res = append(res, toAcademicPeriodResponse(&periods[i]))
+	}
+
+	u.OK(w, res)
+}
+
+func (h *OrganizationHandler) GetAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+	periodID, ok := uuidParam(w, r, "periodID")
+	if !ok {
+		return
+	}
+
+	period, err := h.periodService.Get(r.Context(), orgID, periodID)
+	if err != nil {
+		h.writeError(w, err, "failed to get academic period")
+		return
+	}
+
+	u.OK(w, toAcademicPeriodResponse(period))
+}
+
+func (h *OrganizationHandler) CreateAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	input, ok := decodeAcademicPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	period, err := h.periodService.Create(r.Context(), orgID, input)
+	if err != nil {
+		h.writeError(w, err, "failed to create academic period")
+		return
+	}
+
+	u.Created(w, toAcademicPeriodResponse(period))
+}
+
+func (h *OrganizationHandler) UpdateAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+	periodID, ok := uuidParam(w, r, "periodID")
+	if !ok {
+		return
+	}
+
+	input, ok := decodeAcademicPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	period, err := h.periodService.Update(r.Context(), orgID, periodID, input)
+	if err != nil {
+		h.writeError(w, err, "failed to update academic period")
+		return
+	}
+
+	u.OK(w, toAcademicPeriodResponse(period))
+}
+
+func (h *OrganizationHandler) DeleteAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+	periodID, ok := uuidParam(w, r, "periodID")
+	if !ok {
+		return
+	}
+
+	if err := h.periodService.Delete(r.Context(), orgID, periodID); err != nil {
+		h.writeError(w, err, "failed to delete academic period")
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *OrganizationHandler) ActivateAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	h.changePeriodStatus(w, r, h.periodService.Activate, "failed to activate academic period")
+}
+
+func (h *OrganizationHandler) CloseAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	h.changePeriodStatus(w, r, h.periodService.Close, "failed to close academic period")
+}
+
+func (h *OrganizationHandler) ArchiveAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	h.changePeriodStatus(w, r, h.periodService.Archive, "failed to archive academic period")
+}
+
+func (h *OrganizationHandler) changePeriodStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error),
+	msg string,
+) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+	periodID, ok := uuidParam(w, r, "periodID")
+	if !ok {
+		return
+	}
+
+	period, err := change(r.Context(), orgID, periodID)
+	if err != nil {
+		h.writeError(w, err, msg)
+		return
+	}
+
+	u.OK(w, toAcademicPeriodResponse(period))
+}
+
+func (h *OrganizationHandler) RolloverAcademicPeriod(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+	periodID, ok := uuidParam(w, r, "periodID")
+	if !ok {
+		return
+	}
+
+	req := dto.RolloverRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		u.BadRequest(w, "Invalid start_date format (YYYY-MM-DD required)")
+		return
+	}
+	endDate, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		u.BadRequest(w, "Invalid end_date format (YYYY-MM-DD required)")
+		return
+	}
+
+	plan, err := h.periodService.Rollover(r.Context(), orgID, periodID, service.RolloverInput{
+		Name:       req.Name,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		LevelOrder: req.LevelOrder,
+		PromoteTo:  req.PromoteTo,
+		Preview:    req.Preview,
+		Activate:   req.Activate,
+	})
+	if err != nil {
+		h.writeError(w, err, "failed to roll over academic period")
+		return
+	}
+
+	res := toRolloverResponse(plan, req.Preview)
+	if req.Preview {
+		u.OK(w, res)
+		return
+	}
+	u.Created(w, res)
+}
+
+func decodeAcademicPeriod(w http.ResponseWriter, r *http.Request) (service.AcademicPeriodInput, bool) {
+	req := dto.AcademicPeriodRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return service.AcademicPeriodInput{}, false
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		u.BadRequest(w, "Invalid start_date format (YYYY-MM-DD required)")
+		return service.AcademicPeriodInput{}, false
+	}
+	endDate, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		u.BadRequest(w, "Invalid end_date format (YYYY-MM-DD required)")
+		return service.AcademicPeriodInput{}, false
+	}
+
+	return service.AcademicPeriodInput{
+		Name:      req.Name,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, true
+}
+
+func toAcademicPeriodResponse(p *domain.AcademicPeriod) dto.AcademicPeriodResponse {
+	return dto.AcademicPeriodResponse{
+		ID:               p.ID,
+		Name:             p.Name,
+		StartDate:        p.StartDate.Format(dateLayout),
+		EndDate:          p.EndDate.Format(dateLayout),
+		Status:           string(p.Status),
+		IsActive:         p.IsActive,
+		PreviousPeriodID: p.PreviousPeriodID,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+func toRolloverResponse(plan *domain.RolloverPlan, preview bool) dto.RolloverResponse {
+	p := plan.Period
+	res := dto.RolloverResponse{
+		Preview: preview,
+		Period: dto.RolloverPeriodResponse{
+			Name:             p.Name,
+			StartDate:        p.StartDate.Format(dateLayout),
+			EndDate:          p.EndDate.Format(dateLayout),
+			Status:           string(p.Status),
+			PreviousPeriodID: p.PreviousPeriodID,
+		},
+		Summary: dto.RolloverSummary{
+			Cohorts:     len(plan.Cohorts),
+			Sections:    plan.SectionCount(),
+			Promotions:  len(plan.Promotions),
+			Enrollments: plan.EnrollmentCount(),
+			Graduates:   len(plan.Graduates),
+			Unplaced:    len(plan.Unplaced),
+		},
+		Cohorts:   make([]dto.RolloverCohortResponse, 0, len(plan.Cohorts)),
+		Graduates: plan.Graduates,
+		Unplaced:  plan.Unplaced,
+	}
+	if !preview {
+		res.Period.ID = &p.ID
+	}
+	if res.Graduates == nil {
+		res.Graduates = []uuid.UUID{}
+	}
+	if res.Unplaced == nil {
+		res.Unplaced = []uuid.UUID{}
+	}
+
+	cohortStudents := make(map[uuid.UUID]int)
+	sectionStudents := make(map[uuid.UUID]int)
+	for _, pr := range plan.Promotions {
+		cohortStudents[pr.ToCohortID]++
+		if pr.ToSectionID != nil {
+			sectionStudents[*pr.ToSectionID]++
+		}
+	}
+
+	for _, c := range plan.Cohorts {
+		cohort := dto.RolloverCohortResponse{
+			SourceID:         c.SourceID,
+			Name:             c.Name,
+			EducationLevelID: c.EducationLevelID,
+			Students:         cohortStudents[c.ID],
+			Sections:         make([]dto.RolloverSectionResponse, 0, len(c.Sections)),
+		}
+		for _, s := range c.Sections {
+			cohort.Sections = append(cohort.Sections, dto.RolloverSectionResponse{
+				SourceID: s.SourceID,
+				Name:     s.Name,
+				Teachers: len(s.Staff),
+				Courses:  len(s.CourseIDs),
+				Students: sectionStudents[s.ID],
+			})
+		}
+		res.Cohorts = append(res.Cohorts, cohort)
+	}
+
+	return res
+}
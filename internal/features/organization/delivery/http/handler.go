@@ -0,0 +1,179 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type OrganizationHandler struct {
+	orgService      service.CRUD
+	periodService   service.AcademicPeriods
+	settingsService service.OrganizationSettings
+	log             *logrus.Logger
+}
+
+func NewOrganizationHandler(
+	orgService service.CRUD,
+	periodService service.AcademicPeriods,
+	settingsService service.OrganizationSettings,
+	log *logrus.Logger,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService:      orgService,
+		periodService:   periodService,
+		settingsService: settingsService,
+		log:             log,
+	}
+}
+
+// ProtectedRoutes lets members work on their own organization. Registering
+// and deleting organizations goes through requireSystemAdmin.
+func (h *OrganizationHandler) ProtectedRoutes(requireSystemAdmin func(http.Handler) http.Handler) chi.Router {
+	r := chi.NewRouter()
+
+	r.With(requireSystemAdmin).Post("/", h.RegisterOrganization)
+	r.With(middleware.RequirePermission("organization", "read")).Get("/{id}", h.GetOrganization)
+	r.With(middleware.RequirePermission("organization", "update")).Patch("/{id}", h.UpdateOrganization)
+	r.With(requireSystemAdmin).Delete("/{id}", h.DeleteOrganization)
+
+	r.With(middleware.RequirePermission("organization", "read")).Get("/{id}/settings", h.GetSettings)
+	r.With(middleware.RequirePermission("organization", "update")).Put("/{id}/settings", h.UpdateSettings)
+
+	r.With(middleware.RequirePermission("organization", "read")).Get("/{id}/academic-periods", h.ListAcademicPeriods)
+	r.With(middleware.RequirePermission("organization", "read")).Get("/{id}/academic-periods/{periodID}", h.GetAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Post("/{id}/academic-periods", h.CreateAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Put("/{id}/academic-periods/{periodID}", h.UpdateAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Delete("/{id}/academic-periods/{periodID}", h.DeleteAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Post("/{id}/academic-periods/{periodID}/activate", h.ActivateAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Post("/{id}/academic-periods/{periodID}/close", h.CloseAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Post("/{id}/academic-periods/{periodID}/archive", h.ArchiveAcademicPeriod)
+	r.With(middleware.RequirePermission("organization", "update")).Post("/{id}/academic-periods/{periodID}/rollover", h.RolloverAcademicPeriod)
+
+	return r
+}
+
+func (h *OrganizationHandler) RegisterOrganization(w http.ResponseWriter, r *http.Request) {
+	req := dto.RegisterOrganizationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	org, err := h.orgService.RegisterOrganization(r.Context(), req.Name, req.Slug, req.Address, domain.OrgType(req.Type))
+	if err != nil {
+		h.writeError(w, err, "failed to register organization")
+		return
+	}
+
+	u.Created(w, toOrganizationResponse(org))
+}
+
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.GetOrganization(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err, "failed to get organization")
+		return
+	}
+
+	u.OK(w, toOrganizationResponse(org))
+}
+
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := dto.UpdateOrganizationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	input := service.OrganizationUpdate{
+		Name:    req.Name,
+		Address: req.Address,
+	}
+	if req.Type != nil {
+		orgType := domain.OrgType(*req.Type)
+		input.Type = &orgType
+	}
+
+	org, err := h.orgService.UpdateOrganization(r.Context(), orgID, input)
+	if err != nil {
+		h.writeError(w, err, "failed to update organization")
+		return
+	}
+
+	u.OK(w, toOrganizationResponse(org))
+}
+
+func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.orgService.DeleteOrganization(r.Context(), orgID); err != nil {
+		h.writeError(w, err, "failed to delete organization")
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *OrganizationHandler) writeError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrAcademicPeriodNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrSystemOrganization):
+		u.Forbidden(w, err.Error())
+	case errors.Is(err, service.ErrSlugTaken), errors.Is(err, service.ErrAcademicPeriodActive), errors.Is(err, service.ErrAcademicPeriodInUse),
+		errors.Is(err, service.ErrAcademicPeriodArchived), errors.Is(err, service.ErrAlreadyRolledOver),
+		errors.Is(err, domain.ErrInvalidPeriodTransition):
+		u.Error(w, http.StatusConflict, "CONFLICT", err.Error())
+	case errors.Is(err, domain.ErrInvalidOrganization), errors.Is(err, domain.ErrInvalidAcademicPeriod),
+		errors.Is(err, domain.ErrInvalidSettings), errors.Is(err, service.ErrInvalidRollover):
+		u.UnprocessableEntity(w, err.Error())
+	default:
+		h.log.WithError(err).Error(msg)
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func uuidParam(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, name))
+	if err != nil {
+		u.BadRequest(w, "Invalid "+name)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toOrganizationResponse(org *domain.Organization) dto.OrganizationResponse {
+	return dto.OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		Type:      string(org.Type),
+		Address:   org.Address,
+		IsActive:  org.IsActive,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
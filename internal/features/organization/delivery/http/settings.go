@@ -0,0 +1,79 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+)
+
+func (h *OrganizationHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	settings, err := h.settingsService.View(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err, "failed to get organization settings")
+		return
+	}
+
+	u.OK(w, toSettingsDTO(settings))
+}
+
+// UpdateSettings replaces the whole settings document; clients send back
+// what GetSettings returned with their changes applied.
+func (h *OrganizationHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := dto.Settings{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	settings, err := h.settingsService.Update(r.Context(), orgID, fromSettingsDTO(req))
+	if err != nil {
+		h.writeError(w, err, "failed to update organization settings")
+		return
+	}
+
+	u.OK(w, toSettingsDTO(settings))
+}
+
+func toSettingsDTO(s *domain.Settings) dto.Settings {
+	res := dto.Settings{
+		Timezone:          s.Timezone,
+		Locale:            s.Locale,
+		WeekStartDay:      s.WeekStartDay,
+		GradingScale:      make([]dto.GradeBand, 0, len(s.GradingScale)),
+		DefaultEventColor: s.DefaultEventColor,
+		LateSubmission:    dto.LateSubmissionPolicy(s.LateSubmission),
+		Branding:          dto.Branding(s.Branding),
+	}
+	for _, band := range s.GradingScale {
+		res.GradingScale = append(res.GradingScale, dto.GradeBand(band))
+	}
+	return res
+}
+
+func fromSettingsDTO(req dto.Settings) domain.Settings {
+	s := domain.Settings{
+		Timezone:          req.Timezone,
+		Locale:            req.Locale,
+		WeekStartDay:      req.WeekStartDay,
+		DefaultEventColor: req.DefaultEventColor,
+		LateSubmission:    domain.LateSubmissionPolicy(req.LateSubmission),
+		Branding:          domain.Branding(req.Branding),
+	}
+	for _, band := range req.GradingScale {
+		s.GradingScale = append(s.GradingScale, domain.GradeBand(band))
+	}
+	return s
+}
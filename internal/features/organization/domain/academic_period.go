@@ -1,26 +1,83 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared"
+	"github.com/google/uuid"
+)
+
+type PeriodStatus string
+
+const (
+	PeriodPlanned  PeriodStatus = "planned"
+	PeriodActive   PeriodStatus = "active"
+	PeriodClosed   PeriodStatus = "closed"
+	PeriodArchived PeriodStatus = "archived"
 )
 
 type AcademicPeriod struct {
 	shared.Base
 
-	Name string // e.g., "2025/2026 ganjil"
+	Name      string // e.g., "2025/2026 ganjil"
 	StartDate time.Time
-	EndDate time.Time
+	EndDate   time.Time
 
+	Status   PeriodStatus
 	IsActive bool
+
+	// PreviousPeriodID is set on periods created by a rollover.
+	PreviousPeriodID *uuid.UUID
+}
+
+var (
+	ErrInvalidAcademicPeriod   = errors.New("invalid academic period")
+	ErrInvalidPeriodTransition = errors.New("academic period cannot move to this status")
+)
+
+// periodTransitions lists where each status may go. A closed period can be
+// reopened; an archived one is final.
+var periodTransitions = map[PeriodStatus][]PeriodStatus{
+	PeriodPlanned: {PeriodActive},
+	PeriodActive:  {PeriodClosed},
+	PeriodClosed:  {PeriodActive, PeriodArchived},
+}
+
+func (p *AcademicPeriod) TransitionTo(status PeriodStatus) error {
+	for _, allowed := range periodTransitions[p.Status] {
+		if allowed == status {
+			p.Status = status
+			p.IsActive = status == PeriodActive
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidPeriodTransition, p.Status, status)
+}
+
+func (p *AcademicPeriod) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+
+	if p.Name == "" || len(p.Name) > 100 {
+		return fmt.Errorf("%w: name must be 1-100 characters", ErrInvalidAcademicPeriod)
+	}
+	if p.StartDate.IsZero() || p.EndDate.IsZero() {
+		return fmt.Errorf("%w: start and end dates are required", ErrInvalidAcademicPeriod)
+	}
+	if !p.EndDate.After(p.StartDate) {
+		return fmt.Errorf("%w: end date must be after start date", ErrInvalidAcademicPeriod)
+	}
+	return nil
 }
 
 func NewAcademicPeriod(name string, startDate, endDate time.Time) *AcademicPeriod {
 	return &AcademicPeriod{
-		Name: name,
+		Name:      name,
 		StartDate: startDate,
-		EndDate: endDate,
-		IsActive: true,
+		EndDate:   endDate,
+		Status:    PeriodActive,
+		IsActive:  true,
 	}
-}
\ No newline at end of file
+}
@@ -9,4 +9,16 @@ import (
 type AcademicPeriodRepository interface {
 	Create(ctx context.Context, period *AcademicPeriod, orgID uuid.UUID) error
 	GetActiveByOrganizationID(ctx context.Context, orgID uuid.UUID) (*AcademicPeriod, error)
+	GetByID(ctx context.Context, id, orgID uuid.UUID) (*AcademicPeriod, error)
+	// GetByPreviousPeriodID finds the period a rollover created from previousID.
+	GetByPreviousPeriodID(ctx context.Context, previousID, orgID uuid.UUID) (*AcademicPeriod, error)
+	ListByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]AcademicPeriod, error)
+	Update(ctx context.Context, period *AcademicPeriod, orgID uuid.UUID) error
+	Delete(ctx context.Context, id, orgID uuid.UUID) error
+	// Activate closes the organization's active period, if any, and
+	// activates this one.
+	Activate(ctx context.Context, id, orgID uuid.UUID) error
+	SetStatus(ctx context.Context, id, orgID uuid.UUID, status PeriodStatus) error
+	// InUse reports whether cohorts or enrollments still reference the period.
+	InUse(ctx context.Context, id uuid.UUID) (bool, error)
 }
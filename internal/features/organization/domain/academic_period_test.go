@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcademicPeriodValidate(t *testing.T) {
+	start := time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		period  AcademicPeriod
+		wantErr bool
+	}{
+		{name: "Valid", period: AcademicPeriod{Name: "2025/2026 ganjil", StartDate: start, EndDate: end}, wantErr: false},
+		{name: "Blank name", period: AcademicPeriod{Name: "  ", StartDate: start, EndDate: end}, wantErr: true},
+		{name: "Missing dates", period: AcademicPeriod{Name: "2025/2026 ganjil"}, wantErr: true},
+		{name: "End before start", period: AcademicPeriod{Name: "2025/2026 ganjil", StartDate: end, EndDate: start}, wantErr: true},
+		{name: "Same day", period: AcademicPeriod{Name: "2025/2026 ganjil", StartDate: start, EndDate: start}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.period.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAcademicPeriodTransitionTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    PeriodStatus
+		to      PeriodStatus
+		wantErr bool
+	}{
+		{name: "Activate planned", from: PeriodPlanned, to: PeriodActive, wantErr: false},
+		{name: "Close active", from: PeriodActive, to: PeriodClosed, wantErr: false},
+		{name: "Reopen closed", from: PeriodClosed, to: PeriodActive, wantErr: false},
+		{name: "Archive closed", from: PeriodClosed, to: PeriodArchived, wantErr: false},
+		{name: "Close planned", from: PeriodPlanned, to: PeriodClosed, wantErr: true},
+		{name: "Archive active", from: PeriodActive, to: PeriodArchived, wantErr: true},
+		{name: "Reopen archived", from: PeriodArchived, to: PeriodActive, wantErr: true},
+		{name: "Activate active", from: PeriodActive, to: PeriodActive, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := AcademicPeriod{Status: tt.from}
+			err := p.TransitionTo(tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransitionTo(%s) error = %v, wantErr %v", tt.to, err, tt.wantErr)
+			}
+			if err == nil && (p.Status != tt.to || p.IsActive != (tt.to == PeriodActive)) {
+				t.Errorf("TransitionTo(%s) left status %s, active %v", tt.to, p.Status, p.IsActive)
+			}
+		})
+	}
+}
@@ -1,7 +1,14 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared"
+	"github.com/google/uuid"
 )
 
 type OrgType string
@@ -13,6 +20,33 @@ const (
 	GradeSchool OrgType = "grade_school"
 )
 
+// SystemOrganizationID is the organization reserved by migration 000006 for
+// platform operators.
+var SystemOrganizationID = uuid.MustParse("00000000-0000-4000-a000-000000000000")
+
+var (
+	ErrInvalidSlug         = errors.New("slug must be 3-63 lowercase letters, digits or hyphens, starting with a letter or digit")
+	ErrInvalidOrganization = errors.New("invalid organization")
+)
+
+// slugPattern keeps slugs usable as a subdomain label.
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,62}$`)
+
+func ValidateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) || slug[len(slug)-1] == '-' {
+		return ErrInvalidSlug
+	}
+	return nil
+}
+
+func (t OrgType) IsValid() bool {
+	switch t {
+	case University, HighSchool, MiddleSchool, GradeSchool:
+		return true
+	}
+	return false
+}
+
 type Organization struct {
 	shared.Base
 	
@@ -24,6 +58,51 @@ type Organization struct {
 
 	IsActive bool
 	IsSystemOrg *bool
+
+	// SuspendedAt is set while a system administrator has suspended the
+	// organization; its members cannot sign in until it is reactivated.
+	SuspendedAt *time.Time
+	SuspensionReason string
+
+	// Role names whose members must use two-factor authentication
+	MFARequiredRoles []string
+}
+
+// ValidateProfile checks the fields an organization's administrators may
+// edit. The address is optional.
+func (o *Organization) ValidateProfile() error {
+	o.Name = strings.TrimSpace(o.Name)
+	o.Address = strings.TrimSpace(o.Address)
+
+	if len(o.Name) < 3 || len(o.Name) > 100 {
+		return fmt.Errorf("%w: name must be 3-100 characters", ErrInvalidOrganization)
+	}
+	if o.Address != "" && (len(o.Address) < 10 || len(o.Address) > 200) {
+		return fmt.Errorf("%w: address must be 10-200 characters", ErrInvalidOrganization)
+	}
+	if !o.Type.IsValid() {
+		return fmt.Errorf("%w: unknown organization type '%s'", ErrInvalidOrganization, o.Type)
+	}
+	return nil
+}
+
+func (o *Organization) IsSuspended() bool {
+	return o.SuspendedAt != nil
+}
+
+func (o *Organization) IsSystem() bool {
+	return o.IsSystemOrg != nil && *o.IsSystemOrg
+}
+
+func (o *Organization) RequiresMFAFor(roleNames []string) bool {
+	for _, required := range o.MFARequiredRoles {
+		for _, name := range roleNames {
+			if required == name {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func NewOrganization(
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,4 +14,11 @@ type OrganizationRepository interface {
 	Delete(ctx context.Context, orgID uuid.UUID) error
 	GetBySlug(ctx context.Context, slug string) (*Organization, error)
 	GetIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	UpdateMFARequiredRoles(ctx context.Context, orgID uuid.UUID, roleNames []string) error
+	// SetSuspended suspends the organization, or reactivates it when
+	// suspendedAt is nil.
+	SetSuspended(ctx context.Context, orgID uuid.UUID, suspendedAt *time.Time, reason string) error
+	// GetSettings returns nil when the organization does not exist.
+	GetSettings(ctx context.Context, orgID uuid.UUID) (*Settings, error)
+	UpdateSettings(ctx context.Context, orgID uuid.UUID, settings *Settings) error
 }
@@ -0,0 +1,50 @@
+package domain
+
+import "testing"
+
+func TestValidateSlug(t *testing.T) {
+	tests := []struct {
+		name    string
+		slug    string
+		wantErr bool
+	}{
+		{name: "Letters", slug: "cts", wantErr: false},
+		{name: "With digits and hyphens", slug: "sma-1-jakarta", wantErr: false},
+		{name: "Too short", slug: "ab", wantErr: true},
+		{name: "Uppercase", slug: "CTS", wantErr: true},
+		{name: "Leading hyphen", slug: "-cts", wantErr: true},
+		{name: "Trailing hyphen", slug: "cts-", wantErr: true},
+		{name: "Space", slug: "candle tree", wantErr: true},
+		{name: "Too long", slug: "a123456789012345678901234567890123456789012345678901234567890123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSlug(tt.slug); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSlug(%q) error = %v, wantErr %v", tt.slug, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrganizationValidateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		org     Organization
+		wantErr bool
+	}{
+		{name: "Valid", org: Organization{Name: "SMA Negeri 1", Type: HighSchool}, wantErr: false},
+		{name: "Valid with address", org: Organization{Name: "SMA Negeri 1", Type: HighSchool, Address: "Jl. Budi Utomo No. 7, Jakarta"}, wantErr: false},
+		{name: "Short name", org: Organization{Name: " ab ", Type: HighSchool}, wantErr: true},
+		{name: "Short address", org: Organization{Name: "SMA Negeri 1", Type: HighSchool, Address: "Jakarta"}, wantErr: true},
+		{name: "Unknown type", org: Organization{Name: "SMA Negeri 1", Type: "college"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.org.ValidateProfile(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
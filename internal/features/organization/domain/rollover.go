@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// RolloverSource is what a period looks like when it is rolled over: its
+// cohorts, their sections with staff and course offerings, and the
+// organization's education levels.
+//
+// The schema has no per-period course catalogue, so a section's offerings
+// are the courses its enrollments point at. Carrying them forward is how a
+// program's courses reach the next period.
+type RolloverSource struct {
+	Levels  []LevelRef
+	Cohorts []SourceCohort
+}
+
+// LevelRef places an education level in the organization's progression.
+// Levels without a Sequence are not part of it.
+type LevelRef struct {
+	ID       uuid.UUID
+	Name     string
+	Sequence *int
+}
+
+type SourceCohort struct {
+	ID               uuid.UUID
+	Name             string
+	EducationLevelID uuid.UUID
+	Sections         []SourceSection
+	// Students lists every member of the cohort, with or without a section.
+	Students []uuid.UUID
+}
+
+type SourceSection struct {
+	ID        uuid.UUID
+	Name      string
+	RoomCode  string
+	Capacity  int
+	Staff     []StaffAssignment
+	CourseIDs []uuid.UUID
+	Students  []uuid.UUID
+}
+
+type StaffAssignment struct {
+	UserID   uuid.UUID
+	RoleType string
+}
+
+// RolloverOptions tune how students are promoted.
+type RolloverOptions struct {
+	// LevelOrder, when set, replaces the stored level sequence: students
+	// move from each level to the one after it.
+	LevelOrder []uuid.UUID
+	// PromoteTo sends the students of a source cohort to the copy of another
+	// source cohort. Cohorts not listed go to the cohort at the same position
+	// (by name) in the next level.
+	PromoteTo map[uuid.UUID]uuid.UUID
+}
+
+type RolloverPlan struct {
+	Period  *AcademicPeriod
+	Cohorts []PlannedCohort
+
+	Promotions []Promotion
+	// Graduates were in the last level of the progression.
+	Graduates []uuid.UUID
+	// Unplaced students are in a level outside the progression, or there is
+	// no cohort in the next level to receive them.
+	Unplaced []uuid.UUID
+}
+
+type PlannedCohort struct {
+	SourceID         uuid.UUID
+	ID               uuid.UUID
+	Name             string
+	EducationLevelID uuid.UUID
+	Sections         []PlannedSection
+}
+
+type PlannedSection struct {
+	SourceID  uuid.UUID
+	ID        uuid.UUID
+	Name      string
+	RoomCode  string
+	Capacity  int
+	Staff     []StaffAssignment
+	CourseIDs []uuid.UUID
+}
+
+// Promotion moves a student into a new cohort and, when a matching section
+// exists, into that section and its courses.
+type Promotion struct {
+	UserID       uuid.UUID
+	FromCohortID uuid.UUID
+	ToCohortID   uuid.UUID
+	ToSectionID  *uuid.UUID
+	CourseIDs    []uuid.UUID
+}
+
+func (p *RolloverPlan) SectionCount() int {
+	n := 0
+	for _, c := range p.Cohorts {
+		n += len(c.Sections)
+	}
+	return n
+}
+
+func (p *RolloverPlan) EnrollmentCount() int {
+	n := 0
+	for _, pr := range p.Promotions {
+		n += len(pr.CourseIDs)
+	}
+	return n
+}
+
+// PlanRollover copies every cohort and section of source into next and
+// promotes each cohort's students one level up. It does not touch storage.
+func PlanRollover(source RolloverSource, next *AcademicPeriod, opts RolloverOptions) *RolloverPlan {
+	plan := &RolloverPlan{Period: next}
+
+	cohorts := make([]SourceCohort, len(source.Cohorts))
+	copy(cohorts, source.Cohorts)
+	sort.SliceStable(cohorts, func(i, j int) bool { return cohorts[i].Name < cohorts[j].Name })
+
+	copies := make(map[uuid.UUID]*PlannedCohort, len(cohorts))
+	for _, c := range cohorts {
+		planned := PlannedCohort{
+			SourceID:         c.ID,
+			ID:               uuid.New(),
+			Name:             c.Name,
+			EducationLevelID: c.EducationLevelID,
+		}
+		for _, s := range sortedSections(c.Sections) {
+			planned.Sections = append(planned.Sections, PlannedSection{
+				SourceID:  s.ID,
+				ID:        uuid.New(),
+				Name:      s.Name,
+				RoomCode:  s.RoomCode,
+				Capacity:  s.Capacity,
+				Staff:     teachingStaff(s.Staff),
+				CourseIDs: s.CourseIDs,
+			})
+		}
+		plan.Cohorts = append(plan.Cohorts, planned)
+	}
+	for i := range plan.Cohorts {
+		copies[plan.Cohorts[i].SourceID] = &plan.Cohorts[i]
+	}
+
+	nextLevel, inProgression := levelProgression(source.Levels, opts.LevelOrder)
+
+	byLevel := make(map[uuid.UUID][]SourceCohort)
+	for _, c := range cohorts {
+		byLevel[c.EducationLevelID] = append(byLevel[c.EducationLevelID], c)
+	}
+	position := func(c SourceCohort) int {
+		for i, other := range byLevel[c.EducationLevelID] {
+			if other.ID == c.ID {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, c := range cohorts {
+		var target *PlannedCohort
+		if targetID, ok := opts.PromoteTo[c.ID]; ok {
+			target = copies[targetID]
+		} else if inProgression[c.EducationLevelID] {
+			levelID, hasNext := nextLevel[c.EducationLevelID]
+			if !hasNext {
+				plan.Graduates = append(plan.Graduates, c.Students...)
+				continue
+			}
+			if candidates := byLevel[levelID]; position(c) < len(candidates) {
+				target = copies[candidates[position(c)].ID]
+			}
+		}
+		if target == nil {
+			plan.Unplaced = append(plan.Unplaced, c.Students...)
+			continue
+		}
+
+		sectionOf := make(map[uuid.UUID]int)
+		for i, s := range sortedSections(c.Sections) {
+			for _, studentID := range s.Students {
+				sectionOf[studentID] = i
+			}
+		}
+
+		for _, studentID := range c.Students {
+			promotion := Promotion{
+				UserID:       studentID,
+				FromCohortID: c.ID,
+				ToCohortID:   target.ID,
+			}
+			if i, ok := sectionOf[studentID]; ok && i < len(target.Sections) {
+				section := target.Sections[i]
+				promotion.ToSectionID = &section.ID
+				promotion.CourseIDs = section.CourseIDs
+			}
+			plan.Promotions = append(plan.Promotions, promotion)
+		}
+	}
+
+	return plan
+}
+
+// levelProgression maps each level to the one after it. order overrides
+// the stored sequences when it is given.
+func levelProgression(levels []LevelRef, order []uuid.UUID) (next map[uuid.UUID]uuid.UUID, inProgression map[uuid.UUID]bool) {
+	if len(order) == 0 {
+		sequenced := make([]LevelRef, 0, len(levels))
+		for _, l := range levels {
+			if l.Sequence != nil {
+				sequenced = append(sequenced, l)
+			}
+		}
+		sort.SliceStable(sequenced, func(i, j int) bool { return *sequenced[i].Sequence < *sequenced[j].Sequence })
+		for _, l := range sequenced {
+			order = append(order, l.ID)
+		}
+	}
+
+	next = make(map[uuid.UUID]uuid.UUID, len(order))
+	inProgression = make(map[uuid.UUID]bool, len(order))
+	for i, id := range order {
+		inProgression[id] = true
+		if i+1 < len(order) {
+			next[id] = order[i+1]
+		}
+	}
+	return next, inProgression
+}
+
+func sortedSections(sections []SourceSection) []SourceSection {
+	sorted := make([]SourceSection, len(sections))
+	copy(sorted, sections)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// teachingStaff keeps the assignments that belong to the section rather
+// than to the students in it.
+func teachingStaff(staff []StaffAssignment) []StaffAssignment {
+	var kept []StaffAssignment
+	for _, s := range staff {
+		if s.RoleType == "teacher" || s.RoleType == "assistant" {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
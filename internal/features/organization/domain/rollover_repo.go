@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RolloverRepository interface {
+	// LoadSource reads the cohorts, sections, staff, course offerings and
+	// students of a period, along with the organization's education levels.
+	LoadSource(ctx context.Context, periodID, orgID uuid.UUID) (*RolloverSource, error)
+	// Apply creates the plan's period, cohorts, sections, staff assignments,
+	// memberships and enrollments in one transaction.
+	Apply(ctx context.Context, plan *RolloverPlan, orgID uuid.UUID) error
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPlanRollover(t *testing.T) {
+	seq := func(n int) *int { return &n }
+
+	grade10, grade11, grade12, remedial := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	teacher, monitor := uuid.New(), uuid.New()
+	math, physics := uuid.New(), uuid.New()
+	ani, budi, citra, dewi, eko, fajar := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	x1 := SourceCohort{
+		ID: uuid.New(), Name: "X-1", EducationLevelID: grade10,
+		Sections: []SourceSection{
+			{ID: uuid.New(), Name: "X-1 B", Students: []uuid.UUID{budi}},
+			{ID: uuid.New(), Name: "X-1 A", Students: []uuid.UUID{ani}},
+		},
+		Students: []uuid.UUID{ani, budi},
+	}
+	x2 := SourceCohort{ID: uuid.New(), Name: "X-2", EducationLevelID: grade10, Students: []uuid.UUID{citra}}
+	xi1 := SourceCohort{
+		ID: uuid.New(), Name: "XI-1", EducationLevelID: grade11,
+		Sections: []SourceSection{
+			{
+				ID: uuid.New(), Name: "XI-1 A", RoomCode: "201", Capacity: 32,
+				Staff:     []StaffAssignment{{UserID: teacher, RoleType: "teacher"}, {UserID: monitor, RoleType: "monitor"}},
+				CourseIDs: []uuid.UUID{math, physics},
+				Students:  []uuid.UUID{dewi},
+			},
+		},
+		Students: []uuid.UUID{dewi},
+	}
+	xii1 := SourceCohort{ID: uuid.New(), Name: "XII-1", EducationLevelID: grade12, Students: []uuid.UUID{eko}}
+	r1 := SourceCohort{ID: uuid.New(), Name: "R-1", EducationLevelID: remedial, Students: []uuid.UUID{fajar}}
+
+	source := RolloverSource{
+		Levels: []LevelRef{
+			{ID: grade12, Name: "Grade 12", Sequence: seq(12)},
+			{ID: grade10, Name: "Grade 10", Sequence: seq(10)},
+			{ID: grade11, Name: "Grade 11", Sequence: seq(11)},
+			{ID: remedial, Name: "Remedial"},
+		},
+		Cohorts: []SourceCohort{xii1, x2, xi1, x1, r1},
+	}
+	next := &AcademicPeriod{Name: "2026/2027 ganjil"}
+
+	t.Run("Copies structure", func(t *testing.T) {
+		plan := PlanRollover(source, next, RolloverOptions{})
+
+		if len(plan.Cohorts) != 5 || plan.SectionCount() != 3 {
+			t.Fatalf("got %d cohorts and %d sections, want 5 and 3", len(plan.Cohorts), plan.SectionCount())
+		}
+		copied := findCohort(plan, xi1.ID)
+		if copied == nil || copied.ID == xi1.ID || copied.Name != "XI-1" || copied.EducationLevelID != grade11 {
+			t.Fatalf("XI-1 copy = %+v", copied)
+		}
+		section := copied.Sections[0]
+		if section.RoomCode != "201" || section.Capacity != 32 || len(section.CourseIDs) != 2 {
+			t.Errorf("section copy = %+v", section)
+		}
+		if len(section.Staff) != 1 || section.Staff[0].UserID != teacher {
+			t.Errorf("staff = %+v, want only the teacher", section.Staff)
+		}
+	})
+
+	t.Run("Promotes by level and position", func(t *testing.T) {
+		plan := PlanRollover(source, next, RolloverOptions{})
+
+		xi1Copy, xii1Copy := findCohort(plan, xi1.ID), findCohort(plan, xii1.ID)
+
+		ani := findPromotion(plan, ani)
+		if ani == nil || ani.ToCohortID != xi1Copy.ID || ani.ToSectionID == nil || *ani.ToSectionID != xi1Copy.Sections[0].ID {
+			t.Fatalf("ani promotion = %+v, want first section of XI-1 copy", ani)
+		}
+		if len(ani.CourseIDs) != 2 {
+			t.Errorf("ani courses = %d, want 2", len(ani.CourseIDs))
+		}
+
+		budi := findPromotion(plan, budi)
+		if budi == nil || budi.ToCohortID != xi1Copy.ID || budi.ToSectionID != nil {
+			t.Errorf("budi promotion = %+v, want XI-1 copy without a section", budi)
+		}
+
+		dewi := findPromotion(plan, dewi)
+		if dewi == nil || dewi.ToCohortID != xii1Copy.ID {
+			t.Errorf("dewi promotion = %+v, want XII-1 copy", dewi)
+		}
+
+		if !containsID(plan.Graduates, eko) || len(plan.Graduates) != 1 {
+			t.Errorf("graduates = %v, want only eko", plan.Graduates)
+		}
+		if !containsID(plan.Unplaced, citra) || !containsID(plan.Unplaced, fajar) || len(plan.Unplaced) != 2 {
+			t.Errorf("unplaced = %v, want citra and fajar", plan.Unplaced)
+		}
+		if plan.EnrollmentCount() != 2 {
+			t.Errorf("EnrollmentCount() = %d, want 2", plan.EnrollmentCount())
+		}
+	})
+
+	t.Run("Explicit target cohort", func(t *testing.T) {
+		plan := PlanRollover(source, next, RolloverOptions{PromoteTo: map[uuid.UUID]uuid.UUID{x2.ID: xi1.ID}})
+
+		citra := findPromotion(plan, citra)
+		if citra == nil || citra.ToCohortID != findCohort(plan, xi1.ID).ID {
+			t.Errorf("citra promotion = %+v, want XI-1 copy", citra)
+		}
+	})
+
+	t.Run("Level order override", func(t *testing.T) {
+		plan := PlanRollover(source, next, RolloverOptions{LevelOrder: []uuid.UUID{remedial, grade10}})
+
+		fajar := findPromotion(plan, fajar)
+		if fajar == nil || fajar.ToCohortID != findCohort(plan, x1.ID).ID {
+			t.Errorf("fajar promotion = %+v, want X-1 copy", fajar)
+		}
+		if !containsID(plan.Graduates, ani) {
+			t.Errorf("graduates = %v, want grade 10 as the last level", plan.Graduates)
+		}
+		if !containsID(plan.Unplaced, dewi) {
+			t.Errorf("unplaced = %v, want levels outside the order", plan.Unplaced)
+		}
+	})
+}
+
+func findCohort(plan *RolloverPlan, sourceID uuid.UUID) *PlannedCohort {
+	for i := range plan.Cohorts {
+		if plan.Cohorts[i].SourceID == sourceID {
+			return &plan.Cohorts[i]
+		}
+	}
+	return nil
+}
+
+func findPromotion(plan *RolloverPlan, userID uuid.UUID) *Promotion {
+	for i := range plan.Promotions {
+		if plan.Promotions[i].UserID == userID {
+			return &plan.Promotions[i]
+		}
+	}
+	return nil
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSettings = errors.New("invalid organization settings")
+
+var (
+	hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+	localePattern   = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+)
+
+const maxGracePeriodMinutes = 7 * 24 * 60
+
+// Settings is stored as JSON in organizations.settings. Keys missing from
+// the stored document fall back to DefaultSettings.
+type Settings struct {
+	Timezone          string               `json:"timezone"`
+	Locale            string               `json:"locale"`
+	WeekStartDay      string               `json:"week_start_day"`
+	GradingScale      []GradeBand          `json:"grading_scale"`
+	DefaultEventColor string               `json:"default_event_color"`
+	LateSubmission    LateSubmissionPolicy `json:"late_submission"`
+	Branding          Branding             `json:"branding"`
+}
+
+// GradeBand awards Grade to scores at or above MinScore. Bands are ordered
+// from the highest MinScore down and the last one starts at 0.
+type GradeBand struct {
+	Grade    string  `json:"grade"`
+	MinScore float64 `json:"min_score"`
+}
+
+// LateSubmissionPolicy holds the defaults new assessments start with.
+type LateSubmissionPolicy struct {
+	Allowed            bool    `json:"allowed"`
+	GracePeriodMinutes int     `json:"grace_period_minutes"`
+	PenaltyPerDay      float64 `json:"penalty_per_day"` // percent of the score
+	MaxPenalty         float64 `json:"max_penalty"`     // percent of the score
+}
+
+type Branding struct {
+	LogoURL        string `json:"logo_url"`
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+}
+
+// SettingsProvider lets other features read an organization's settings
+// without going through the organization API.
+type SettingsProvider interface {
+	Get(ctx context.Context, orgID uuid.UUID) (*Settings, error)
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Timezone:     "Asia/Jakarta",
+		Locale:       "id-ID",
+		WeekStartDay: "monday",
+		GradingScale: []GradeBand{
+			{Grade: "A", MinScore: 85},
+			{Grade: "B", MinScore: 70},
+			{Grade: "C", MinScore: 55},
+			{Grade: "D", MinScore: 40},
+			{Grade: "E", MinScore: 0},
+		},
+		DefaultEventColor: "#3B82F6",
+		LateSubmission: LateSubmissionPolicy{
+			Allowed:            true,
+			GracePeriodMinutes: 0,
+			PenaltyPerDay:      10,
+			MaxPenalty:         50,
+		},
+		Branding: Branding{
+			PrimaryColor:   "#1E3A8A",
+			SecondaryColor: "#F59E0B",
+		},
+	}
+}
+
+func (s *Settings) Location() (*time.Location, error) {
+	return time.LoadLocation(s.Timezone)
+}
+
+// GradeFor returns the grade the scale awards to score.
+func (s *Settings) GradeFor(score float64) string {
+	for _, band := range s.GradingScale {
+		if score >= band.MinScore {
+			return band.Grade
+		}
+	}
+	return ""
+}
+
+func (s *Settings) Validate() error {
+	s.Timezone = strings.TrimSpace(s.Timezone)
+	s.WeekStartDay = strings.ToLower(strings.TrimSpace(s.WeekStartDay))
+
+	if s.Timezone == "" {
+		return fmt.Errorf("%w: timezone is required", ErrInvalidSettings)
+	}
+	if _, err := s.Location(); err != nil {
+		return fmt.Errorf("%w: unknown timezone '%s'", ErrInvalidSettings, s.Timezone)
+	}
+	if !localePattern.MatchString(s.Locale) {
+		return fmt.Errorf("%w: locale must look like 'id' or 'id-ID'", ErrInvalidSettings)
+	}
+	if s.WeekStartDay != "monday" && s.WeekStartDay != "sunday" && s.WeekStartDay != "saturday" {
+		return fmt.Errorf("%w: week_start_day must be monday, sunday or saturday", ErrInvalidSettings)
+	}
+	if err := validateGradingScale(s.GradingScale); err != nil {
+		return err
+	}
+	if !hexColorPattern.MatchString(s.DefaultEventColor) {
+		return fmt.Errorf("%w: default_event_color must be a hex color like #3B82F6", ErrInvalidSettings)
+	}
+
+	late := s.LateSubmission
+	if late.GracePeriodMinutes < 0 || late.GracePeriodMinutes > maxGracePeriodMinutes {
+		return fmt.Errorf("%w: grace_period_minutes must be between 0 and %d", ErrInvalidSettings, maxGracePeriodMinutes)
+	}
+	if late.PenaltyPerDay < 0 || late.PenaltyPerDay > 100 || late.MaxPenalty < 0 || late.MaxPenalty > 100 {
+		return fmt.Errorf("%w: late submission penalties must be between 0 and 100", ErrInvalidSettings)
+	}
+
+	return s.Branding.validate()
+}
+
+func validateGradingScale(scale []GradeBand) error {
+	if len(scale) == 0 {
+		return fmt.Errorf("%w: grading_scale needs at least one band", ErrInvalidSettings)
+	}
+
+	seen := make(map[string]bool, len(scale))
+	for i, band := range scale {
+		if band.Grade == "" || len(band.Grade) > 8 {
+			return fmt.Errorf("%w: grade names must be 1-8 characters", ErrInvalidSettings)
+		}
+		if seen[band.Grade] {
+			return fmt.Errorf("%w: grade '%s' appears more than once", ErrInvalidSettings, band.Grade)
+		}
+		seen[band.Grade] = true
+
+		if band.MinScore < 0 || band.MinScore > 100 {
+			return fmt.Errorf("%w: min_score must be between 0 and 100", ErrInvalidSettings)
+		}
+		if i > 0 && band.MinScore >= scale[i-1].MinScore {
+			return fmt.Errorf("%w: grading_scale must be ordered from the highest min_score down", ErrInvalidSettings)
+		}
+	}
+
+	if scale[len(scale)-1].MinScore != 0 {
+		return fmt.Errorf("%w: the last grade band must start at 0", ErrInvalidSettings)
+	}
+	return nil
+}
+
+func (b *Branding) validate() error {
+	if b.LogoURL != "" {
+		u, err := url.Parse(b.LogoURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: logo_url must be an http(s) URL", ErrInvalidSettings)
+		}
+	}
+	for _, c := range []string{b.PrimaryColor, b.SecondaryColor} {
+		if c != "" && !hexColorPattern.MatchString(c) {
+			return fmt.Errorf("%w: branding colors must be hex colors like #1E3A8A", ErrInvalidSettings)
+		}
+	}
+	return nil
+}
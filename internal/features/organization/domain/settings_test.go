@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+func TestDefaultSettingsAreValid(t *testing.T) {
+	s := DefaultSettings()
+	if err := s.Validate(); err != nil {
+		t.Fatalf("DefaultSettings().Validate() error = %v", err)
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *Settings)
+		wantErr bool
+	}{
+		{name: "Other timezone", modify: func(s *Settings) { s.Timezone = "Asia/Makassar" }, wantErr: false},
+		{name: "Unknown timezone", modify: func(s *Settings) { s.Timezone = "Mars/Olympus" }, wantErr: true},
+		{name: "Language-only locale", modify: func(s *Settings) { s.Locale = "en" }, wantErr: false},
+		{name: "Bad locale", modify: func(s *Settings) { s.Locale = "english" }, wantErr: true},
+		{name: "Sunday week start", modify: func(s *Settings) { s.WeekStartDay = "Sunday" }, wantErr: false},
+		{name: "Bad week start", modify: func(s *Settings) { s.WeekStartDay = "friday" }, wantErr: true},
+		{name: "Empty grading scale", modify: func(s *Settings) { s.GradingScale = nil }, wantErr: true},
+		{name: "Unordered grading scale", modify: func(s *Settings) {
+			s.GradingScale = []GradeBand{{Grade: "B", MinScore: 70}, {Grade: "A", MinScore: 85}, {Grade: "C", MinScore: 0}}
+		}, wantErr: true},
+		{name: "Scale not starting at zero", modify: func(s *Settings) {
+			s.GradingScale = []GradeBand{{Grade: "Pass", MinScore: 60}}
+		}, wantErr: true},
+		{name: "Duplicate grade", modify: func(s *Settings) {
+			s.GradingScale = []GradeBand{{Grade: "A", MinScore: 80}, {Grade: "A", MinScore: 0}}
+		}, wantErr: true},
+		{name: "Bad event color", modify: func(s *Settings) { s.DefaultEventColor = "blue" }, wantErr: true},
+		{name: "Negative grace period", modify: func(s *Settings) { s.LateSubmission.GracePeriodMinutes = -1 }, wantErr: true},
+		{name: "Penalty over 100", modify: func(s *Settings) { s.LateSubmission.MaxPenalty = 150 }, wantErr: true},
+		{name: "Logo URL", modify: func(s *Settings) { s.Branding.LogoURL = "https://cdn.example.com/logo.png" }, wantErr: false},
+		{name: "Logo not a URL", modify: func(s *Settings) { s.Branding.LogoURL = "logo.png" }, wantErr: true},
+		{name: "Bad branding color", modify: func(s *Settings) { s.Branding.PrimaryColor = "#12345" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := DefaultSettings()
+			tt.modify(&s)
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSettingsGradeFor(t *testing.T) {
+	s := DefaultSettings()
+
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{score: 100, want: "A"},
+		{score: 85, want: "A"},
+		{score: 84.9, want: "B"},
+		{score: 55, want: "C"},
+		{score: 0, want: "E"},
+	}
+
+	for _, tt := range tests {
+		if got := s.GradeFor(tt.score); got != tt.want {
+			t.Errorf("GradeFor(%v) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
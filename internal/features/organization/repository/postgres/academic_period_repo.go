@@ -4,25 +4,48 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type AcademicPeriodRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewAcademicPeriodRepository(db *sql.DB) domain.AcademicPeriodRepository {
+func NewAcademicPeriodRepository(db database.DB) domain.AcademicPeriodRepository {
 	return &AcademicPeriodRepoPostgres{db: db}
 }
 
+const periodColumns = `id, name, start_date, end_date, status, previous_period_id, created_at, updated_at`
+
+func scanPeriod(row interface{ Scan(...any) error }, period *domain.AcademicPeriod) error {
+	err := row.Scan(
+		&period.ID,
+		&period.Name,
+		&period.StartDate,
+		&period.EndDate,
+		&period.Status,
+		&period.PreviousPeriodID,
+		&period.CreatedAt,
+		&period.UpdatedAt,
+	)
+	period.IsActive = period.Status == domain.PeriodActive
+	return err
+}
+
 func (r *AcademicPeriodRepoPostgres) Create(ctx context.Context, period *domain.AcademicPeriod, orgID uuid.UUID) error {
 	query := `
-		INSERT INTO academic_periods (id, organization_id, name, start_date, end_date, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO academic_periods (id, organization_id, name, start_date, end_date, status, is_active, previous_period_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	period.PrepareCreate(nil)
+	if period.Status == "" {
+		period.Status = domain.PeriodPlanned
+	}
+	period.IsActive = period.Status == domain.PeriodActive
 
 	_, err := r.db.ExecContext(ctx, query,
 		period.ID,
@@ -30,7 +53,9 @@ func (r *AcademicPeriodRepoPostgres) Create(ctx context.Context, period *domain.
 		period.Name,
 		period.StartDate,
 		period.EndDate,
+		period.Status,
 		period.IsActive,
+		period.PreviousPeriodID,
 		period.CreatedAt,
 		period.UpdatedAt,
 	)
@@ -44,21 +69,13 @@ func (r *AcademicPeriodRepoPostgres) Create(ctx context.Context, period *domain.
 
 func (r *AcademicPeriodRepoPostgres) GetActiveByOrganizationID(ctx context.Context, orgID uuid.UUID) (*domain.AcademicPeriod, error) {
 	query := `
-		SELECT id, name, start_date, end_date, is_active, created_at, updated_at
+		SELECT ` + periodColumns + `
 		FROM academic_periods
-		WHERE organization_id = $1 AND is_active = true AND deleted_at IS NULL
+		WHERE organization_id = $1 AND status = 'active' AND deleted_at IS NULL
 		LIMIT 1`
 
 	period := &domain.AcademicPeriod{}
-	err := r.db.QueryRowContext(ctx, query, orgID).Scan(
-		&period.ID,
-		&period.Name,
-		&period.StartDate,
-		&period.EndDate,
-		&period.IsActive,
-		&period.CreatedAt,
-		&period.UpdatedAt,
-	)
+	err := scanPeriod(r.db.QueryRowContext(ctx, query, orgID), period)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -69,3 +86,218 @@ func (r *AcademicPeriodRepoPostgres) GetActiveByOrganizationID(ctx context.Conte
 
 	return period, nil
 }
+
+func (r *AcademicPeriodRepoPostgres) GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.AcademicPeriod, error) {
+	query := `
+		SELECT ` + periodColumns + `
+		FROM academic_periods
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`
+
+	period := &domain.AcademicPeriod{}
+	err := scanPeriod(r.db.QueryRowContext(ctx, query, id, orgID), period)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get academic period: %w", err)
+	}
+
+	return period, nil
+}
+
+func (r *AcademicPeriodRepoPostgres) GetByPreviousPeriodID(ctx context.Context, previousID, orgID uuid.UUID) (*domain.AcademicPeriod, error) {
+	query := `
+		SELECT ` + periodColumns + `
+		FROM academic_periods
+		WHERE previous_period_id = $1 AND organization_id = $2 AND deleted_at IS NULL`
+
+	period := &domain.AcademicPeriod{}
+	err := scanPeriod(r.db.QueryRowContext(ctx, query, previousID, orgID), period)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolled over academic period: %w", err)
+	}
+
+	return period, nil
+}
+
+func (r *AcademicPeriodRepoPostgres) ListByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]domain.AcademicPeriod, error) {
+	query := `
+		SELECT ` + periodColumns + `
+		FROM academic_periods
+		WHERE organization_id = $1 AND deleted_at IS NULL
+		ORDER BY start_date DESC, name`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list academic periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []domain.AcademicPeriod
+	for rows.Next() {
+		var period domain.AcademicPeriod
+		if err := scanPeriod(rows, &period); err != nil {
+			return nil, fmt.Errorf("failed to scan academic period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating academic periods: %w", err)
+	}
+
+	return periods, nil
+}
+
+func (r *AcademicPeriodRepoPostgres) Update(ctx context.Context, period *domain.AcademicPeriod, orgID uuid.UUID) error {
+	query := `
+		UPDATE academic_periods
+		SET name = $3, start_date = $4, end_date = $5, updated_at = $6
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`
+
+	period.UpdatedAt = time.Now()
+
+	res, err := r.db.ExecContext(ctx, query,
+		period.ID,
+		orgID,
+		period.Name,
+		period.StartDate,
+		period.EndDate,
+		period.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update academic period: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("academic period not found or already deleted")
+	}
+
+	return nil
+}
+
+// Activate makes the period the organization's only active one, closing
+// whichever was active before, in one transaction. The unique index on
+// active periods backs this up against concurrent activations.
+func (r *AcademicPeriodRepoPostgres) Activate(ctx context.Context, id, orgID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previousID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		UPDATE academic_periods
+		SET status = 'closed', is_active = false, updated_at = now()
+		WHERE organization_id = $1 AND status = 'active' AND id <> $2 AND deleted_at IS NULL
+		RETURNING id`, orgID, id).Scan(&previousID)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to close active academic period: %w", err)
+	}
+	if err == nil {
+		if err := completeEnrollments(ctx, tx, previousID); err != nil {
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE academic_periods
+		SET status = 'active', is_active = true, updated_at = now()
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to activate academic period: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("academic period not found or already deleted")
+	}
+
+	// Enrollments created ahead of time by a rollover start now.
+	_, err = tx.ExecContext(ctx, `
+		UPDATE enrollments
+		SET status = 'active', updated_at = now()
+		WHERE academic_period_id = $1 AND status = 'pending' AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to start pending enrollments: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SetStatus closes or archives a period. Closing completes the period's
+// active enrollments.
+func (r *AcademicPeriodRepoPostgres) SetStatus(ctx context.Context, id, orgID uuid.UUID, status domain.PeriodStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE academic_periods
+		SET status = $3, is_active = ($3 = 'active'), updated_at = now()
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`, id, orgID, status)
+	if err != nil {
+		return fmt.Errorf("failed to update academic period status: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("academic period not found or already deleted")
+	}
+
+	if status == domain.PeriodClosed {
+		if err := completeEnrollments(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func completeEnrollments(ctx context.Context, tx *sql.Tx, periodID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE enrollments
+		SET status = 'completed', updated_at = now()
+		WHERE academic_period_id = $1 AND status = 'active' AND deleted_at IS NULL`, periodID)
+	if err != nil {
+		return fmt.Errorf("failed to complete enrollments: %w", err)
+	}
+	return nil
+}
+
+func (r *AcademicPeriodRepoPostgres) Delete(ctx context.Context, id, orgID uuid.UUID) error {
+	query := `
+		UPDATE academic_periods
+		SET deleted_at = now(), updated_at = now()
+		WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete academic period: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("academic period not found or already deleted")
+	}
+
+	return nil
+}
+
+func (r *AcademicPeriodRepoPostgres) InUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM cohorts WHERE academic_period_id = $1 AND deleted_at IS NULL)
+		    OR EXISTS (SELECT 1 FROM enrollments WHERE academic_period_id = $1 AND deleted_at IS NULL)`
+
+	var inUse bool
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&inUse); err != nil {
+		return false, fmt.Errorf("failed to check academic period usage: %w", err)
+	}
+
+	return inUse, nil
+}
@@ -3,33 +3,38 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 type OrganizationRepoPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewOrganizationRepo(db *sql.DB, log *logrus.Logger) domain.OrganizationRepository {
+func NewOrganizationRepo(db database.DB, log *logrus.Logger) domain.OrganizationRepository {
 	return &OrganizationRepoPostgres{db: db, log: log}
 }
 
 func (r *OrganizationRepoPostgres) Create(ctx context.Context, org *domain.Organization) error {
 	query := `
-		INSERT INTO organizations (id, name, slug, type, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO organizations (id, name, slug, type, address, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)`
 	org.PrepareCreate(nil)
 	_, err := r.db.ExecContext(ctx, query,
 		org.ID,
 		org.Name,
 		org.Slug,
 		org.Type,
+		org.Address,
 		org.CreatedAt,
 		org.UpdatedAt,
 	)
@@ -43,19 +48,24 @@ func (r *OrganizationRepoPostgres) Create(ctx context.Context, org *domain.Organ
 
 func (r *OrganizationRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
 	query := `
-			SELECT id, name, slug, type, created_at, updated_at, is_system_org 
+			SELECT id, name, slug, type, address, created_at, updated_at, is_system_org, mfa_required_roles, suspended_at, suspension_reason
 			FROM organizations 
 			WHERE id = $1 AND deleted_at IS NULL`
 
 	organization := &domain.Organization{}
+	var orgType, address, suspensionReason sql.NullString
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&organization.ID,
 		&organization.Name,
 		&organization.Slug,
-		&organization.Type,
+		&orgType,
+		&address,
 		&organization.CreatedAt,
 		&organization.UpdatedAt,
 		&organization.IsSystemOrg,
+		pq.Array(&organization.MFARequiredRoles),
+		&organization.SuspendedAt,
+		&suspensionReason,
 	)
 
 	if err == sql.ErrNoRows {
@@ -66,6 +76,11 @@ func (r *OrganizationRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*
 		r.log.WithError(err).WithField("org_id", id).Error("failed to get organization by id")
 		return nil, fmt.Errorf("failed to get organization by id: %w", err)
 	}
+	// The system organization has no type.
+	organization.Type = domain.OrgType(orgType.String)
+	organization.Address = address.String
+	organization.SuspensionReason = suspensionReason.String
+	organization.IsActive = !organization.IsSuspended()
 
 	return organization, nil
 }
@@ -73,7 +88,7 @@ func (r *OrganizationRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*
 func (r *OrganizationRepoPostgres) Update(ctx context.Context, org *domain.Organization) error {
 	query := `
 		UPDATE organizations
-		SET name=$2, slug=$3, type=$4, created_at=$5, updated_at=$6
+		SET name=$2, slug=$3, type=$4, address=NULLIF($5, ''), created_at=$6, updated_at=$7
 		WHERE id=$1 AND deleted_at IS NULL`
 
 	res, err := r.db.ExecContext(ctx, query,
@@ -81,6 +96,7 @@ func (r *OrganizationRepoPostgres) Update(ctx context.Context, org *domain.Organ
 		org.Name,
 		org.Slug,
 		org.Type,
+		org.Address,
 		org.CreatedAt,
 		org.UpdatedAt,
 	)
@@ -101,9 +117,12 @@ func (r *OrganizationRepoPostgres) Update(ctx context.Context, org *domain.Organ
 }
 
 func (r *OrganizationRepoPostgres) Delete(ctx context.Context, orgID uuid.UUID) error {
+	// Organizations are soft-deleted: users, courses and history still
+	// reference them.
 	query := `
-		DELETE FROM organizations
-		WHERE id=$1`
+		UPDATE organizations
+		SET deleted_at = now(), updated_at = now()
+		WHERE id=$1 AND deleted_at IS NULL`
 
 	res, err := r.db.ExecContext(ctx, query, orgID)
 
@@ -124,19 +143,24 @@ func (r *OrganizationRepoPostgres) Delete(ctx context.Context, orgID uuid.UUID)
 
 func (r *OrganizationRepoPostgres) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
 	query := `
-		SELECT id, name, slug, type, created_at, updated_at, is_system_org
+		SELECT id, name, slug, type, address, created_at, updated_at, is_system_org, mfa_required_roles, suspended_at, suspension_reason
 		FROM organizations
 		WHERE slug = $1 AND deleted_at IS NULL`
 
 	organization := &domain.Organization{}
+	var orgType, address, suspensionReason sql.NullString
 	err := r.db.QueryRowContext(ctx, query, slug).Scan(
 		&organization.ID,
 		&organization.Name,
 		&organization.Slug,
-		&organization.Type,
+		&orgType,
+		&address,
 		&organization.CreatedAt,
 		&organization.UpdatedAt,
 		&organization.IsSystemOrg,
+		pq.Array(&organization.MFARequiredRoles),
+		&organization.SuspendedAt,
+		&suspensionReason,
 	)
 
 	if err == sql.ErrNoRows {
@@ -147,6 +171,11 @@ func (r *OrganizationRepoPostgres) GetBySlug(ctx context.Context, slug string) (
 		r.log.WithError(err).WithField("slug", slug).Error("failed to get organization by slug")
 		return nil, fmt.Errorf("failed to get organization by slug: %w", err)
 	}
+	// The system organization has no type.
+	organization.Type = domain.OrgType(orgType.String)
+	organization.Address = address.String
+	organization.SuspensionReason = suspensionReason.String
+	organization.IsActive = !organization.IsSuspended()
 
 	return organization, nil
 }
@@ -171,3 +200,99 @@ func (r *OrganizationRepoPostgres) GetIDByUserID(ctx context.Context, userID uui
 
 	return orgID, nil
 }
+
+func (r *OrganizationRepoPostgres) UpdateMFARequiredRoles(ctx context.Context, orgID uuid.UUID, roleNames []string) error {
+	query := `
+		UPDATE organizations
+		SET mfa_required_roles = $2, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, orgID, pq.Array(roleNames))
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to update mfa required roles")
+		return fmt.Errorf("failed to update mfa required roles: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("organization not found or already deleted")
+	}
+
+	r.log.WithFields(logrus.Fields{"org_id": orgID, "roles": roleNames}).Info("mfa required roles updated")
+	return nil
+}
+
+func (r *OrganizationRepoPostgres) SetSuspended(ctx context.Context, orgID uuid.UUID, suspendedAt *time.Time, reason string) error {
+	query := `
+		UPDATE organizations
+		SET suspended_at = $2, suspension_reason = NULLIF($3, ''), updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, orgID, suspendedAt, reason)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to update organization suspension")
+		return fmt.Errorf("failed to update organization suspension: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("organization not found or already deleted")
+	}
+
+	r.log.WithFields(logrus.Fields{"org_id": orgID, "suspended": suspendedAt != nil}).Info("organization suspension updated")
+	return nil
+}
+
+func (r *OrganizationRepoPostgres) GetSettings(ctx context.Context, orgID uuid.UUID) (*domain.Settings, error) {
+	query := `
+		SELECT settings
+		FROM organizations
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	var raw []byte
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to get organization settings")
+		return nil, fmt.Errorf("failed to get organization settings: %w", err)
+	}
+
+	// Keys the stored document lacks keep their defaults.
+	settings := domain.DefaultSettings()
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			r.log.WithError(err).WithField("org_id", orgID).Error("failed to decode organization settings")
+			return nil, fmt.Errorf("failed to decode organization settings: %w", err)
+		}
+	}
+
+	return &settings, nil
+}
+
+func (r *OrganizationRepoPostgres) UpdateSettings(ctx context.Context, orgID uuid.UUID, settings *domain.Settings) error {
+	query := `
+		UPDATE organizations
+		SET settings = $2, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode organization settings: %w", err)
+	}
+
+	res, err := r.db.ExecContext(ctx, query, orgID, raw)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to update organization settings")
+		return fmt.Errorf("failed to update organization settings: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("organization not found or already deleted")
+	}
+
+	r.log.WithField("org_id", orgID).Info("organization settings updated")
+	return nil
+}
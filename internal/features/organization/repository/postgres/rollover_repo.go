@@ -0,0 +1,339 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type RolloverRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewRolloverRepository(db database.DB, log *logrus.Logger) domain.RolloverRepository {
+	return &RolloverRepoPostgres{db: db, log: log}
+}
+
+func (r *RolloverRepoPostgres) LoadSource(ctx context.Context, periodID, orgID uuid.UUID) (*domain.RolloverSource, error) {
+	source := &domain.RolloverSource{}
+
+	if err := r.loadLevels(ctx, orgID, source); err != nil {
+		return nil, err
+	}
+
+	cohorts := make(map[uuid.UUID]*domain.SourceCohort)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, education_level_id
+		FROM cohorts
+		WHERE academic_period_id = $1 AND organization_id = $2 AND deleted_at IS NULL
+		ORDER BY name`, periodID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cohorts: %w", err)
+	}
+	var order []uuid.UUID
+	for rows.Next() {
+		var c domain.SourceCohort
+		var levelID uuid.NullUUID
+		if err := rows.Scan(&c.ID, &c.Name, &levelID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan cohort: %w", err)
+		}
+		c.EducationLevelID = levelID.UUID
+		cohorts[c.ID] = &c
+		order = append(order, c.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohorts: %w", err)
+	}
+
+	sections, err := r.loadSections(ctx, periodID, orgID, cohorts)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadSectionMembers(ctx, periodID, orgID, sections); err != nil {
+		return nil, err
+	}
+	if err := r.loadOfferings(ctx, periodID, orgID, sections); err != nil {
+		return nil, err
+	}
+	if err := r.loadCohortStudents(ctx, periodID, orgID, cohorts); err != nil {
+		return nil, err
+	}
+
+	for _, id := range order {
+		c := cohorts[id]
+		for _, s := range sections {
+			if s.cohortID == id {
+				c.Sections = append(c.Sections, s.SourceSection)
+			}
+		}
+		source.Cohorts = append(source.Cohorts, *c)
+	}
+
+	return source, nil
+}
+
+type sourceSection struct {
+	domain.SourceSection
+	cohortID uuid.UUID
+}
+
+func (r *RolloverRepoPostgres) loadLevels(ctx context.Context, orgID uuid.UUID, source *domain.RolloverSource) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, sequence
+		FROM education_levels
+		WHERE organization_id = $1 AND deleted_at IS NULL`, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to load education levels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l domain.LevelRef
+		var sequence sql.NullInt64
+		if err := rows.Scan(&l.ID, &l.Name, &sequence); err != nil {
+			return fmt.Errorf("failed to scan education level: %w", err)
+		}
+		if sequence.Valid {
+			n := int(sequence.Int64)
+			l.Sequence = &n
+		}
+		source.Levels = append(source.Levels, l)
+	}
+	return rows.Err()
+}
+
+func (r *RolloverRepoPostgres) loadSections(ctx context.Context, periodID, orgID uuid.UUID, cohorts map[uuid.UUID]*domain.SourceCohort) ([]*sourceSection, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.id, s.cohort_id, s.name, COALESCE(s.room_number, ''), COALESCE(s.capacity, 0)
+		FROM sections s
+		JOIN cohorts c ON c.id = s.cohort_id
+		WHERE c.academic_period_id = $1 AND c.organization_id = $2
+		  AND c.deleted_at IS NULL AND s.deleted_at IS NULL
+		ORDER BY s.name`, periodID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sections: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []*sourceSection
+	for rows.Next() {
+		s := &sourceSection{}
+		if err := rows.Scan(&s.ID, &s.cohortID, &s.Name, &s.RoomCode, &s.Capacity); err != nil {
+			return nil, fmt.Errorf("failed to scan section: %w", err)
+		}
+		if _, ok := cohorts[s.cohortID]; ok {
+			sections = append(sections, s)
+		}
+	}
+	return sections, rows.Err()
+}
+
+func (r *RolloverRepoPostgres) loadSectionMembers(ctx context.Context, periodID, orgID uuid.UUID, sections []*sourceSection) error {
+	byID := make(map[uuid.UUID]*sourceSection, len(sections))
+	for _, s := range sections {
+		byID[s.ID] = s
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT sm.section_id, sm.user_id, sm.role_type
+		FROM section_members sm
+		JOIN sections s ON s.id = sm.section_id
+		JOIN cohorts c ON c.id = s.cohort_id
+		JOIN users u ON u.id = sm.user_id
+		WHERE c.academic_period_id = $1 AND c.organization_id = $2
+		  AND c.deleted_at IS NULL AND s.deleted_at IS NULL
+		  AND u.deleted_at IS NULL AND u.deactivated_at IS NULL`, periodID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to load section members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sectionID, userID uuid.UUID
+		var roleType string
+		if err := rows.Scan(&sectionID, &userID, &roleType); err != nil {
+			return fmt.Errorf("failed to scan section member: %w", err)
+		}
+		s, ok := byID[sectionID]
+		if !ok {
+			continue
+		}
+		if roleType == "student" {
+			s.Students = append(s.Students, userID)
+		} else {
+			s.Staff = append(s.Staff, domain.StaffAssignment{UserID: userID, RoleType: roleType})
+		}
+	}
+	return rows.Err()
+}
+
+// loadOfferings reads which courses each section taught, from the period's
+// enrollments that were not dropped.
+func (r *RolloverRepoPostgres) loadOfferings(ctx context.Context, periodID, orgID uuid.UUID, sections []*sourceSection) error {
+	byID := make(map[uuid.UUID]*sourceSection, len(sections))
+	for _, s := range sections {
+		byID[s.ID] = s
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT e.section_id, e.course_id
+		FROM enrollments e
+		JOIN courses co ON co.id = e.course_id
+		WHERE e.academic_period_id = $1 AND co.organization_id = $2
+		  AND e.deleted_at IS NULL AND co.deleted_at IS NULL
+		  AND e.status <> 'dropped'
+		ORDER BY e.section_id, e.course_id`, periodID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to load course offerings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sectionID, courseID uuid.UUID
+		if err := rows.Scan(&sectionID, &courseID); err != nil {
+			return fmt.Errorf("failed to scan course offering: %w", err)
+		}
+		if s, ok := byID[sectionID]; ok {
+			s.CourseIDs = append(s.CourseIDs, courseID)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *RolloverRepoPostgres) loadCohortStudents(ctx context.Context, periodID, orgID uuid.UUID, cohorts map[uuid.UUID]*domain.SourceCohort) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT cm.cohort_id, cm.user_id
+		FROM cohort_members cm
+		JOIN cohorts c ON c.id = cm.cohort_id
+		JOIN users u ON u.id = cm.user_id
+		WHERE c.academic_period_id = $1 AND c.organization_id = $2
+		  AND c.deleted_at IS NULL AND cm.deleted_at IS NULL
+		  AND u.deleted_at IS NULL AND u.deactivated_at IS NULL
+		ORDER BY cm.cohort_id, u.last_name, u.first_name`, periodID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to load cohort members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cohortID, userID uuid.UUID
+		if err := rows.Scan(&cohortID, &userID); err != nil {
+			return fmt.Errorf("failed to scan cohort member: %w", err)
+		}
+		if c, ok := cohorts[cohortID]; ok {
+			c.Students = append(c.Students, userID)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *RolloverRepoPostgres) Apply(ctx context.Context, plan *domain.RolloverPlan, orgID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	period := plan.Period
+	period.PrepareCreate(nil)
+	period.Status = domain.PeriodPlanned
+	period.IsActive = false
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO academic_periods (id, organization_id, name, start_date, end_date, status, is_active, previous_period_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		period.ID, orgID, period.Name, period.StartDate, period.EndDate,
+		period.Status, period.IsActive, period.PreviousPeriodID, period.CreatedAt, period.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create academic period: %w", err)
+	}
+
+	insertCohort, err := tx.PrepareContext(ctx, `
+		INSERT INTO cohorts (id, organization_id, academic_period_id, education_level_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now(), now())`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare cohort insert: %w", err)
+	}
+	insertSection, err := tx.PrepareContext(ctx, `
+		INSERT INTO sections (id, cohort_id, name, room_number, capacity, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, now(), now())`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare section insert: %w", err)
+	}
+	insertSectionMember, err := tx.PrepareContext(ctx, `
+		INSERT INTO section_members (section_id, user_id, role_type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (section_id, user_id) DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare section member insert: %w", err)
+	}
+	insertCohortMember, err := tx.PrepareContext(ctx, `
+		INSERT INTO cohort_members (cohort_id, user_id, created_at, updated_at)
+		VALUES ($1, $2, now(), now())
+		ON CONFLICT (cohort_id, user_id) DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare cohort member insert: %w", err)
+	}
+	insertEnrollment, err := tx.PrepareContext(ctx, `
+		INSERT INTO enrollments (id, user_id, course_id, section_id, academic_period_id, status, enrolled_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, 'pending', now(), now(), now())`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare enrollment insert: %w", err)
+	}
+
+	for _, c := range plan.Cohorts {
+		levelID := uuid.NullUUID{UUID: c.EducationLevelID, Valid: c.EducationLevelID != uuid.Nil}
+		if _, err := insertCohort.ExecContext(ctx, c.ID, orgID, period.ID, levelID, c.Name); err != nil {
+			return fmt.Errorf("failed to copy cohort %s: %w", c.Name, err)
+		}
+		for _, s := range c.Sections {
+			if _, err := insertSection.ExecContext(ctx, s.ID, c.ID, s.Name, s.RoomCode, s.Capacity); err != nil {
+				return fmt.Errorf("failed to copy section %s: %w", s.Name, err)
+			}
+			for _, staff := range s.Staff {
+				if _, err := insertSectionMember.ExecContext(ctx, s.ID, staff.UserID, staff.RoleType); err != nil {
+					return fmt.Errorf("failed to copy staff of section %s: %w", s.Name, err)
+				}
+			}
+		}
+	}
+
+	for _, p := range plan.Promotions {
+		if _, err := insertCohortMember.ExecContext(ctx, p.ToCohortID, p.UserID); err != nil {
+			return fmt.Errorf("failed to promote student %s: %w", p.UserID, err)
+		}
+		if p.ToSectionID == nil {
+			continue
+		}
+		if _, err := insertSectionMember.ExecContext(ctx, *p.ToSectionID, p.UserID, "student"); err != nil {
+			return fmt.Errorf("failed to place student %s: %w", p.UserID, err)
+		}
+		for _, courseID := range p.CourseIDs {
+			if _, err := insertEnrollment.ExecContext(ctx, uuid.New(), p.UserID, courseID, *p.ToSectionID, period.ID); err != nil {
+				return fmt.Errorf("failed to enroll student %s: %w", p.UserID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rollover: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{
+		"org_id":      orgID,
+		"period_id":   period.ID,
+		"cohorts":     len(plan.Cohorts),
+		"promotions":  len(plan.Promotions),
+		"enrollments": plan.EnrollmentCount(),
+	}).Info("academic period rolled over")
+	return nil
+}
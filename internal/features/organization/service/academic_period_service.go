@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrAcademicPeriodNotFound = errors.New("academic period not found")
+	ErrAcademicPeriodActive   = errors.New("the active academic period cannot be deleted")
+	ErrAcademicPeriodInUse    = errors.New("academic period still has cohorts or enrollments")
+	ErrAcademicPeriodArchived = errors.New("archived academic periods cannot be changed")
+	ErrAlreadyRolledOver      = errors.New("academic period has already been rolled over")
+	ErrInvalidRollover        = errors.New("invalid rollover")
+)
+
+type academicPeriodService struct {
+	periodRepo   domain.AcademicPeriodRepository
+	rolloverRepo domain.RolloverRepository
+	log          *logrus.Logger
+}
+
+func NewAcademicPeriodService(pr domain.AcademicPeriodRepository, rr domain.RolloverRepository, log *logrus.Logger) AcademicPeriods {
+	return &academicPeriodService{
+		periodRepo:   pr,
+		rolloverRepo: rr,
+		log:          log,
+	}
+}
+
+func (s *academicPeriodService) List(ctx context.Context, orgID uuid.UUID) ([]domain.AcademicPeriod, error) {
+	if err := authorizeOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.periodRepo.ListByOrganizationID(ctx, orgID)
+}
+
+func (s *academicPeriodService) Get(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error) {
+	if err := authorizeOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	period, err := s.periodRepo.GetByID(ctx, periodID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, ErrAcademicPeriodNotFound
+	}
+	return period, nil
+}
+
+// Create adds a planned period. An organization's first period is made
+// active straight away so rosters have somewhere to go.
+func (s *academicPeriodService) Create(ctx context.Context, orgID uuid.UUID, input AcademicPeriodInput) (*domain.AcademicPeriod, error) {
+	if err := authorizeOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	period := domain.NewAcademicPeriod(input.Name, input.StartDate, input.EndDate)
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	active, err := s.periodRepo.GetActiveByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	period.Status = domain.PeriodPlanned
+	if active == nil {
+		period.Status = domain.PeriodActive
+	}
+
+	if err := s.periodRepo.Create(ctx, period, orgID); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "period_id": period.ID, "status": period.Status}).Info("academic period created")
+	return period, nil
+}
+
+func (s *academicPeriodService) Update(ctx context.Context, orgID, periodID uuid.UUID, input AcademicPeriodInput) (*domain.AcademicPeriod, error) {
+	period, err := s.Get(ctx, orgID, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if period.Status == domain.PeriodArchived {
+		return nil, ErrAcademicPeriodArchived
+	}
+
+	period.Name = input.Name
+	period.StartDate = input.StartDate
+	period.EndDate = input.EndDate
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.periodRepo.Update(ctx, period, orgID); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "period_id": periodID}).Info("academic period updated")
+	return period, nil
+}
+
+func (s *academicPeriodService) Delete(ctx context.Context, orgID, periodID uuid.UUID) error {
+	period, err := s.Get(ctx, orgID, periodID)
+	if err != nil {
+		return err
+	}
+	if period.IsActive {
+		return ErrAcademicPeriodActive
+	}
+
+	inUse, err := s.periodRepo.InUse(ctx, periodID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrAcademicPeriodInUse
+	}
+
+	if err := s.periodRepo.Delete(ctx, periodID, orgID); err != nil {
+		return fmt.Errorf("failed to delete academic period: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "period_id": periodID}).Info("academic period deleted")
+	return nil
+}
+
+// Activate closes the organization's current period, completing its
+// enrollments, and starts this one with any enrollments a rollover prepared.
+func (s *academicPeriodService) Activate(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error) {
+	period, err := s.Get(ctx, orgID, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if err := period.TransitionTo(domain.PeriodActive); err != nil {
+		return nil, err
+	}
+
+	if err := s.periodRepo.Activate(ctx, periodID, orgID); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "period_id": periodID}).Info("academic period activated")
+	return period, nil
+}
+
+func (s *academicPeriodService) Close(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error) {
+	return s.transition(ctx, orgID, periodID, domain.PeriodClosed)
+}
+
+func (s *academicPeriodService) Archive(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error) {
+	return s.transition(ctx, orgID, periodID, domain.PeriodArchived)
+}
+
+func (s *academicPeriodService) transition(ctx context.Context, orgID, periodID uuid.UUID, status domain.PeriodStatus) (*domain.AcademicPeriod, error) {
+	period, err := s.Get(ctx, orgID, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if err := period.TransitionTo(status); err != nil {
+		return nil, err
+	}
+
+	if err := s.periodRepo.SetStatus(ctx, periodID, orgID, status); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "period_id": periodID, "status": status}).Info("academic period status changed")
+	return period, nil
+}
+
+func (s *academicPeriodService) Rollover(ctx context.Context, orgID, periodID uuid.UUID, input RolloverInput) (*domain.RolloverPlan, error) {
+	source, err := s.Get(ctx, orgID, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Status != domain.PeriodActive && source.Status != domain.PeriodClosed {
+		return nil, fmt.Errorf("%w: only active or closed periods can be rolled over", ErrInvalidRollover)
+	}
+
+	existing, err := s.periodRepo.GetByPreviousPeriodID(ctx, periodID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w into '%s'", ErrAlreadyRolledOver, existing.Name)
+	}
+
+	next := domain.NewAcademicPeriod(input.Name, input.StartDate, input.EndDate)
+	next.Status = domain.PeriodPlanned
+	next.IsActive = false
+	next.PreviousPeriodID = &source.ID
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+	if !next.StartDate.After(source.StartDate) {
+		return nil, fmt.Errorf("%w: the new period must start after '%s' starts", ErrInvalidRollover, source.Name)
+	}
+
+	snapshot, err := s.rolloverRepo.LoadSource(ctx, periodID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRolloverOptions(snapshot, input); err != nil {
+		return nil, err
+	}
+
+	plan := domain.PlanRollover(*snapshot, next, domain.RolloverOptions{
+		LevelOrder: input.LevelOrder,
+		PromoteTo:  input.PromoteTo,
+	})
+	if input.Preview {
+		return plan, nil
+	}
+
+	if err := s.rolloverRepo.Apply(ctx, plan, orgID); err != nil {
+		return nil, err
+	}
+
+	if input.Activate {
+		if _, err := s.Activate(ctx, orgID, next.ID); err != nil {
+			return nil, fmt.Errorf("rollover succeeded but activation failed: %w", err)
+		}
+		next.Status = domain.PeriodActive
+		next.IsActive = true
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"org_id":     orgID,
+		"source_id":  periodID,
+		"period_id":  next.ID,
+		"promotions": len(plan.Promotions),
+		"graduates":  len(plan.Graduates),
+		"unplaced":   len(plan.Unplaced),
+	}).Info("academic period rollover completed")
+	return plan, nil
+}
+
+func validateRolloverOptions(source *domain.RolloverSource, input RolloverInput) error {
+	levels := make(map[uuid.UUID]bool, len(source.Levels))
+	for _, l := range source.Levels {
+		levels[l.ID] = true
+	}
+	seen := make(map[uuid.UUID]bool, len(input.LevelOrder))
+	for _, id := range input.LevelOrder {
+		if !levels[id] {
+			return fmt.Errorf("%w: education level %s does not belong to this organization", ErrInvalidRollover, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: education level %s appears twice in level_order", ErrInvalidRollover, id)
+		}
+		seen[id] = true
+	}
+
+	cohorts := make(map[uuid.UUID]bool, len(source.Cohorts))
+	for _, c := range source.Cohorts {
+		cohorts[c.ID] = true
+	}
+	for from, to := range input.PromoteTo {
+		if !cohorts[from] || !cohorts[to] {
+			return fmt.Errorf("%w: promote_to must map cohorts of the period being rolled over", ErrInvalidRollover)
+		}
+	}
+	return nil
+}
@@ -2,13 +2,78 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
 	"github.com/google/uuid"
 )
 
+// OrganizationUpdate carries the profile fields an administrator may change;
+// nil fields are left as they are.
+type OrganizationUpdate struct {
+	Name    *string
+	Address *string
+	Type    *domain.OrgType
+}
+
+type AcademicPeriodInput struct {
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type RolloverInput struct {
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+
+	// LevelOrder and PromoteTo override how students are promoted; see
+	// domain.RolloverOptions.
+	LevelOrder []uuid.UUID
+	PromoteTo  map[uuid.UUID]uuid.UUID
+
+	// Preview returns the plan without writing anything.
+	Preview bool
+	// Activate makes the new period active straight away, closing the
+	// current one.
+	Activate bool
+}
+
 type CRUD interface {
-	RegisterOrganization(ctx context.Context, name, slug, address string, isActive bool) (*domain.Organization, error)
-	DeleteOrganization(ctx context.Context, orgID uuid.UUID) (error) 
+	RegisterOrganization(ctx context.Context, name, slug, address string, orgType domain.OrgType) (*domain.Organization, error)
+	UpdateOrganization(ctx context.Context, orgID uuid.UUID, input OrganizationUpdate) (*domain.Organization, error)
+	DeleteOrganization(ctx context.Context, orgID uuid.UUID) error
 	GetOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error)
-}
\ No newline at end of file
+}
+
+// AcademicPeriods manages the periods of the caller's own organization.
+type AcademicPeriods interface {
+	List(ctx context.Context, orgID uuid.UUID) ([]domain.AcademicPeriod, error)
+	Get(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error)
+	Create(ctx context.Context, orgID uuid.UUID, input AcademicPeriodInput) (*domain.AcademicPeriod, error)
+	Update(ctx context.Context, orgID, periodID uuid.UUID, input AcademicPeriodInput) (*domain.AcademicPeriod, error)
+	Delete(ctx context.Context, orgID, periodID uuid.UUID) error
+
+	Activate(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error)
+	Close(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error)
+	Archive(ctx context.Context, orgID, periodID uuid.UUID) (*domain.AcademicPeriod, error)
+	// Rollover creates the period after periodID with copies of its cohorts,
+	// sections, teachers and course offerings, and promotes its students.
+	Rollover(ctx context.Context, orgID, periodID uuid.UUID, input RolloverInput) (*domain.RolloverPlan, error)
+}
+
+// OrganizationSettings serves the settings endpoints and, through
+// domain.SettingsProvider, every other feature that needs them.
+type OrganizationSettings interface {
+	domain.SettingsProvider
+	// View and Update only work on the caller's own organization.
+	View(ctx context.Context, orgID uuid.UUID) (*domain.Settings, error)
+	Update(ctx context.Context, orgID uuid.UUID, settings domain.Settings) (*domain.Settings, error)
+}
+
+// TenantResolver maps the slug in an organization's own address, such as
+// its subdomain, to the organization.
+type TenantResolver interface {
+	// ResolveSlug returns ErrOrganizationNotFound for unknown slugs.
+	ResolveSlug(ctx context.Context, slug string) (uuid.UUID, error)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrSlugTaken            = errors.New("an organization with this slug already exists")
+	ErrSystemOrganization   = errors.New("the system organization cannot be changed")
+)
+
+type organizationService struct {
+	orgRepo domain.OrganizationRepository
+	log     *logrus.Logger
+}
+
+func NewOrganizationService(or domain.OrganizationRepository, log *logrus.Logger) CRUD {
+	return &organizationService{
+		orgRepo: or,
+		log:     log,
+	}
+}
+
+func (s *organizationService) RegisterOrganization(ctx context.Context, name, slug, address string, orgType domain.OrgType) (*domain.Organization, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if err := domain.ValidateSlug(slug); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOrganization, err)
+	}
+
+	org := domain.NewOrganization(name, slug, orgType, address, nil)
+	if err := org.ValidateProfile(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.orgRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrSlugTaken
+	}
+
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to register organization: %w", err)
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": org.ID, "slug": slug, "actor_id": actorID}).Info("organization registered")
+	return s.orgRepo.GetByID(ctx, org.ID)
+}
+
+// GetOrganization only returns the caller's own organization; system
+// administrators look at other tenants through the system API.
+func (s *organizationService) GetOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	if err := authorizeOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+func (s *organizationService) UpdateOrganization(ctx context.Context, orgID uuid.UUID, input OrganizationUpdate) (*domain.Organization, error) {
+	org, err := s.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org.IsSystem() {
+		return nil, ErrSystemOrganization
+	}
+
+	if input.Name != nil {
+		org.Name = *input.Name
+	}
+	if input.Address != nil {
+		org.Address = *input.Address
+	}
+	if input.Type != nil {
+		org.Type = *input.Type
+	}
+	if err := org.ValidateProfile(); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "actor_id": actorID}).Info("organization profile updated")
+	return s.orgRepo.GetByID(ctx, orgID)
+}
+
+func (s *organizationService) DeleteOrganization(ctx context.Context, orgID uuid.UUID) error {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return ErrOrganizationNotFound
+	}
+	if org.IsSystem() {
+		return ErrSystemOrganization
+	}
+
+	if err := s.orgRepo.Delete(ctx, orgID); err != nil {
+		return err
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "actor_id": actorID}).Warn("organization deleted")
+	return nil
+}
+
+func authorizeOrganization(ctx context.Context, orgID uuid.UUID) error {
+	if callerOrgID, ok := auth.GetOrgID(ctx); !ok || callerOrgID != orgID {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const settingsCacheTTL = time.Hour
+
+type settingsService struct {
+	orgRepo domain.OrganizationRepository
+	redis   *redis.Client
+	log     *logrus.Logger
+}
+
+func NewSettingsService(or domain.OrganizationRepository, redisClient *redis.Client, log *logrus.Logger) OrganizationSettings {
+	return &settingsService{
+		orgRepo: or,
+		redis:   redisClient,
+		log:     log,
+	}
+}
+
+func settingsCacheKey(orgID uuid.UUID) string {
+	return fmt.Sprintf("v1:org:settings:%s", orgID)
+}
+
+// Get reads through the cache. A Redis outage only costs a database query.
+func (s *settingsService) Get(ctx context.Context, orgID uuid.UUID) (*domain.Settings, error) {
+	key := settingsCacheKey(orgID)
+	if val, err := s.redis.Get(ctx, key).Bytes(); err == nil {
+		var cached domain.Settings
+		if err := json.Unmarshal(val, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	settings, err := s.orgRepo.GetSettings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	if data, err := json.Marshal(settings); err == nil {
+		if err := s.redis.Set(ctx, key, data, settingsCacheTTL).Err(); err != nil {
+			s.log.WithError(err).WithField("org_id", orgID).Warn("failed to cache organization settings")
+		}
+	}
+
+	return settings, nil
+}
+
+func (s *settingsService) View(ctx context.Context, orgID uuid.UUID) (*domain.Settings, error) {
+	if err := authorizeOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, orgID)
+}
+
+func (s *settingsService) Update(ctx context.Context, orgID uuid.UUID, settings domain.Settings) (*domain.Settings, error) {
+	if err := authorizeOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.UpdateSettings(ctx, orgID, &settings); err != nil {
+		return nil, err
+	}
+
+	if err := s.redis.Del(ctx, settingsCacheKey(orgID)).Err(); err != nil {
+		s.log.WithError(err).WithField("org_id", orgID).Warn("failed to invalidate organization settings cache")
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "actor_id": actorID}).Info("organization settings changed")
+	return &settings, nil
+}
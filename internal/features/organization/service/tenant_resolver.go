@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// Slugs never change, so an entry can only go stale when its organization
+// is deleted. Requests for a deleted organization are refused later anyway:
+// logins find no organization to start a session in and tokens fail
+// middleware.RequireActiveOrganization.
+const tenantCacheTTL = 10 * time.Minute
+
+type tenantResolver struct {
+	orgRepo domain.OrganizationRepository
+	redis   *redis.Client
+	log     *logrus.Logger
+}
+
+func NewTenantResolver(or domain.OrganizationRepository, redisClient *redis.Client, log *logrus.Logger) TenantResolver {
+	return &tenantResolver{
+		orgRepo: or,
+		redis:   redisClient,
+		log:     log,
+	}
+}
+
+func tenantCacheKey(slug string) string {
+	return fmt.Sprintf("v1:org:slug:%s", slug)
+}
+
+// ResolveSlug reads through the cache. Unknown slugs are not cached, so an
+// organization onboarded a moment ago resolves straight away.
+func (s *tenantResolver) ResolveSlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	if domain.ValidateSlug(slug) != nil {
+		return uuid.Nil, ErrOrganizationNotFound
+	}
+
+	key := tenantCacheKey(slug)
+	if val, err := s.redis.Get(ctx, key).Result(); err == nil {
+		if orgID, err := uuid.Parse(val); err == nil {
+			return orgID, nil
+		}
+	}
+
+	org, err := s.orgRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if org == nil {
+		return uuid.Nil, ErrOrganizationNotFound
+	}
+
+	if err := s.redis.Set(ctx, key, org.ID.String(), tenantCacheTTL).Err(); err != nil {
+		s.log.WithError(err).WithField("slug", slug).Warn("failed to cache organization slug")
+	}
+	return org.ID, nil
+}
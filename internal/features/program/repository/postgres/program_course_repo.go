@@ -2,17 +2,17 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/program/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 )
 
 type ProgramCourseRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewProgramCourseRepository(db *sql.DB) domain.ProgramCourseRepository {
+func NewProgramCourseRepository(db database.DB) domain.ProgramCourseRepository {
 	return &ProgramCourseRepoPostgres{db: db}
 }
 
@@ -6,14 +6,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/program/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type ProgramRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewProgramRepository(db *sql.DB) domain.ProgramRepository {
+func NewProgramRepository(db database.DB) domain.ProgramRepository {
 	return &ProgramRepoPostgres{db: db}
 }
 
@@ -2,17 +2,17 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/progress_tracker/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 )
 
 type ProgressTrackerRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewProgressTrackerRepository(db *sql.DB) domain.ProgressTrackerRepository {
+func NewProgressTrackerRepository(db database.DB) domain.ProgressTrackerRepository {
 	return &ProgressTrackerRepoPostgres{db: db}
 }
 
@@ -0,0 +1,15 @@
+package dto
+
+type RowErrorResponse struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportReportResponse struct {
+	DryRun   bool               `json:"dry_run"`
+	Total    int                `json:"total"`
+	Valid    int                `json:"valid"`
+	Imported int                `json:"imported"`
+	Errors   []RowErrorResponse `json:"errors"`
+}
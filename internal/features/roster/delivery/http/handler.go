@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/spreadsheet"
+	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const maxRosterSize = 10 << 20
+
+type RosterHandler struct {
+	rosterService service.RosterImporter
+	log           *logrus.Logger
+}
+
+func NewRosterHandler(rosterService service.RosterImporter, log *logrus.Logger) *RosterHandler {
+	return &RosterHandler{
+		rosterService: rosterService,
+		log:           log,
+	}
+}
+
+func (h *RosterHandler) ProtectedRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(middleware.RequirePermission("user", "create")).Post("/import", h.Import)
+
+	return r
+}
+
+// Import accepts a CSV or XLSX file in the "file" form field. With
+// ?dry_run=true nothing is written and the per-row report is returned.
+func (h *RosterHandler) Import(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := auth.GetOrgID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "Organization not found in context")
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	invite, _ := strconv.ParseBool(r.URL.Query().Get("invite"))
+
+	if err := r.ParseMultipartForm(maxRosterSize); err != nil {
+		h.log.WithError(err).Warn("failed to parse multipart form")
+		response.BadRequest(w, "File too large or invalid form data")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, "File is required (field name: 'file')")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxRosterSize))
+	if err != nil {
+		response.BadRequest(w, "Failed to read file")
+		return
+	}
+
+	records, err := spreadsheet.Read(header.Filename, data)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	report, err := h.rosterService.Import(r.Context(), orgID, records, service.ImportOptions{DryRun: dryRun, Invite: invite})
+	if errors.Is(err, domain.ErrInvalidRoster) || errors.Is(err, service.ErrNoActivePeriod) {
+		response.UnprocessableEntity(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("org_id", orgID).Error("failed to import roster")
+		response.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := ToImportReportResponse(report)
+	switch {
+	case report.HasErrors() && !dryRun:
+		response.Invalid(w, res, "the roster has errors, nothing was imported")
+	case dryRun:
+		response.OK(w, res)
+	default:
+		response.Created(w, res)
+	}
+}
+
+func ToImportReportResponse(report *domain.ImportReport) dto.ImportReportResponse {
+	errs := make([]dto.RowErrorResponse, 0, len(report.Errors))
+	for _, e := range report.Errors {
+		errs = append(errs, dto.RowErrorResponse{Line: e.Line, Field: e.Field, Message: e.Message})
+	}
+
+	return dto.ImportReportResponse{
+		DryRun:   report.DryRun,
+		Total:    report.Total,
+		Valid:    report.Valid,
+		Imported: report.Imported,
+		Errors:   errs,
+	}
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidRoster = errors.New("invalid roster")
+
+// Column names accepted in the header row. A single "name" column is split
+// at the first space; "first_name" and "last_name" may be used instead.
+const (
+	ColumnName          = "name"
+	ColumnFirstName     = "first_name"
+	ColumnLastName      = "last_name"
+	ColumnEmail         = "email"
+	ColumnCohort        = "cohort"
+	ColumnSection       = "section"
+	ColumnGuardianEmail = "guardian_email"
+)
+
+// RosterRow is one student of an uploaded roster. Line is the 1-based line
+// in the file so errors can point at it.
+type RosterRow struct {
+	Line          int
+	FirstName     string
+	LastName      string
+	Email         string
+	Cohort        string
+	Section       string
+	GuardianEmail string
+}
+
+type RowError struct {
+	Line    int
+	Field   string
+	Message string
+}
+
+type ImportReport struct {
+	DryRun   bool
+	Total    int
+	Valid    int
+	Imported int
+	Errors   []RowError
+}
+
+func (r *ImportReport) AddError(line int, field, message string) {
+	r.Errors = append(r.Errors, RowError{Line: line, Field: field, Message: message})
+}
+
+func (r *ImportReport) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// StudentImport is a validated row resolved to the records it creates.
+type StudentImport struct {
+	User      *userDomain.User
+	CohortID  uuid.UUID
+	SectionID *uuid.UUID
+}
+
+// ParseRoster maps the header row to columns and checks each row on its own:
+// required fields, email syntax and duplicates within the file. Blank rows
+// are skipped; rows with errors are recorded in report and left out of the
+// result. It fails only when the file as a whole is unusable.
+func ParseRoster(records [][]string, report *ImportReport) ([]RosterRow, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidRoster)
+	}
+
+	columns := make(map[string]int)
+	for i, h := range records[0] {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), " ", "_")
+		if _, dup := columns[key]; dup {
+			return nil, fmt.Errorf("%w: column %q appears twice", ErrInvalidRoster, key)
+		}
+		columns[key] = i
+	}
+
+	_, hasName := columns[ColumnName]
+	_, hasFirstName := columns[ColumnFirstName]
+	if !hasName && !hasFirstName {
+		return nil, fmt.Errorf("%w: a %q or %q column is required", ErrInvalidRoster, ColumnName, ColumnFirstName)
+	}
+	for _, required := range []string{ColumnEmail, ColumnCohort} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: a %q column is required", ErrInvalidRoster, required)
+		}
+	}
+
+	var rows []RosterRow
+	seen := make(map[string]int)
+
+	for i, record := range records[1:] {
+		line := i + 2
+		cell := func(column string) string {
+			idx, ok := columns[column]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		if isBlank(record) {
+			continue
+		}
+		report.Total++
+
+		row := RosterRow{
+			Line:          line,
+			FirstName:     cell(ColumnFirstName),
+			LastName:      cell(ColumnLastName),
+			Email:         strings.ToLower(cell(ColumnEmail)),
+			Cohort:        cell(ColumnCohort),
+			Section:       cell(ColumnSection),
+			GuardianEmail: strings.ToLower(cell(ColumnGuardianEmail)),
+		}
+		if row.FirstName == "" {
+			first, last, _ := strings.Cut(cell(ColumnName), " ")
+			row.FirstName, row.LastName = first, strings.TrimSpace(last)
+		}
+
+		rowErrs := len(report.Errors)
+		if row.FirstName == "" {
+			report.AddError(line, ColumnName, "name is required")
+		}
+		switch {
+		case row.Email == "":
+			report.AddError(line, ColumnEmail, "email is required")
+		case !validEmail(row.Email):
+			report.AddError(line, ColumnEmail, "email is not a valid address")
+		case seen[row.Email] != 0:
+			report.AddError(line, ColumnEmail, fmt.Sprintf("email is already used on line %d", seen[row.Email]))
+		default:
+			seen[row.Email] = line
+		}
+		if row.Cohort == "" {
+			report.AddError(line, ColumnCohort, "cohort is required")
+		}
+		if row.GuardianEmail != "" {
+			if !validEmail(row.GuardianEmail) {
+				report.AddError(line, ColumnGuardianEmail, "guardian email is not a valid address")
+			} else if row.GuardianEmail == row.Email {
+				report.AddError(line, ColumnGuardianEmail, "guardian email must differ from the student's")
+			}
+		}
+
+		if len(report.Errors) == rowErrs {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func validEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type RosterRepository interface {
+	// ImportStudents creates the users with their roles, cohort and section
+	// memberships in one transaction: either every student is created or none.
+	ImportStudents(ctx context.Context, students []StudentImport) error
+}
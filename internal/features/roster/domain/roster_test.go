@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRoster(t *testing.T) {
+	tests := []struct {
+		name     string
+		records  [][]string
+		wantRows []RosterRow
+		wantErrs []RowError
+		total    int
+	}{
+		{
+			name: "single name column is split",
+			records: [][]string{
+				{"Name", "Email", "Cohort", "Section", "Guardian Email"},
+				{"Ada King Lovelace", "Ada@Example.com ", "10", "10-A", "parent@example.com"},
+			},
+			wantRows: []RosterRow{{
+				Line: 2, FirstName: "Ada", LastName: "King Lovelace", Email: "ada@example.com",
+				Cohort: "10", Section: "10-A", GuardianEmail: "parent@example.com",
+			}},
+			total: 1,
+		},
+		{
+			name: "first and last name columns, blank rows skipped",
+			records: [][]string{
+				{"first_name", "last_name", "email", "cohort"},
+				{"", "", "", ""},
+				{"Alan", "Turing", "alan@example.com", "11"},
+			},
+			wantRows: []RosterRow{{Line: 3, FirstName: "Alan", LastName: "Turing", Email: "alan@example.com", Cohort: "11"}},
+			total:    1,
+		},
+		{
+			name: "row errors",
+			records: [][]string{
+				{"name", "email", "cohort", "guardian_email"},
+				{"", "not-an-email", "", ""},
+				{"Ada", "ada@example.com", "10", "ada@example.com"},
+				{"Grace", "grace@example.com", "10"},
+				{"Grace Again", "GRACE@example.com", "10"},
+			},
+			wantRows: []RosterRow{{Line: 4, FirstName: "Grace", Email: "grace@example.com", Cohort: "10"}},
+			wantErrs: []RowError{
+				{Line: 2, Field: ColumnName, Message: "name is required"},
+				{Line: 2, Field: ColumnEmail, Message: "email is not a valid address"},
+				{Line: 2, Field: ColumnCohort, Message: "cohort is required"},
+				{Line: 3, Field: ColumnGuardianEmail, Message: "guardian email must differ from the student's"},
+				{Line: 5, Field: ColumnEmail, Message: "email is already used on line 4"},
+			},
+			total: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &ImportReport{}
+			rows, err := ParseRoster(tt.records, report)
+			if err != nil {
+				t.Fatalf("ParseRoster() error = %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(report.Errors, tt.wantErrs) {
+				t.Errorf("errors = %+v, want %+v", report.Errors, tt.wantErrs)
+			}
+			if report.Total != tt.total {
+				t.Errorf("total = %d, want %d", report.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestParseRosterInvalidFile(t *testing.T) {
+	tests := map[string][][]string{
+		"empty":            nil,
+		"no name column":   {{"email", "cohort"}},
+		"no cohort column": {{"name", "email"}},
+		"duplicate column": {{"name", "email", "cohort", "Email"}},
+	}
+
+	for name, records := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseRoster(records, &ImportReport{})
+			if !errors.Is(err, ErrInvalidRoster) {
+				t.Errorf("ParseRoster() error = %v, want %v", err, ErrInvalidRoster)
+			}
+		})
+	}
+}
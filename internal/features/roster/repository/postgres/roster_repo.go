@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/sirupsen/logrus"
+)
+
+type RosterRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewRosterRepository(db database.DB, log *logrus.Logger) domain.RosterRepository {
+	return &RosterRepoPostgres{db: db, log: log}
+}
+
+func (r *RosterRepoPostgres) ImportStudents(ctx context.Context, students []domain.StudentImport) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("failed to begin transaction for roster import")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	insertUser, err := tx.PrepareContext(ctx, `
+        INSERT INTO users (
+            id, organization_id, email, password_hash, is_superuser, created_at, updated_at,
+            first_name, last_name, email_verified_at, guardian_id
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare user insert: %w", err)
+	}
+	defer insertUser.Close()
+
+	insertMembership, err := tx.PrepareContext(ctx, `
+        INSERT INTO organization_memberships (user_id, organization_id, created_at)
+        VALUES ($1, $2, $3)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare membership insert: %w", err)
+	}
+	defer insertMembership.Close()
+
+	insertRole, err := tx.PrepareContext(ctx, `INSERT INTO user_roles (user_id, organization_id, role_id) VALUES ($1, $2, $3)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare role insert: %w", err)
+	}
+	defer insertRole.Close()
+
+	insertCohortMember, err := tx.PrepareContext(ctx, `
+        INSERT INTO cohort_members (cohort_id, user_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $3)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare cohort member insert: %w", err)
+	}
+	defer insertCohortMember.Close()
+
+	insertSectionMember, err := tx.PrepareContext(ctx, `
+        INSERT INTO section_members (section_id, user_id, role_type)
+        VALUES ($1, $2, 'student')`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare section member insert: %w", err)
+	}
+	defer insertSectionMember.Close()
+
+	now := time.Now()
+	for _, s := range students {
+		user := s.User
+		user.PrepareCreate(nil)
+
+		if _, err := insertUser.ExecContext(ctx,
+			user.ID, user.OrganizationID, user.Email, user.PasswordHash, user.IsSuperuser, user.CreatedAt, user.UpdatedAt,
+			user.FirstName, user.LastName, user.EmailVerifiedAt, user.GuardianID,
+		); err != nil {
+			r.log.WithError(err).WithField("email", user.Email).Error("failed to insert imported user")
+			return fmt.Errorf("failed to insert user %s: %w", user.Email, err)
+		}
+
+		if _, err := insertMembership.ExecContext(ctx, user.ID, user.OrganizationID, user.CreatedAt); err != nil {
+			return fmt.Errorf("failed to add %s to organization: %w", user.Email, err)
+		}
+
+		for _, role := range user.Roles {
+			if _, err := insertRole.ExecContext(ctx, user.ID, user.OrganizationID, role.ID); err != nil {
+				return fmt.Errorf("failed to assign role to %s: %w", user.Email, err)
+			}
+		}
+
+		if _, err := insertCohortMember.ExecContext(ctx, s.CohortID, user.ID, now); err != nil {
+			return fmt.Errorf("failed to add %s to cohort: %w", user.Email, err)
+		}
+
+		if s.SectionID != nil {
+			if _, err := insertSectionMember.ExecContext(ctx, *s.SectionID, user.ID); err != nil {
+				return fmt.Errorf("failed to add %s to section: %w", user.Email, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.WithError(err).Error("failed to commit roster import")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.WithField("count", len(students)).Info("roster imported")
+	return nil
+}
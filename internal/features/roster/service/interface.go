@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/domain"
+	"github.com/google/uuid"
+)
+
+type ImportOptions struct {
+	// DryRun validates every row and reports errors without writing anything.
+	DryRun bool
+	// Invite sends each imported student a link to choose a password.
+	Invite bool
+}
+
+type RosterImporter interface {
+	Import(ctx context.Context, orgID uuid.UUID, records [][]string, opts ImportOptions) (*domain.ImportReport, error)
+}
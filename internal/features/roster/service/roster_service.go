@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cohortDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/cohort/domain"
+	orgDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/roster/domain"
+	sectionDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/section/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	userService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrNoActivePeriod = errors.New("organization has no active academic period")
+
+type rosterService struct {
+	rosterRepo  domain.RosterRepository
+	userRepo    userDomain.UserRepository
+	roleRepo    userDomain.RoleRepository
+	periodRepo  orgDomain.AcademicPeriodRepository
+	cohortRepo  cohortDomain.CohortRepository
+	sectionRepo sectionDomain.SectionRepository
+	passwords   userService.PasswordRecovery
+	log         *logrus.Logger
+}
+
+func NewRosterService(
+	rr domain.RosterRepository,
+	ur userDomain.UserRepository,
+	roleRepo userDomain.RoleRepository,
+	pr orgDomain.AcademicPeriodRepository,
+	cr cohortDomain.CohortRepository,
+	sr sectionDomain.SectionRepository,
+	passwords userService.PasswordRecovery,
+	log *logrus.Logger,
+) RosterImporter {
+	return &rosterService{
+		rosterRepo:  rr,
+		userRepo:    ur,
+		roleRepo:    roleRepo,
+		periodRepo:  pr,
+		cohortRepo:  cr,
+		sectionRepo: sr,
+		passwords:   passwords,
+		log:         log,
+	}
+}
+
+// Import validates the roster against the organization's active academic
+// period and, unless it is a dry run or any row is invalid, creates every
+// student in one transaction.
+func (s *rosterService) Import(ctx context.Context, orgID uuid.UUID, records [][]string, opts ImportOptions) (*domain.ImportReport, error) {
+	report := &domain.ImportReport{DryRun: opts.DryRun}
+
+	rows, err := domain.ParseRoster(records, report)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := s.periodRepo.GetActiveByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, ErrNoActivePeriod
+	}
+
+	cohorts, sections, err := s.loadClasses(ctx, period.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	studentRole, err := s.roleRepo.GetByName(ctx, "student")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student role: %w", err)
+	}
+	if studentRole == nil {
+		return nil, errors.New("role 'student' does not exist")
+	}
+
+	guardians := make(map[string]*userDomain.User)
+	var students []domain.StudentImport
+
+	for _, row := range rows {
+		errCount := len(report.Errors)
+
+		existing, err := s.userRepo.GetByEmail(database.Unscoped(ctx), row.Email)
+		if err != nil {
+			return nil, err
+		}
+		// Accounts are global, so say nothing about where the address is
+		// already in use.
+		if existing != nil {
+			report.AddError(row.Line, domain.ColumnEmail, "this email cannot be used")
+		}
+
+		cohort, ok := cohorts[strings.ToLower(row.Cohort)]
+		if !ok {
+			report.AddError(row.Line, domain.ColumnCohort, fmt.Sprintf("cohort %q does not exist in %s", row.Cohort, period.Name))
+		}
+
+		var sectionID *uuid.UUID
+		if ok && row.Section != "" {
+			section, found := sections[sectionKey(cohort.ID, row.Section)]
+			if !found {
+				report.AddError(row.Line, domain.ColumnSection, fmt.Sprintf("section %q does not exist in cohort %q", row.Section, row.Cohort))
+			} else {
+				sectionID = &section.ID
+			}
+		}
+
+		var guardianID *uuid.UUID
+		if row.GuardianEmail != "" {
+			guardian, err := s.guardian(ctx, guardians, orgID, row.GuardianEmail)
+			if err != nil {
+				return nil, err
+			}
+			if guardian == nil {
+				report.AddError(row.Line, domain.ColumnGuardianEmail, "no guardian account with this email exists in the organization")
+			} else {
+				guardianID = &guardian.ID
+			}
+		}
+
+		if len(report.Errors) > errCount {
+			continue
+		}
+
+		user := userDomain.NewUser(row.Email, row.FirstName, row.LastName, orgID, []userDomain.Role{*studentRole})
+		user.GuardianID = guardianID
+		// The school vouches for the addresses on its roster.
+		user.MarkEmailVerified()
+		students = append(students, domain.StudentImport{User: user, CohortID: cohort.ID, SectionID: sectionID})
+	}
+
+	report.Valid = len(students)
+	if opts.DryRun || report.HasErrors() {
+		return report, nil
+	}
+
+	// Imported students choose their password through a reset. Until then
+	// they share the hash of one discarded random secret, which keeps large
+	// imports from spending minutes in bcrypt.
+	if len(students) > 0 {
+		if err := students[0].User.SetRandomPassword(); err != nil {
+			return nil, fmt.Errorf("failed to set password: %w", err)
+		}
+		for _, student := range students[1:] {
+			student.User.PasswordHash = students[0].User.PasswordHash
+		}
+	}
+
+	if err := s.rosterRepo.ImportStudents(ctx, students); err != nil {
+		return nil, err
+	}
+	report.Imported = len(students)
+
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "imported": report.Imported}).Info("student roster imported")
+
+	if opts.Invite {
+		for _, student := range students {
+			if err := s.passwords.ForgotPassword(ctx, student.User.Email); err != nil {
+				s.log.WithError(err).WithField("user_id", student.User.ID).Warn("failed to send roster invitation")
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (s *rosterService) loadClasses(ctx context.Context, periodID uuid.UUID) (map[string]cohortDomain.Cohort, map[string]sectionDomain.Section, error) {
+	cohortList, err := s.cohortRepo.ListByAcademicPeriod(ctx, periodID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cohorts := make(map[string]cohortDomain.Cohort, len(cohortList))
+	cohortIDs := make([]uuid.UUID, 0, len(cohortList))
+	for _, c := range cohortList {
+		cohorts[strings.ToLower(c.Name)] = c
+		cohortIDs = append(cohortIDs, c.ID)
+	}
+
+	sections := make(map[string]sectionDomain.Section)
+	if len(cohortIDs) == 0 {
+		return cohorts, sections, nil
+	}
+
+	sectionList, err := s.sectionRepo.ListByCohortIDs(ctx, cohortIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, sec := range sectionList {
+		sections[sectionKey(sec.CohortID, sec.Name)] = sec
+	}
+
+	return cohorts, sections, nil
+}
+
+// guardian looks up a guardian account once per email. It returns nil when
+// the email does not belong to a guardian of the organization.
+func (s *rosterService) guardian(ctx context.Context, cache map[string]*userDomain.User, orgID uuid.UUID, email string) (*userDomain.User, error) {
+	if g, ok := cache[email]; ok {
+		return g, nil
+	}
+
+	g, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if g != nil && (g.OrganizationID != orgID || !g.HasAnyRole(userDomain.GuardianRole)) {
+		g = nil
+	}
+
+	cache[email] = g
+	return g, nil
+}
+
+func sectionKey(cohortID uuid.UUID, name string) string {
+	return cohortID.String() + "/" + strings.ToLower(name)
+}
@@ -10,4 +10,5 @@ type SectionRepository interface {
 	Create(ctx context.Context, section *Section) error
 	GetSectionIDsByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Section, error)
+	ListByCohortIDs(ctx context.Context, cohortIDs []uuid.UUID) ([]Section, error)
 }
@@ -2,17 +2,17 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/section/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 )
 
 type SectionMemberRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewSectionMemberRepository(db *sql.DB) domain.SectionMemberRepository {
+func NewSectionMemberRepository(db database.DB) domain.SectionMemberRepository {
 	return &SectionMemberRepoPostgres{db: db}
 }
 
@@ -6,16 +6,18 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/section/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 type SectionRepositoryPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewSectionRepository(db *sql.DB, log *logrus.Logger) domain.SectionRepository {
+func NewSectionRepository(db database.DB, log *logrus.Logger) domain.SectionRepository {
 	return &SectionRepositoryPostgres{
 		db:  db,
 		log: log,
@@ -105,3 +107,32 @@ func (r *SectionRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (
 
 	return section, nil
 }
+
+func (r *SectionRepositoryPostgres) ListByCohortIDs(ctx context.Context, cohortIDs []uuid.UUID) ([]domain.Section, error) {
+	query := `
+			SELECT id, cohort_id, name, COALESCE(capacity, 0) FROM sections
+			WHERE cohort_id = ANY($1) AND deleted_at IS NULL
+			ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(cohortIDs))
+	if err != nil {
+		r.log.WithError(err).Error("failed to list sections by cohorts")
+		return nil, fmt.Errorf("failed to list sections: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []domain.Section
+	for rows.Next() {
+		var s domain.Section
+		if err := rows.Scan(&s.ID, &s.CohortID, &s.Name, &s.Capacity); err != nil {
+			return nil, fmt.Errorf("failed to scan section: %w", err)
+		}
+		sections = append(sections, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sections: %w", err)
+	}
+
+	return sections, nil
+}
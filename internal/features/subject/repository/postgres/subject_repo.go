@@ -6,14 +6,15 @@ import (
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/subject/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 )
 
 type SubjectRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewSubjectRepository(db *sql.DB) domain.SubjectRepository {
+func NewSubjectRepository(db database.DB) domain.SubjectRepository {
 	return &SubjectRepoPostgres{db: db}
 }
 
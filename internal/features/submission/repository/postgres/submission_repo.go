@@ -2,17 +2,17 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/submission/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 )
 
 type SubmissionRepoPostgres struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewSubmissionRepoPostgres(db *sql.DB) domain.SubmissionRepository {
+func NewSubmissionRepoPostgres(db database.DB) domain.SubmissionRepository {
 	return &SubmissionRepoPostgres{
 		db: db,
 	}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TenantResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name"`
+	Slug             string      `json:"slug"`
+	Type             string      `json:"type"`
+	IsActive         bool        `json:"is_active"`
+	SuspendedAt      *time.Time  `json:"suspended_at,omitempty"`
+	SuspensionReason string      `json:"suspension_reason,omitempty"`
+	Usage            TenantUsage `json:"usage"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
+
+type TenantUsage struct {
+	Members        int        `json:"members"`
+	ActiveMembers  int        `json:"active_members"`
+	Courses        int        `json:"courses"`
+	Cohorts        int        `json:"cohorts"`
+	ActiveSessions int        `json:"active_sessions"`
+	StorageBytes   int64      `json:"storage_bytes"`
+	LastLoginAt    *time.Time `json:"last_login_at,omitempty"`
+}
+
+type TenantListResponse struct {
+	Organizations []TenantResponse `json:"organizations"`
+	Total         int              `json:"total"`
+	Limit         int              `json:"limit"`
+	Offset        int              `json:"offset"`
+}
+
+type CreateOrganizationRequest struct {
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Address string `json:"address"`
+	Type    string `json:"type"`
+}
+
+type SuspendOrganizationRequest struct {
+	Reason string `json:"reason"`
+}
+
+type HealthCheck struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type PlatformStats struct {
+	Organizations          int   `json:"organizations"`
+	SuspendedOrganizations int   `json:"suspended_organizations"`
+	Users                  int   `json:"users"`
+	ActiveSessions         int   `json:"active_sessions"`
+	StorageBytes           int64 `json:"storage_bytes"`
+}
+
+type DatabasePool struct {
+	Open  int `json:"open"`
+	InUse int `json:"in_use"`
+	Idle  int `json:"idle"`
+}
+
+type HealthResponse struct {
+	Status        string                 `json:"status"`
+	Checks        map[string]HealthCheck `json:"checks"`
+	Stats         *PlatformStats         `json:"stats,omitempty"`
+	DatabasePool  DatabasePool           `json:"database_pool"`
+	UptimeSeconds int64                  `json:"uptime_seconds"`
+	GoVersion     string                 `json:"go_version"`
+}
+
+type OnboardingRequest struct {
+	Organization   OnboardingOrganization `json:"organization"`
+	Admin          OnboardingAdmin        `json:"admin"`
+	AcademicPeriod OnboardingPeriod       `json:"academic_period"`
+}
+
+type OnboardingOrganization struct {
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+type OnboardingAdmin struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	// Password is optional; without it the admin gets a password reset email.
+	Password string `json:"password,omitempty"`
+}
+
+type OnboardingPeriod struct {
+	Name      string `json:"name"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type OnboardingResponse struct {
+	Created         bool                      `json:"created"`
+	Organization    OnboardedOrganization     `json:"organization"`
+	Admin           *OnboardedAdmin           `json:"admin,omitempty"`
+	AcademicPeriod  *OnboardedPeriod          `json:"academic_period,omitempty"`
+	EducationLevels []OnboardedEducationLevel `json:"education_levels"`
+	Subjects        int                       `json:"subjects"`
+	Roles           []string                  `json:"roles"`
+}
+
+type OnboardedOrganization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Type      string    `json:"type"`
+	Address   string    `json:"address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OnboardedAdmin struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
+
+type OnboardedPeriod struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	Status    string    `json:"status"`
+}
+
+type OnboardedEducationLevel struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Code     string    `json:"code"`
+	Sequence int       `json:"sequence"`
+}
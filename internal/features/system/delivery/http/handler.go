@@ -0,0 +1,263 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	orgService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/service"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type SystemHandler struct {
+	admin      service.Administration
+	onboarding service.Onboarding
+	log        *logrus.Logger
+}
+
+func NewSystemHandler(admin service.Administration, onboarding service.Onboarding, log *logrus.Logger) *SystemHandler {
+	return &SystemHandler{
+		admin:      admin,
+		onboarding: onboarding,
+		log:        log,
+	}
+}
+
+// ProtectedRoutes must be mounted behind middleware.RequireSystemAdmin.
+func (h *SystemHandler) ProtectedRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/health", h.Health)
+
+	r.Get("/organizations", h.ListOrganizations)
+	r.Post("/organizations", h.CreateOrganization)
+	r.Get("/organizations/{id}", h.GetOrganization)
+	r.Post("/organizations/{id}/suspend", h.SuspendOrganization)
+	r.Post("/organizations/{id}/reactivate", h.ReactivateOrganization)
+	r.Post("/organizations/{id}/users/{userID}/reset-password", h.ResetAdminPassword)
+
+	r.Post("/onboarding", h.Onboard)
+
+	return r
+}
+
+func (h *SystemHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.TenantFilter{
+		Search: q.Get("search"),
+		Status: q.Get("status"),
+	}
+	if filter.Status != "" && filter.Status != domain.TenantStatusActive && filter.Status != domain.TenantStatusSuspended {
+		u.BadRequest(w, "status must be 'active' or 'suspended'")
+		return
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 {
+		filter.Limit = limit
+	}
+	if offset, err := strconv.Atoi(q.Get("offset")); err == nil && offset > 0 {
+		filter.Offset = offset
+	}
+
+	tenants, total, err := h.admin.ListTenants(r.Context(), &filter)
+	if err != nil {
+		h.log.WithError(err).Error("failed to list organizations")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := dto.TenantListResponse{
+		Organizations: make([]dto.TenantResponse, 0, len(tenants)),
+		Total:         total,
+		Limit:         filter.Limit,
+		Offset:        filter.Offset,
+	}
+	for i := range tenants {
+		res.Organizations = append(res.Organizations, toTenantResponse(&tenants[i]))
+	}
+
+	u.OK(w, res)
+}
+
+func (h *SystemHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	tenant, err := h.admin.GetTenant(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err, "failed to get organization")
+		return
+	}
+
+	u.OK(w, toTenantResponse(tenant))
+}
+
+func (h *SystemHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	req := dto.CreateOrganizationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	tenant, err := h.admin.CreateOrganization(r.Context(), service.OrganizationInput{
+		Name:    req.Name,
+		Slug:    req.Slug,
+		Address: req.Address,
+		Type:    o.OrgType(req.Type),
+	})
+	if err != nil {
+		h.writeError(w, err, "failed to create organization")
+		return
+	}
+
+	u.Created(w, toTenantResponse(tenant))
+}
+
+func (h *SystemHandler) SuspendOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := dto.SuspendOrganizationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	tenant, err := h.admin.SuspendOrganization(r.Context(), orgID, req.Reason)
+	if err != nil {
+		h.writeError(w, err, "failed to suspend organization")
+		return
+	}
+
+	u.OK(w, toTenantResponse(tenant))
+}
+
+func (h *SystemHandler) ReactivateOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	tenant, err := h.admin.ReactivateOrganization(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err, "failed to reactivate organization")
+		return
+	}
+
+	u.OK(w, toTenantResponse(tenant))
+}
+
+func (h *SystemHandler) ResetAdminPassword(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := uuidParam(w, r, "id")
+	if !ok {
+		return
+	}
+	userID, ok := uuidParam(w, r, "userID")
+	if !ok {
+		return
+	}
+
+	if err := h.admin.ResetAdminPassword(r.Context(), orgID, userID); err != nil {
+		h.writeError(w, err, "failed to reset tenant admin password")
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Password reset link sent",
+	})
+}
+
+func (h *SystemHandler) Health(w http.ResponseWriter, r *http.Request) {
+	health := h.admin.Health(r.Context())
+
+	res := dto.HealthResponse{
+		Status: health.Status,
+		Checks: make(map[string]dto.HealthCheck, len(health.Checks)),
+		DatabasePool: dto.DatabasePool{
+			Open:  health.OpenConnections,
+			InUse: health.InUseConnections,
+			Idle:  health.IdleConnections,
+		},
+		UptimeSeconds: int64(health.Uptime.Seconds()),
+		GoVersion:     health.GoVersion,
+	}
+	for name, c := range health.Checks {
+		res.Checks[name] = dto.HealthCheck{
+			Status:    c.Status,
+			LatencyMS: float64(c.Latency.Microseconds()) / 1000,
+			Error:     c.Error,
+		}
+	}
+	if health.Stats != nil {
+		res.Stats = &dto.PlatformStats{
+			Organizations:          health.Stats.Organizations,
+			SuspendedOrganizations: health.Stats.SuspendedOrganizations,
+			Users:                  health.Stats.Users,
+			ActiveSessions:         health.Stats.ActiveSessions,
+			StorageBytes:           health.Stats.StorageBytes,
+		}
+	}
+
+	// A degraded platform is still a successful report; the status field
+	// says what is wrong.
+	u.OK(w, res)
+}
+
+func (h *SystemHandler) writeError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrTenantNotFound), errors.Is(err, service.ErrTenantUserNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrNotTenantAdmin):
+		u.UnprocessableEntity(w, err.Error())
+	case errors.Is(err, orgService.ErrSlugTaken), errors.Is(err, domain.ErrAdminEmailTaken), errors.Is(err, domain.ErrSlugConflict):
+		u.Error(w, http.StatusConflict, "CONFLICT", err.Error())
+	case errors.Is(err, service.ErrInvalidOnboarding),
+		errors.Is(err, o.ErrInvalidOrganization), errors.Is(err, o.ErrInvalidAcademicPeriod):
+		u.BadRequest(w, err.Error())
+	default:
+		h.log.WithError(err).Error(msg)
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func uuidParam(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, name))
+	if err != nil {
+		u.BadRequest(w, "Invalid "+name)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toTenantResponse(t *domain.TenantUsage) dto.TenantResponse {
+	return dto.TenantResponse{
+		ID:               t.Organization.ID,
+		Name:             t.Organization.Name,
+		Slug:             t.Organization.Slug,
+		Type:             string(t.Organization.Type),
+		IsActive:         !t.Organization.IsSuspended(),
+		SuspendedAt:      t.Organization.SuspendedAt,
+		SuspensionReason: t.Organization.SuspensionReason,
+		Usage: dto.TenantUsage{
+			Members:        t.Members,
+			ActiveMembers:  t.ActiveMembers,
+			Courses:        t.Courses,
+			Cohorts:        t.Cohorts,
+			ActiveSessions: t.ActiveSessions,
+			StorageBytes:   t.StorageBytes,
+			LastLoginAt:    t.LastLoginAt,
+		},
+		CreatedAt: t.Organization.CreatedAt,
+	}
+}
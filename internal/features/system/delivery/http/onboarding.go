@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/service"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+)
+
+const dateLayout = "2006-01-02"
+
+// Onboard answers 201 when it set the organization up and 200 when the slug
+// was already onboarded, so a retried request is safe.
+func (h *SystemHandler) Onboard(w http.ResponseWriter, r *http.Request) {
+	req := dto.OnboardingRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+	startDate, err := time.Parse(dateLayout, req.AcademicPeriod.StartDate)
+	if err != nil {
+		u.BadRequest(w, "Invalid start_date format (YYYY-MM-DD required)")
+		return
+	}
+	endDate, err := time.Parse(dateLayout, req.AcademicPeriod.EndDate)
+	if err != nil {
+		u.BadRequest(w, "Invalid end_date format (YYYY-MM-DD required)")
+		return
+	}
+
+	summary, err := h.onboarding.Onboard(r.Context(), service.OnboardingInput{
+		Name:           req.Organization.Name,
+		Slug:           req.Organization.Slug,
+		Type:           o.OrgType(req.Organization.Type),
+		Address:        req.Organization.Address,
+		AdminEmail:     req.Admin.Email,
+		AdminFirstName: req.Admin.FirstName,
+		AdminLastName:  req.Admin.LastName,
+		AdminPassword:  req.Admin.Password,
+		PeriodName:     req.AcademicPeriod.Name,
+		PeriodStart:    startDate,
+		PeriodEnd:      endDate,
+	})
+	if err != nil {
+		h.writeError(w, err, "failed to onboard organization")
+		return
+	}
+
+	res := ToOnboardingResponse(summary)
+	if summary.Created {
+		u.Created(w, res)
+		return
+	}
+	u.OK(w, res)
+}
+
+// ToOnboardingResponse is shared with the onboard command.
+func ToOnboardingResponse(s *domain.OnboardingSummary) dto.OnboardingResponse {
+	res := dto.OnboardingResponse{
+		Created: s.Created,
+		Organization: dto.OnboardedOrganization{
+			ID:        s.Organization.ID,
+			Name:      s.Organization.Name,
+			Slug:      s.Organization.Slug,
+			Type:      string(s.Organization.Type),
+			Address:   s.Organization.Address,
+			CreatedAt: s.Organization.CreatedAt,
+		},
+		EducationLevels: make([]dto.OnboardedEducationLevel, 0, len(s.Levels)),
+		Subjects:        s.Subjects,
+		Roles:           s.Roles,
+	}
+	if res.Roles == nil {
+		res.Roles = []string{}
+	}
+	if s.Admin != nil {
+		res.Admin = &dto.OnboardedAdmin{
+			ID:        s.Admin.ID,
+			Email:     s.Admin.Email,
+			FirstName: s.Admin.FirstName,
+			LastName:  s.Admin.LastName,
+		}
+	}
+	if s.Period != nil {
+		res.AcademicPeriod = &dto.OnboardedPeriod{
+			ID:        s.Period.ID,
+			Name:      s.Period.Name,
+			StartDate: s.Period.StartDate.Format(dateLayout),
+			EndDate:   s.Period.EndDate.Format(dateLayout),
+			Status:    string(s.Period.Status),
+		}
+	}
+	for _, l := range s.Levels {
+		res.EducationLevels = append(res.EducationLevels, dto.OnboardedEducationLevel{
+			ID:       l.ID,
+			Name:     l.Name,
+			Code:     l.Code,
+			Sequence: l.Sequence,
+		})
+	}
+	return res
+}
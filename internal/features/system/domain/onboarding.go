@@ -0,0 +1,293 @@
+package domain
+
+import (
+	"fmt"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/google/uuid"
+)
+
+// OnboardingTemplate is the structure a new organization of one type starts
+// with. Levels are listed in promotion order.
+type OnboardingTemplate struct {
+	Type     o.OrgType
+	Levels   []TemplateLevel
+	Subjects []TemplateSubject
+	// Roles are created for the organization next to the built-in admin,
+	// teacher, student and guardian roles, so their names must differ.
+	Roles []TemplateRole
+}
+
+type TemplateLevel struct {
+	Name string
+	Code string
+}
+
+// TemplateSubject is created once for each level that teaches it. Levels
+// holds level codes; an empty list means every level.
+type TemplateSubject struct {
+	Name   string
+	Code   string
+	Levels []string
+}
+
+type TemplateRole struct {
+	Name        string
+	Description string
+	Permissions map[string][]string
+}
+
+var schoolRoles = []TemplateRole{
+	{
+		Name:        "homeroom_teacher",
+		Description: "Teacher responsible for a class and its students",
+		Permissions: map[string][]string{
+			"course":     {"create", "read", "update"},
+			"content":    {"upload", "organize"},
+			"student":    {"grade", "view_progress"},
+			"assessment": {"create", "read", "update"},
+			"event":      {"create", "read", "update"},
+			"attachment": {"create", "read", "delete"},
+			"user":       {"read"},
+		},
+	},
+	{
+		Name:        "counselor",
+		Description: "Guidance counselor following students' progress",
+		Permissions: map[string][]string{
+			"student": {"view_progress"},
+			"user":    {"read"},
+			"event":   {"create", "read"},
+			"report":  {"read"},
+		},
+	},
+	{
+		Name:        "curriculum_coordinator",
+		Description: "Manages courses and content across the school",
+		Permissions: map[string][]string{
+			"course":     {"create", "read", "update", "delete", "archive"},
+			"content":    {"upload", "organize"},
+			"assessment": {"read"},
+			"report":     {"read", "export"},
+			"event":      {"read"},
+		},
+	},
+}
+
+var onboardingTemplates = map[o.OrgType]OnboardingTemplate{
+	o.GradeSchool: {
+		Type: o.GradeSchool,
+		Levels: []TemplateLevel{
+			{Name: "Grade 1", Code: "G1"}, {Name: "Grade 2", Code: "G2"}, {Name: "Grade 3", Code: "G3"},
+			{Name: "Grade 4", Code: "G4"}, {Name: "Grade 5", Code: "G5"}, {Name: "Grade 6", Code: "G6"},
+		},
+		Subjects: []TemplateSubject{
+			{Name: "Religious Education", Code: "AGM"},
+			{Name: "Pancasila Education", Code: "PPKN"},
+			{Name: "Indonesian", Code: "BIND"},
+			{Name: "Mathematics", Code: "MTK"},
+			{Name: "Science and Social Studies", Code: "IPAS", Levels: []string{"G3", "G4", "G5", "G6"}},
+			{Name: "Arts", Code: "SBDP"},
+			{Name: "Physical Education", Code: "PJOK"},
+			{Name: "English", Code: "BING", Levels: []string{"G4", "G5", "G6"}},
+		},
+		Roles: schoolRoles,
+	},
+	o.MiddleSchool: {
+		Type: o.MiddleSchool,
+		Levels: []TemplateLevel{
+			{Name: "Grade 7", Code: "G7"}, {Name: "Grade 8", Code: "G8"}, {Name: "Grade 9", Code: "G9"},
+		},
+		Subjects: []TemplateSubject{
+			{Name: "Religious Education", Code: "AGM"},
+			{Name: "Pancasila Education", Code: "PPKN"},
+			{Name: "Indonesian", Code: "BIND"},
+			{Name: "Mathematics", Code: "MTK"},
+			{Name: "Natural Science", Code: "IPA"},
+			{Name: "Social Studies", Code: "IPS"},
+			{Name: "English", Code: "BING"},
+			{Name: "Physical Education", Code: "PJOK"},
+			{Name: "Arts", Code: "SENI"},
+			{Name: "Informatics", Code: "INF"},
+		},
+		Roles: schoolRoles,
+	},
+	o.HighSchool: {
+		Type: o.HighSchool,
+		Levels: []TemplateLevel{
+			{Name: "Grade 10", Code: "G10"}, {Name: "Grade 11", Code: "G11"}, {Name: "Grade 12", Code: "G12"},
+		},
+		Subjects: []TemplateSubject{
+			{Name: "Religious Education", Code: "AGM"},
+			{Name: "Pancasila Education", Code: "PPKN"},
+			{Name: "Indonesian", Code: "BIND"},
+			{Name: "Mathematics", Code: "MTK"},
+			{Name: "Physics", Code: "FIS"},
+			{Name: "Chemistry", Code: "KIM"},
+			{Name: "Biology", Code: "BIO"},
+			{Name: "History", Code: "SEJ"},
+			{Name: "Geography", Code: "GEO"},
+			{Name: "Economics", Code: "EKO"},
+			{Name: "English", Code: "BING"},
+			{Name: "Physical Education", Code: "PJOK"},
+			{Name: "Informatics", Code: "INF", Levels: []string{"G10"}},
+		},
+		Roles: schoolRoles,
+	},
+	o.University: {
+		Type: o.University,
+		Levels: []TemplateLevel{
+			{Name: "Year 1", Code: "Y1"}, {Name: "Year 2", Code: "Y2"}, {Name: "Year 3", Code: "Y3"}, {Name: "Year 4", Code: "Y4"},
+		},
+		Subjects: []TemplateSubject{
+			{Name: "Religious Studies", Code: "MKU-AGM", Levels: []string{"Y1"}},
+			{Name: "Pancasila", Code: "MKU-PANC", Levels: []string{"Y1"}},
+			{Name: "Civic Education", Code: "MKU-KWN", Levels: []string{"Y1"}},
+			{Name: "Indonesian", Code: "MKU-BIND", Levels: []string{"Y1"}},
+			{Name: "Academic English", Code: "MKU-BING", Levels: []string{"Y1"}},
+			{Name: "Research Methods", Code: "METPEN", Levels: []string{"Y3"}},
+			{Name: "Community Service", Code: "KKN", Levels: []string{"Y3"}},
+			{Name: "Undergraduate Thesis", Code: "SKRIPSI", Levels: []string{"Y4"}},
+		},
+		Roles: []TemplateRole{
+			{
+				Name:        "lecturer",
+				Description: "Teaches courses and grades students",
+				Permissions: map[string][]string{
+					"course":     {"create", "read", "update"},
+					"content":    {"upload", "organize"},
+					"student":    {"grade", "view_progress"},
+					"assessment": {"create", "read", "update"},
+					"event":      {"create", "read", "update"},
+					"attachment": {"create", "read", "delete"},
+				},
+			},
+			{
+				Name:        "academic_advisor",
+				Description: "Follows the progress of assigned students",
+				Permissions: map[string][]string{
+					"student": {"view_progress"},
+					"user":    {"read"},
+					"course":  {"read"},
+					"event":   {"read"},
+				},
+			},
+			{
+				Name:        "registrar",
+				Description: "Manages student records and enrollment",
+				Permissions: map[string][]string{
+					"user":   {"create", "read", "update"},
+					"course": {"read", "enroll"},
+					"report": {"read", "export"},
+					"event":  {"read"},
+				},
+			},
+		},
+	},
+}
+
+// TemplateFor returns the template for an organization type.
+func TemplateFor(t o.OrgType) (OnboardingTemplate, bool) {
+	tmpl, ok := onboardingTemplates[t]
+	return tmpl, ok
+}
+
+// OnboardingPlan is everything created for a new organization. Level
+// sequences follow the template order, starting at 1.
+type OnboardingPlan struct {
+	Organization *o.Organization
+	Admin        *userDomain.User
+	Period       *o.AcademicPeriod
+	Levels       []PlannedLevel
+	Subjects     []PlannedSubject
+	Roles        []userDomain.Role
+}
+
+type PlannedLevel struct {
+	ID       uuid.UUID
+	Name     string
+	Code     string
+	Sequence int
+}
+
+type PlannedSubject struct {
+	ID               uuid.UUID
+	EducationLevelID uuid.UUID
+	Name             string
+	// Code carries the level so the same subject in two levels stays
+	// distinguishable, for example MTK-G10.
+	Code string
+}
+
+// PlanOnboarding lays out the template's levels, subjects and roles for
+// org and places admin and period in it. It assigns IDs but does not touch
+// storage; the period becomes the organization's active one.
+func PlanOnboarding(tmpl OnboardingTemplate, org *o.Organization, admin *userDomain.User, period *o.AcademicPeriod) *OnboardingPlan {
+	plan := &OnboardingPlan{
+		Organization: org,
+		Admin:        admin,
+		Period:       period,
+	}
+	org.PrepareCreate(nil)
+	admin.PrepareCreate(nil)
+	admin.OrganizationID = org.ID
+	admin.HomeOrganizationID = org.ID
+	period.PrepareCreate(nil)
+	period.Status = o.PeriodActive
+	period.IsActive = true
+
+	levelIDs := make(map[string]uuid.UUID, len(tmpl.Levels))
+	for i, l := range tmpl.Levels {
+		level := PlannedLevel{ID: uuid.New(), Name: l.Name, Code: l.Code, Sequence: i + 1}
+		levelIDs[l.Code] = level.ID
+		plan.Levels = append(plan.Levels, level)
+	}
+
+	for _, s := range tmpl.Subjects {
+		codes := s.Levels
+		if len(codes) == 0 {
+			for _, l := range tmpl.Levels {
+				codes = append(codes, l.Code)
+			}
+		}
+		for _, code := range codes {
+			levelID, ok := levelIDs[code]
+			if !ok {
+				continue
+			}
+			plan.Subjects = append(plan.Subjects, PlannedSubject{
+				ID:               uuid.New(),
+				EducationLevelID: levelID,
+				Name:             s.Name,
+				Code:             fmt.Sprintf("%s-%s", s.Code, code),
+			})
+		}
+	}
+
+	orgID := org.ID
+	for _, r := range tmpl.Roles {
+		plan.Roles = append(plan.Roles, userDomain.Role{
+			OrganizationID: &orgID,
+			Name:           r.Name,
+			Description:    r.Description,
+			Permissions:    r.Permissions,
+		})
+	}
+
+	return plan
+}
+
+// OnboardingSummary describes an onboarded organization as stored, so
+// repeating an onboarding request reports the same thing as the first one.
+type OnboardingSummary struct {
+	Organization o.Organization
+	// Created is false when the slug was already taken and nothing changed.
+	Created bool
+	// Admin is the earliest administrator of the organization, if any.
+	Admin    *userDomain.User
+	Period   *o.AcademicPeriod
+	Levels   []PlannedLevel
+	Subjects int
+	Roles    []string
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrAdminEmailTaken = errors.New("the administrator's email address is already in use")
+	// ErrAdminRoleMissing means the built-in roles were never seeded.
+	ErrAdminRoleMissing = errors.New("the built-in admin role does not exist")
+	// ErrSlugConflict means the slug belongs to an organization this plan
+	// did not create.
+	ErrSlugConflict = errors.New("the slug belongs to a different organization")
+)
+
+// OnboardingRepository stores a new organization in one transaction.
+type OnboardingRepository interface {
+	// Provision stores plan unless an organization already holds its slug.
+	// An organization of the plan's type administered by the plan's admin is
+	// taken to be an earlier run of the same plan and summarized; any other
+	// holder of the slug is ErrSlugConflict.
+	Provision(ctx context.Context, plan *OnboardingPlan) (*OnboardingSummary, error)
+}
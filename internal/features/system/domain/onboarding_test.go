@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/google/uuid"
+)
+
+func TestOnboardingTemplates(t *testing.T) {
+	builtIn := map[string]bool{"superadmin": true, "admin": true, "teacher": true, "student": true, "guardian": true}
+
+	for _, orgType := range []o.OrgType{o.University, o.HighSchool, o.MiddleSchool, o.GradeSchool} {
+		t.Run(string(orgType), func(t *testing.T) {
+			tmpl, ok := TemplateFor(orgType)
+			if !ok {
+				t.Fatalf("TemplateFor(%s) found no template", orgType)
+			}
+			if tmpl.Type != orgType || len(tmpl.Levels) == 0 || len(tmpl.Subjects) == 0 {
+				t.Fatalf("template = %+v, want levels and subjects for %s", tmpl, orgType)
+			}
+
+			levels := make(map[string]bool, len(tmpl.Levels))
+			for _, l := range tmpl.Levels {
+				levels[l.Code] = true
+			}
+			for _, s := range tmpl.Subjects {
+				for _, code := range s.Levels {
+					if !levels[code] {
+						t.Errorf("subject %s names unknown level %s", s.Code, code)
+					}
+				}
+			}
+			for _, r := range tmpl.Roles {
+				if builtIn[r.Name] {
+					t.Errorf("role %s clashes with a built-in role", r.Name)
+				}
+				if err := userDomain.ValidatePermissions(r.Permissions); err != nil {
+					t.Errorf("role %s: %v", r.Name, err)
+				}
+			}
+		})
+	}
+
+	if _, ok := TemplateFor(o.OrgType("kindergarten")); ok {
+		t.Error("TemplateFor(kindergarten) found a template")
+	}
+}
+
+func TestPlanOnboarding(t *testing.T) {
+	tmpl := OnboardingTemplate{
+		Type:   o.HighSchool,
+		Levels: []TemplateLevel{{Name: "Grade 10", Code: "G10"}, {Name: "Grade 11", Code: "G11"}},
+		Subjects: []TemplateSubject{
+			{Name: "Mathematics", Code: "MTK"},
+			{Name: "Informatics", Code: "INF", Levels: []string{"G11"}},
+		},
+		Roles: []TemplateRole{{Name: "counselor", Permissions: map[string][]string{"user": {"read"}}}},
+	}
+	org := o.NewOrganization("SMA Nusantara", "sma-nusantara", o.HighSchool, "", nil)
+	admin := userDomain.NewUser("admin@nusantara.sch.id", "Siti", "", uuid.Nil, nil)
+	start := time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC)
+	period := &o.AcademicPeriod{Name: "2025/2026 Ganjil", StartDate: start, EndDate: start.AddDate(0, 5, 0), Status: o.PeriodPlanned}
+
+	plan := PlanOnboarding(tmpl, org, admin, period)
+
+	if org.ID == uuid.Nil || admin.OrganizationID != org.ID || admin.HomeOrganizationID != org.ID {
+		t.Fatalf("admin organization = %s/%s, want %s", admin.OrganizationID, admin.HomeOrganizationID, org.ID)
+	}
+	if period.ID == uuid.Nil || period.Status != o.PeriodActive || !period.IsActive {
+		t.Errorf("period = %+v, want an active period with an ID", period)
+	}
+
+	if len(plan.Levels) != 2 || plan.Levels[0].Sequence != 1 || plan.Levels[1].Sequence != 2 {
+		t.Fatalf("levels = %+v, want sequences 1 and 2", plan.Levels)
+	}
+
+	codes := make([]string, 0, len(plan.Subjects))
+	for _, s := range plan.Subjects {
+		codes = append(codes, s.Code)
+		if s.Code == "INF-G11" && s.EducationLevelID != plan.Levels[1].ID {
+			t.Errorf("INF-G11 is in level %s, want %s", s.EducationLevelID, plan.Levels[1].ID)
+		}
+	}
+	if got := strings.Join(codes, ","); got != "MTK-G10,MTK-G11,INF-G11" {
+		t.Errorf("subject codes = %s, want MTK-G10,MTK-G11,INF-G11", got)
+	}
+
+	if len(plan.Roles) != 1 || plan.Roles[0].OrganizationID == nil || *plan.Roles[0].OrganizationID != org.ID {
+		t.Errorf("roles = %+v, want one role of the organization", plan.Roles)
+	}
+}
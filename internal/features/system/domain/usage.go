@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+)
+
+const (
+	TenantStatusActive    = "active"
+	TenantStatusSuspended = "suspended"
+)
+
+// TenantUsage is an organization together with how much of the platform it
+// uses. Service accounts are not counted as members.
+type TenantUsage struct {
+	Organization   o.Organization
+	Members        int
+	ActiveMembers  int
+	Courses        int
+	Cohorts        int
+	ActiveSessions int
+	StorageBytes   int64
+	LastLoginAt    *time.Time
+}
+
+// TenantFilter narrows the tenant list. Search matches name or slug; Status
+// is TenantStatusActive, TenantStatusSuspended or empty.
+type TenantFilter struct {
+	Search string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// PlatformStats are totals across every tenant.
+type PlatformStats struct {
+	Organizations          int
+	SuspendedOrganizations int
+	Users                  int
+	ActiveSessions         int
+	StorageBytes           int64
+}
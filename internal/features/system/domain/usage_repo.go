@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UsageRepository reads across tenants. It never returns the system
+// organization itself.
+type UsageRepository interface {
+	ListTenants(ctx context.Context, filter TenantFilter) ([]TenantUsage, int, error)
+	GetTenant(ctx context.Context, orgID uuid.UUID) (*TenantUsage, error)
+	PlatformStats(ctx context.Context) (*PlatformStats, error)
+}
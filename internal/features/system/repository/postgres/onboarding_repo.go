@@ -0,0 +1,287 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type OnboardingRepoPostgres struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+// NewOnboardingRepository writes for an organization that does not exist
+// yet, so it uses the pool directly rather than a tenant-scoped handle.
+func NewOnboardingRepository(db *sql.DB, log *logrus.Logger) domain.OnboardingRepository {
+	return &OnboardingRepoPostgres{db: db, log: log}
+}
+
+func (r *OnboardingRepoPostgres) Provision(ctx context.Context, plan *domain.OnboardingPlan) (*domain.OnboardingSummary, error) {
+	org := plan.Organization
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Concurrent requests for one slug queue here, so only the first
+	// provisions and the rest see its organization.
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('onboarding:' || $1))`, org.Slug); err != nil {
+		return nil, fmt.Errorf("failed to lock slug: %w", err)
+	}
+
+	// The system organization and deleted organizations never match a
+	// plan, even though they keep their slugs.
+	var existingID uuid.UUID
+	var reserved bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, COALESCE(is_system_org, false) OR deleted_at IS NOT NULL
+		FROM organizations WHERE slug = $1`, org.Slug).Scan(&existingID, &reserved)
+	if err == nil {
+		tx.Rollback()
+		if reserved {
+			return nil, domain.ErrSlugConflict
+		}
+		return r.retry(ctx, existingID, plan)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to look up slug: %w", err)
+	}
+
+	var emailTaken bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE email = $1)`, plan.Admin.Email).Scan(&emailTaken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check admin email: %w", err)
+	}
+	if emailTaken {
+		return nil, domain.ErrAdminEmailTaken
+	}
+
+	var adminRoleID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM roles
+		WHERE organization_id IS NULL AND lower(name) = 'admin' AND deleted_at IS NULL`).Scan(&adminRoleID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAdminRoleMissing
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin role: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organizations (id, name, slug, type, address, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)`,
+		org.ID, org.Name, org.Slug, org.Type, org.Address, org.CreatedAt, org.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	admin := plan.Admin
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users (id, organization_id, email, password_hash, first_name, last_name, email_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		admin.ID, org.ID, admin.Email, admin.PasswordHash, admin.FirstName, admin.LastName,
+		admin.EmailVerifiedAt, admin.CreatedAt, admin.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create admin user: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO organization_memberships (user_id, organization_id, created_at) VALUES ($1, $2, $3)`,
+		admin.ID, org.ID, admin.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add admin membership: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO user_roles (user_id, organization_id, role_id) VALUES ($1, $2, $3)`,
+		admin.ID, org.ID, adminRoleID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign admin role: %w", err)
+	}
+
+	for i := range plan.Roles {
+		role := &plan.Roles[i]
+		role.PrepareCreate(&admin.ID)
+		permissions, err := json.Marshal(role.Permissions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal permissions: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO roles (id, organization_id, name, description, permissions, created_at, updated_at, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			role.ID, org.ID, role.Name, role.Description, permissions, role.CreatedAt, role.UpdatedAt, role.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create role %s: %w", role.Name, err)
+		}
+	}
+
+	insertLevel, err := tx.PrepareContext(ctx, `
+		INSERT INTO education_levels (id, organization_id, name, code, sequence, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now(), now())`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare education level insert: %w", err)
+	}
+	for _, l := range plan.Levels {
+		if _, err := insertLevel.ExecContext(ctx, l.ID, org.ID, l.Name, l.Code, l.Sequence); err != nil {
+			return nil, fmt.Errorf("failed to create education level %s: %w", l.Name, err)
+		}
+	}
+
+	insertSubject, err := tx.PrepareContext(ctx, `
+		INSERT INTO subjects (id, organization_id, education_level_id, name, code, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now(), now())`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare subject insert: %w", err)
+	}
+	for _, s := range plan.Subjects {
+		if _, err := insertSubject.ExecContext(ctx, s.ID, org.ID, s.EducationLevelID, s.Name, s.Code); err != nil {
+			return nil, fmt.Errorf("failed to create subject %s: %w", s.Code, err)
+		}
+	}
+
+	period := plan.Period
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO academic_periods (id, organization_id, name, start_date, end_date, status, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		period.ID, org.ID, period.Name, period.StartDate, period.EndDate,
+		period.Status, period.IsActive, period.CreatedAt, period.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create academic period: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit onboarding: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{
+		"org_id":   org.ID,
+		"slug":     org.Slug,
+		"levels":   len(plan.Levels),
+		"subjects": len(plan.Subjects),
+		"roles":    len(plan.Roles),
+	}).Info("organization onboarded")
+	return r.summary(ctx, org.ID, true)
+}
+
+// retry summarizes the organization holding the plan's slug if it is what
+// the plan would have created.
+func (r *OnboardingRepoPostgres) retry(ctx context.Context, orgID uuid.UUID, plan *domain.OnboardingPlan) (*domain.OnboardingSummary, error) {
+	s, err := r.summary(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	if s.Organization.Type != plan.Organization.Type || s.Admin == nil || s.Admin.Email != plan.Admin.Email {
+		r.log.WithFields(logrus.Fields{"org_id": orgID, "slug": plan.Organization.Slug}).Warn("onboarding refused: slug held by a different organization")
+		return nil, domain.ErrSlugConflict
+	}
+	return s, nil
+}
+
+func (r *OnboardingRepoPostgres) summary(ctx context.Context, orgID uuid.UUID, created bool) (*domain.OnboardingSummary, error) {
+	s := &domain.OnboardingSummary{Created: created}
+
+	var orgType, address sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, name, slug, type, address, created_at, updated_at
+		FROM organizations WHERE id = $1`, orgID).Scan(
+		&s.Organization.ID, &s.Organization.Name, &s.Organization.Slug, &orgType, &address,
+		&s.Organization.CreatedAt, &s.Organization.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	s.Organization.Type = o.OrgType(orgType.String)
+	s.Organization.Address = address.String
+
+	admin := &userDomain.User{}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT u.id, u.email, u.first_name, u.last_name
+		FROM users u
+		JOIN user_roles ur ON ur.user_id = u.id AND ur.organization_id = $1
+		JOIN roles ro ON ro.id = ur.role_id AND ro.organization_id IS NULL AND lower(ro.name) = 'admin'
+		WHERE u.organization_id = $1 AND u.deleted_at IS NULL
+		ORDER BY u.created_at
+		LIMIT 1`, orgID).Scan(&admin.ID, &admin.Email, &admin.FirstName, &admin.LastName)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get organization admin: %w", err)
+	}
+	if err == nil {
+		s.Admin = admin
+	}
+
+	period := &o.AcademicPeriod{}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT id, name, start_date, end_date, status
+		FROM academic_periods
+		WHERE organization_id = $1 AND status = 'active' AND deleted_at IS NULL`, orgID).Scan(
+		&period.ID, &period.Name, &period.StartDate, &period.EndDate, &period.Status,
+	)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get active academic period: %w", err)
+	}
+	if err == nil {
+		period.IsActive = true
+		s.Period = period
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, COALESCE(code, ''), COALESCE(sequence, 0)
+		FROM education_levels
+		WHERE organization_id = $1 AND deleted_at IS NULL
+		ORDER BY sequence NULLS LAST, name`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list education levels: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l domain.PlannedLevel
+		if err := rows.Scan(&l.ID, &l.Name, &l.Code, &l.Sequence); err != nil {
+			return nil, fmt.Errorf("failed to scan education level: %w", err)
+		}
+		s.Levels = append(s.Levels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating education levels: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM subjects WHERE organization_id = $1 AND deleted_at IS NULL`, orgID).Scan(&s.Subjects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count subjects: %w", err)
+	}
+
+	roleRows, err := r.db.QueryContext(ctx, `
+		SELECT name FROM roles
+		WHERE organization_id = $1 AND deleted_at IS NULL
+		ORDER BY name`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer roleRows.Close()
+	for roleRows.Next() {
+		var name string
+		if err := roleRows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		s.Roles = append(s.Roles, name)
+	}
+	if err := roleRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating roles: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type UsageRepoPostgres struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewUsageRepository(db *sql.DB, log *logrus.Logger) domain.UsageRepository {
+	return &UsageRepoPostgres{db: db, log: log}
+}
+
+// tenantColumns computes each tenant's usage with correlated subqueries, so
+// a page of tenants costs one query however the counts are spread.
+const tenantColumns = `
+        o.id, o.name, o.slug, o.type, o.created_at, o.updated_at, o.suspended_at, o.suspension_reason,
+        (SELECT COUNT(*) FROM organization_memberships m JOIN users u ON u.id = m.user_id
+         WHERE m.organization_id = o.id AND u.deleted_at IS NULL AND NOT u.is_service_account),
+        (SELECT COUNT(*) FROM organization_memberships m JOIN users u ON u.id = m.user_id
+         WHERE m.organization_id = o.id AND u.deleted_at IS NULL AND NOT u.is_service_account
+           AND u.deactivated_at IS NULL),
+        (SELECT COUNT(*) FROM courses c WHERE c.organization_id = o.id AND c.deleted_at IS NULL),
+        (SELECT COUNT(*) FROM cohorts c WHERE c.organization_id = o.id AND c.deleted_at IS NULL),
+        (SELECT COUNT(*) FROM user_sessions s
+         WHERE s.organization_id = o.id AND s.revoked_at IS NULL AND s.expires_at > now()),
+        (SELECT COALESCE(SUM(a.file_size), 0) FROM attachments a
+         WHERE a.organization_id = o.id AND a.deleted_at IS NULL),
+        (SELECT MAX(h.created_at) FROM login_history h
+         WHERE h.organization_id = o.id AND h.event = 'success')`
+
+const tenantWhere = ` WHERE o.deleted_at IS NULL AND NOT COALESCE(o.is_system_org, false)`
+
+func scanTenant(row interface{ Scan(...any) error }, t *domain.TenantUsage) error {
+	var orgType, suspensionReason sql.NullString
+	err := row.Scan(
+		&t.Organization.ID, &t.Organization.Name, &t.Organization.Slug, &orgType,
+		&t.Organization.CreatedAt, &t.Organization.UpdatedAt,
+		&t.Organization.SuspendedAt, &suspensionReason,
+		&t.Members, &t.ActiveMembers, &t.Courses, &t.Cohorts, &t.ActiveSessions,
+		&t.StorageBytes, &t.LastLoginAt,
+	)
+	if err != nil {
+		return err
+	}
+	t.Organization.Type = o.OrgType(orgType.String)
+	t.Organization.SuspensionReason = suspensionReason.String
+	t.Organization.IsActive = !t.Organization.IsSuspended()
+	return nil
+}
+
+func (r *UsageRepoPostgres) ListTenants(ctx context.Context, filter domain.TenantFilter) ([]domain.TenantUsage, int, error) {
+	where := tenantWhere
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		where += fmt.Sprintf(" AND (o.name ILIKE $%d OR o.slug ILIKE $%d)", len(args), len(args))
+	}
+
+	switch filter.Status {
+	case domain.TenantStatusActive:
+		where += " AND o.suspended_at IS NULL"
+	case domain.TenantStatusSuspended:
+		where += " AND o.suspended_at IS NOT NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM organizations o"+where, args...).Scan(&total); err != nil {
+		r.log.WithError(err).Error("failed to count tenants")
+		return nil, 0, fmt.Errorf("failed to count tenants: %w", err)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := "SELECT" + tenantColumns + "\n        FROM organizations o" + where +
+		fmt.Sprintf("\n        ORDER BY o.name, o.id LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.WithError(err).Error("failed to list tenants")
+		return nil, 0, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []domain.TenantUsage
+	for rows.Next() {
+		var t domain.TenantUsage
+		if err := scanTenant(rows, &t); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating tenants: %w", err)
+	}
+
+	return tenants, total, nil
+}
+
+func (r *UsageRepoPostgres) GetTenant(ctx context.Context, orgID uuid.UUID) (*domain.TenantUsage, error) {
+	query := "SELECT" + tenantColumns + "\n        FROM organizations o" + tenantWhere + " AND o.id = $1"
+
+	var t domain.TenantUsage
+	err := scanTenant(r.db.QueryRowContext(ctx, query, orgID), &t)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to get tenant")
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *UsageRepoPostgres) PlatformStats(ctx context.Context) (*domain.PlatformStats, error) {
+	query := `
+        SELECT
+            (SELECT COUNT(*) FROM organizations o` + tenantWhere + `),
+            (SELECT COUNT(*) FROM organizations o` + tenantWhere + ` AND o.suspended_at IS NOT NULL),
+            (SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND NOT is_service_account),
+            (SELECT COUNT(*) FROM user_sessions WHERE revoked_at IS NULL AND expires_at > now()),
+            (SELECT COALESCE(SUM(file_size), 0) FROM attachments WHERE deleted_at IS NULL)`
+
+	var s domain.PlatformStats
+	err := r.db.QueryRowContext(ctx, query).Scan(
+		&s.Organizations, &s.SuspendedOrganizations, &s.Users, &s.ActiveSessions, &s.StorageBytes,
+	)
+	if err != nil {
+		r.log.WithError(err).Error("failed to get platform stats")
+		return nil, fmt.Errorf("failed to get platform stats: %w", err)
+	}
+
+	return &s, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
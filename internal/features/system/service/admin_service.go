@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	orgService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	userService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultTenantPageSize = 20
+	maxTenantPageSize     = 100
+
+	healthCheckTimeout = 2 * time.Second
+
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+)
+
+var (
+	ErrTenantNotFound     = errors.New("organization not found")
+	ErrTenantUserNotFound = errors.New("user not found in this organization")
+	ErrNotTenantAdmin     = errors.New("user is not an administrator of this organization")
+)
+
+type adminService struct {
+	usageRepo domain.UsageRepository
+	orgRepo   o.OrganizationRepository
+	orgs      orgService.CRUD
+	userRepo  userDomain.UserRepository
+	passwords userService.PasswordRecovery
+	db        *sql.DB
+	redis     *redis.Client
+	startedAt time.Time
+	log       *logrus.Logger
+}
+
+func NewAdminService(
+	ur domain.UsageRepository,
+	or o.OrganizationRepository,
+	orgs orgService.CRUD,
+	usr userDomain.UserRepository,
+	pr userService.PasswordRecovery,
+	db *sql.DB,
+	redisClient *redis.Client,
+	log *logrus.Logger,
+) Administration {
+	return &adminService{
+		usageRepo: ur,
+		orgRepo:   or,
+		orgs:      orgs,
+		userRepo:  usr,
+		passwords: pr,
+		db:        db,
+		redis:     redisClient,
+		startedAt: time.Now(),
+		log:       log,
+	}
+}
+
+func (s *adminService) ListTenants(ctx context.Context, filter *domain.TenantFilter) ([]domain.TenantUsage, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTenantPageSize
+	}
+	if filter.Limit > maxTenantPageSize {
+		filter.Limit = maxTenantPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	return s.usageRepo.ListTenants(ctx, *filter)
+}
+
+func (s *adminService) GetTenant(ctx context.Context, orgID uuid.UUID) (*domain.TenantUsage, error) {
+	tenant, err := s.usageRepo.GetTenant(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound
+	}
+	return tenant, nil
+}
+
+// CreateOrganization registers the tenant through the organization
+// service, so slug and profile rules match POST /organizations.
+func (s *adminService) CreateOrganization(ctx context.Context, input OrganizationInput) (*domain.TenantUsage, error) {
+	org, err := s.orgs.RegisterOrganization(ctx, input.Name, input.Slug, input.Address, input.Type)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTenant(ctx, org.ID)
+}
+
+// SuspendOrganization stops the tenant's members and API keys from using
+// the platform without touching their data. Requests with tokens issued
+// earlier are refused by middleware.RequireActiveOrganization.
+func (s *adminService) SuspendOrganization(ctx context.Context, orgID uuid.UUID, reason string) (*domain.TenantUsage, error) {
+	tenant, err := s.GetTenant(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if tenant.Organization.IsSuspended() {
+		now = *tenant.Organization.SuspendedAt
+	}
+	if err := s.orgRepo.SetSuspended(ctx, orgID, &now, strings.TrimSpace(reason)); err != nil {
+		return nil, err
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "actor_id": actorID, "reason": reason}).Warn("organization suspended")
+	return s.GetTenant(ctx, orgID)
+}
+
+func (s *adminService) ReactivateOrganization(ctx context.Context, orgID uuid.UUID) (*domain.TenantUsage, error) {
+	if _, err := s.GetTenant(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.SetSuspended(ctx, orgID, nil, ""); err != nil {
+		return nil, err
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "actor_id": actorID}).Info("organization reactivated")
+	return s.GetTenant(ctx, orgID)
+}
+
+// ResetAdminPassword only serves administrators, who have nobody in their
+// own organization to turn to; everyone else asks their tenant's admins.
+func (s *adminService) ResetAdminPassword(ctx context.Context, orgID, userID uuid.UUID) error {
+	if _, err := s.GetTenant(ctx, orgID); err != nil {
+		return err
+	}
+
+	// The user belongs to the tenant, not to the administrator's organization.
+	ctx = database.Unscoped(ctx)
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || user.HomeOrganizationID != orgID || user.IsServiceAccount {
+		return ErrTenantUserNotFound
+	}
+	if !user.CanPerform("user", "update") {
+		return ErrNotTenantAdmin
+	}
+
+	if err := s.passwords.ForgotPassword(ctx, user.Email); err != nil {
+		return err
+	}
+
+	actorID, _ := auth.GetUserID(ctx)
+	s.log.WithFields(logrus.Fields{"org_id": orgID, "user_id": userID, "actor_id": actorID}).Warn("tenant admin password reset by system administrator")
+	return nil
+}
+
+func (s *adminService) Health(ctx context.Context) *Health {
+	h := &Health{
+		Status:    HealthOK,
+		Checks:    make(map[string]CheckResult),
+		Uptime:    time.Since(s.startedAt),
+		GoVersion: runtime.Version(),
+	}
+
+	h.Checks["database"] = check(ctx, s.db.PingContext)
+	h.Checks["redis"] = check(ctx, func(ctx context.Context) error {
+		return s.redis.Ping(ctx).Err()
+	})
+	for _, c := range h.Checks {
+		if c.Status != HealthOK {
+			h.Status = HealthDegraded
+		}
+	}
+
+	dbStats := s.db.Stats()
+	h.OpenConnections = dbStats.OpenConnections
+	h.InUseConnections = dbStats.InUse
+	h.IdleConnections = dbStats.Idle
+
+	if h.Checks["database"].Status == HealthOK {
+		stats, err := s.usageRepo.PlatformStats(ctx)
+		if err != nil {
+			s.log.WithError(err).Warn("failed to collect platform stats for health report")
+		}
+		h.Stats = stats
+	}
+
+	return h
+}
+
+func check(ctx context.Context, ping func(context.Context) error) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := ping(ctx)
+	res := CheckResult{Status: HealthOK, Latency: time.Since(start)}
+	if err != nil {
+		res.Status = HealthDegraded
+		res.Error = err.Error()
+	}
+	return res
+}
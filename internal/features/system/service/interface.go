@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	"github.com/google/uuid"
+)
+
+// OrganizationInput describes a new tenant.
+type OrganizationInput struct {
+	Name    string
+	Slug    string
+	Address string
+	Type    o.OrgType
+}
+
+// CheckResult is the outcome of probing one dependency.
+type CheckResult struct {
+	Status  string
+	Latency time.Duration
+	Error   string
+}
+
+// Health reports whether the platform's dependencies respond, with totals
+// across tenants when the database is reachable.
+type Health struct {
+	Status    string
+	Checks    map[string]CheckResult
+	Stats     *domain.PlatformStats
+	Uptime    time.Duration
+	GoVersion string
+	// Database connection pool counters from database/sql.
+	OpenConnections  int
+	InUseConnections int
+	IdleConnections  int
+}
+
+// Administration is the cross-tenant API of the system organization. Only
+// its superusers reach it; tenant endpoints never return other tenants'
+// data.
+type Administration interface {
+	// ListTenants fills in the page defaults on filter before querying, so
+	// callers can echo the effective paging back.
+	ListTenants(ctx context.Context, filter *domain.TenantFilter) ([]domain.TenantUsage, int, error)
+	GetTenant(ctx context.Context, orgID uuid.UUID) (*domain.TenantUsage, error)
+	CreateOrganization(ctx context.Context, input OrganizationInput) (*domain.TenantUsage, error)
+	SuspendOrganization(ctx context.Context, orgID uuid.UUID, reason string) (*domain.TenantUsage, error)
+	ReactivateOrganization(ctx context.Context, orgID uuid.UUID) (*domain.TenantUsage, error)
+	// ResetAdminPassword emails a password reset link to an administrator of
+	// the tenant who can no longer sign in.
+	ResetAdminPassword(ctx context.Context, orgID, userID uuid.UUID) error
+	Health(ctx context.Context) *Health
+}
+
+// OnboardingInput describes an organization to set up from its type's
+// template, with the first administrator and the academic period it starts
+// in.
+type OnboardingInput struct {
+	Name    string
+	Slug    string
+	Type    o.OrgType
+	Address string
+
+	AdminEmail     string
+	AdminFirstName string
+	AdminLastName  string
+	// AdminPassword may be empty, in which case the administrator is sent a
+	// password reset email instead.
+	AdminPassword string
+
+	PeriodName  string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+// Onboarding sets up a new organization in one step.
+type Onboarding interface {
+	// Onboard is idempotent by slug: when the same request already created
+	// the organization it changes nothing and reports the organization as
+	// stored, with Created false.
+	Onboard(ctx context.Context, input OnboardingInput) (*domain.OnboardingSummary, error)
+}
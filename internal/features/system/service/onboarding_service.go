@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/system/domain"
+	userDomain "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	userService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrInvalidOnboarding = errors.New("invalid onboarding request")
+
+type onboardingService struct {
+	repo      domain.OnboardingRepository
+	passwords userService.PasswordRecovery
+	log       *logrus.Logger
+}
+
+// NewOnboardingService creates the service. passwords may be nil when
+// callers always set the administrator's password, as the CLI does.
+func NewOnboardingService(repo domain.OnboardingRepository, pr userService.PasswordRecovery, log *logrus.Logger) Onboarding {
+	return &onboardingService{repo: repo, passwords: pr, log: log}
+}
+
+func (s *onboardingService) Onboard(ctx context.Context, input OnboardingInput) (*domain.OnboardingSummary, error) {
+	org := o.NewOrganization(input.Name, strings.ToLower(strings.TrimSpace(input.Slug)), input.Type, input.Address, nil)
+	if err := o.ValidateSlug(org.Slug); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOnboarding, err)
+	}
+	if err := org.ValidateProfile(); err != nil {
+		return nil, err
+	}
+	tmpl, ok := domain.TemplateFor(org.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: no template for organization type '%s'", ErrInvalidOnboarding, org.Type)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.AdminEmail))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, fmt.Errorf("%w: admin email is not a valid address", ErrInvalidOnboarding)
+	}
+	firstName := strings.TrimSpace(input.AdminFirstName)
+	if firstName == "" {
+		return nil, fmt.Errorf("%w: admin first name is required", ErrInvalidOnboarding)
+	}
+
+	admin := userDomain.NewUser(email, firstName, strings.TrimSpace(input.AdminLastName), org.ID, nil)
+	sendReset := input.AdminPassword == ""
+	if sendReset {
+		if s.passwords == nil {
+			return nil, fmt.Errorf("%w: admin password is required", ErrInvalidOnboarding)
+		}
+		if err := admin.SetRandomPassword(); err != nil {
+			return nil, fmt.Errorf("failed to set admin password: %w", err)
+		}
+	} else {
+		if err := userDomain.ValidatePassword(input.AdminPassword); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOnboarding, err)
+		}
+		if err := admin.SetPassword(input.AdminPassword); err != nil {
+			return nil, fmt.Errorf("failed to set admin password: %w", err)
+		}
+	}
+	// The administrator is invited by the platform, so there is no address
+	// left to confirm.
+	admin.MarkEmailVerified()
+
+	period := o.NewAcademicPeriod(input.PeriodName, input.PeriodStart, input.PeriodEnd)
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.repo.Provision(ctx, domain.PlanOnboarding(tmpl, org, admin, period))
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Created && sendReset {
+		if err := s.passwords.ForgotPassword(ctx, email); err != nil {
+			s.log.WithError(err).WithField("org_id", summary.Organization.ID).Warn("failed to send password reset to onboarded administrator")
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateGuardianRequest struct {
+	Email     string      `json:"email"`
+	Password  string      `json:"password"`
+	FirstName string      `json:"first_name"`
+	LastName  string      `json:"last_name"`
+	ChildIDs  []uuid.UUID `json:"child_ids"`
+}
+
+type LinkGuardianRequest struct {
+	GuardianID uuid.UUID `json:"guardian_id"`
+}
+
+type ChildResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
+
+type GuardianResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Email     string          `json:"email"`
+	FirstName string          `json:"first_name"`
+	LastName  string          `json:"last_name"`
+	Children  []ChildResponse `json:"children"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LockedUserResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Email       string    `json:"email"`
+	FirstName   string    `json:"first_name"`
+	LastName    string    `json:"last_name"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type LoginEventResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Event     string    `json:"event"`
+	Email     string    `json:"email"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MembershipResponse struct {
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	OrganizationSlug string    `json:"organization_slug"`
+	IsHome           bool      `json:"is_home"`
+	IsCurrent        bool      `json:"is_current"`
+	Roles            []string  `json:"roles"`
+	JoinedAt         time.Time `json:"joined_at"`
+}
+
+type SwitchOrganizationRequest struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+type InviteMemberRequest struct {
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+type InvitationResponse struct {
+	ID               uuid.UUID `json:"id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name,omitempty"`
+	Email            string    `json:"email"`
+	Roles            []string  `json:"roles"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoleRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions map[string][]string `json:"permissions"`
+}
+
+type RoleResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions map[string][]string `json:"permissions"`
+	BuiltIn     bool                `json:"built_in"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type PermissionResourceResponse struct {
+	Resource    string   `json:"resource"`
+	Description string   `json:"description"`
+	Actions     []string `json:"actions"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateServiceAccountRequest struct {
+	Name string `json:"name"`
+}
+
+type ServiceAccountResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ServiceAccountListResponse struct {
+	ServiceAccounts []ServiceAccountResponse `json:"service_accounts"`
+	Total           int                      `json:"total"`
+	Limit           int                      `json:"limit"`
+	Offset          int                      `json:"offset"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string              `json:"name"`
+	Scopes    map[string][]string `json:"scopes"`
+	ExpiresAt *time.Time          `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         uuid.UUID           `json:"id"`
+	Name       string              `json:"name"`
+	Prefix     string              `json:"prefix"`
+	Scopes     map[string][]string `json:"scopes"`
+	ExpiresAt  *time.Time          `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time          `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time          `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse is the only response that includes the key itself.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
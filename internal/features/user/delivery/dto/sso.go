@@ -0,0 +1,32 @@
+package dto
+
+type SSOAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type SSOCallbackRequest struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+}
+
+type IdentityProviderRequest struct {
+	Issuer         string   `json:"issuer"`
+	ClientID       string   `json:"client_id"`
+	ClientSecret   string   `json:"client_secret"`
+	DefaultRole    string   `json:"default_role"`
+	AutoProvision  bool     `json:"auto_provision"`
+	AllowedDomains []string `json:"allowed_domains"`
+	IsEnabled      bool     `json:"is_enabled"`
+}
+
+// IdentityProviderResponse never echoes the client secret, only whether one
+// is stored.
+type IdentityProviderResponse struct {
+	Issuer          string   `json:"issuer"`
+	ClientID        string   `json:"client_id"`
+	HasClientSecret bool     `json:"has_client_secret"`
+	DefaultRole     string   `json:"default_role"`
+	AutoProvision   bool     `json:"auto_provision"`
+	AllowedDomains  []string `json:"allowed_domains"`
+	IsEnabled       bool     `json:"is_enabled"`
+}
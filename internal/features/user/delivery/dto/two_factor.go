@@ -0,0 +1,34 @@
+package dto
+
+type LoginChallengeResponse struct {
+	MFARequired        bool   `json:"mfa_required"`
+	EnrollmentRequired bool   `json:"enrollment_required"`
+	ChallengeToken     string `json:"challenge_token"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorEnrollmentLoginResponse struct {
+	TokenResponse
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorPolicy struct {
+	RequiredRoles []string `json:"required_roles"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Email            string     `json:"email"`
+	FirstName        string     `json:"first_name"`
+	LastName         string     `json:"last_name"`
+	Roles            []string   `json:"roles"`
+	IsActive         bool       `json:"is_active"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	LockedUntil      *time.Time `json:"locked_until,omitempty"`
+	GuardianID       *uuid.UUID `json:"guardian_id,omitempty"`
+	// IsGuest marks members whose account is owned by another organization.
+	IsGuest   bool      `json:"is_guest"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserListResponse struct {
+	Users  []UserResponse `json:"users"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+type UpdateUserRequest struct {
+	Email     *string `json:"email"`
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func (h *UserHandler) CreateGuardian(w http.ResponseWriter, r *http.Request) {
+	req := dto.CreateGuardianRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+	if req.Email == "" || req.FirstName == "" {
+		u.BadRequest(w, "email and first_name are required")
+		return
+	}
+	if err := domain.ValidatePassword(req.Password); err != nil {
+		u.BadRequest(w, err.Error())
+		return
+	}
+
+	orgID, ok := auth.GetOrgID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "Organization not found in context")
+		return
+	}
+
+	guardian, err := h.authService.RegisterGuardian(r.Context(), req.Email, req.Password, req.FirstName, req.LastName, orgID)
+	if err != nil {
+		h.log.WithError(err).WithField("email", req.Email).Error("failed to create guardian")
+		u.UnprocessableEntity(w, err.Error())
+		return
+	}
+
+	for _, childID := range req.ChildIDs {
+		if err := h.guardianService.LinkChild(r.Context(), guardian.ID, childID); err != nil {
+			h.writeGuardianError(w, err, childID)
+			return
+		}
+	}
+
+	children, err := h.guardianService.ListChildren(r.Context(), guardian.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", guardian.ID).Error("failed to list children")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.Created(w, dto.GuardianResponse{
+		ID:        guardian.ID,
+		Email:     guardian.Email,
+		FirstName: guardian.FirstName,
+		LastName:  guardian.LastName,
+		Children:  toChildResponses(children),
+	})
+}
+
+func (h *UserHandler) LinkGuardian(w http.ResponseWriter, r *http.Request) {
+	childID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	req := dto.LinkGuardianRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GuardianID == uuid.Nil {
+		u.BadRequest(w, "guardian_id is required")
+		return
+	}
+
+	if err := h.guardianService.LinkChild(r.Context(), req.GuardianID, childID); err != nil {
+		h.writeGuardianError(w, err, childID)
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Guardian linked",
+	})
+}
+
+func (h *UserHandler) UnlinkGuardian(w http.ResponseWriter, r *http.Request) {
+	childID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	if err := h.guardianService.UnlinkChild(r.Context(), childID); err != nil {
+		h.writeGuardianError(w, err, childID)
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Guardian unlinked",
+	})
+}
+
+func (h *UserHandler) GetGuardianChildren(w http.ResponseWriter, r *http.Request) {
+	guardianID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	children, err := h.guardianService.ListChildren(r.Context(), guardianID)
+	if err != nil {
+		h.writeGuardianError(w, err, guardianID)
+		return
+	}
+
+	u.OK(w, toChildResponses(children))
+}
+
+func (h *UserHandler) writeGuardianError(w http.ResponseWriter, err error, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrChildNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrNotGuardian), errors.Is(err, service.ErrInvalidGuardian):
+		u.UnprocessableEntity(w, err.Error())
+	case errors.Is(err, service.ErrManagedElsewhere):
+		u.Forbidden(w, err.Error())
+	default:
+		h.log.WithError(err).WithField("user_id", userID).Error("guardian request failed")
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func toChildResponses(children []domain.User) []dto.ChildResponse {
+	res := make([]dto.ChildResponse, 0, len(children))
+	for _, child := range children {
+		res = append(res, dto.ChildResponse{
+			ID:        child.ID,
+			Email:     child.Email,
+			FirstName: child.FirstName,
+			LastName:  child.LastName,
+		})
+	}
+	return res
+}
@@ -2,11 +2,15 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
 	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -14,8 +18,18 @@ import (
 )
 
 type UserHandler struct {
-	authService service.Auth
-	log         *logrus.Logger
+	authService         service.Auth
+	passwordService     service.PasswordRecovery
+	verificationService service.EmailVerification
+	twoFactorService    service.TwoFactor
+	loginGuard          service.LoginProtection
+	sessionService      service.Sessions
+	ssoService          service.SingleSignOn
+	guardianService     service.Guardianship
+	userAdmin           service.UserManagement
+	impersonation       service.Impersonation
+	memberships         service.Memberships
+	log                 *logrus.Logger
 }
 
 func (h *UserHandler) PublicRoutes() chi.Router {
@@ -23,6 +37,15 @@ func (h *UserHandler) PublicRoutes() chi.Router {
 
 	r.Post("/register/student", h.Register)
 	r.Post("/login", h.Login)
+	r.Post("/refresh", h.Refresh)
+	r.Post("/password/forgot", h.ForgotPassword)
+	r.Post("/password/reset", h.ResetPassword)
+	r.Post("/verify-email", h.VerifyEmail)
+	r.Post("/2fa/verify", h.VerifyTwoFactorChallenge)
+	r.Post("/2fa/enroll", h.BeginChallengeEnrollment)
+	r.Post("/2fa/enroll/confirm", h.ConfirmChallengeEnrollment)
+	r.Get("/sso/{orgSlug}/authorize", h.BeginSSO)
+	r.Post("/sso/callback", h.CompleteSSO)
 
 	return r
 }
@@ -32,12 +55,97 @@ func (h *UserHandler) ProtectedRoutes() chi.Router {
 
 	r.Post("/logout", h.Logout)
 	r.Get("/me", h.Me)
+	r.Patch("/me", h.UpdateMe)
+	r.Get("/me/login-history", h.GetMyLoginHistory)
+	r.Get("/me/sessions", h.ListSessions)
+	r.Get("/me/organizations", h.ListMyOrganizations)
+	r.Get("/me/invitations", h.ListMyInvitations)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.DenyImpersonation)
+
+		r.Post("/me/password", h.ChangePassword)
+		r.Post("/me/2fa/enroll", h.BeginTwoFactorEnrollment)
+		r.Post("/me/2fa/confirm", h.ConfirmTwoFactorEnrollment)
+		r.Post("/me/2fa/disable", h.DisableTwoFactor)
+		r.Post("/me/2fa/recovery-codes", h.RegenerateRecoveryCodes)
+		r.Delete("/me/sessions", h.RevokeOtherSessions)
+		r.Delete("/me/sessions/{sessionID}", h.RevokeSession)
+		r.Post("/me/organizations/switch", h.SwitchOrganization)
+		r.Post("/me/invitations/{invitationID}/accept", h.AcceptInvitation)
+		r.Delete("/me/invitations/{invitationID}", h.DeclineInvitation)
+	})
+
+	r.With(middleware.RequirePermission("organization", "read")).Get("/2fa/policy", h.GetTwoFactorPolicy)
+	r.With(middleware.RequirePermission("organization", "update")).Put("/2fa/policy", h.UpdateTwoFactorPolicy)
+	r.With(middleware.RequirePermission("organization", "read")).Get("/sso/provider", h.GetIdentityProvider)
+	r.With(middleware.RequirePermission("organization", "update")).Put("/sso/provider", h.SaveIdentityProvider)
+
+	r.With(middleware.RequirePermission("user", "create")).Post("/guardians", h.CreateGuardian)
+	r.With(middleware.RequirePermission("user", "create")).Post("/members", h.InviteMember)
+	r.With(middleware.RequirePermission("user", "create")).Get("/members/invitations", h.ListInvitations)
+	r.With(middleware.RequirePermission("user", "create")).Delete("/members/invitations/{invitationID}", h.RevokeInvitation)
+	r.With(middleware.RequirePermission("user", "delete")).Delete("/{id}", h.DeleteUser)
+	r.With(middleware.RequirePermission("user", "delete")).Delete("/{id}/membership", h.RemoveMember)
+	r.With(middleware.RequirePermission("user", "impersonate")).Post("/{id}/impersonate", h.Impersonate)
+	r.With(middleware.RequirePermission("user", "impersonate")).Get("/impersonations", h.ListImpersonations)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RequirePermission("user", "update"))
+
+		r.Post("/{id}/verify", h.MarkVerified)
+		r.Post("/{id}/verification/resend", h.ResendVerification)
+		r.Delete("/{id}/lock", h.UnlockUser)
+		r.Put("/{id}/guardian", h.LinkGuardian)
+		r.Delete("/{id}/guardian", h.UnlinkGuardian)
+		r.Patch("/{id}", h.UpdateUser)
+		r.Post("/{id}/activate", h.ActivateUser)
+		r.Post("/{id}/deactivate", h.DeactivateUser)
+		r.Post("/{id}/roles", h.AssignRole)
+		r.Delete("/{id}/roles/{role}", h.RevokeRole)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RequirePermission("user", "read"))
+
+		r.Get("/", h.ListUsers)
+		r.Get("/locked", h.ListLockedUsers)
+		r.Get("/{id}", h.GetUser)
+		r.Get("/{id}/login-history", h.GetLoginHistory)
+		r.Get("/{id}/children", h.GetGuardianChildren)
+	})
 
 	return r
 }
 
-func NewUserHandler(authService service.Auth, log *logrus.Logger) *UserHandler {
-	return &UserHandler{authService: authService, log: log}
+func NewUserHandler(
+	authService service.Auth,
+	passwordService service.PasswordRecovery,
+	verificationService service.EmailVerification,
+	twoFactorService service.TwoFactor,
+	loginGuard service.LoginProtection,
+	sessionService service.Sessions,
+	ssoService service.SingleSignOn,
+	guardianService service.Guardianship,
+	userAdmin service.UserManagement,
+	impersonation service.Impersonation,
+	memberships service.Memberships,
+	log *logrus.Logger,
+) *UserHandler {
+	return &UserHandler{
+		authService:         authService,
+		passwordService:     passwordService,
+		verificationService: verificationService,
+		twoFactorService:    twoFactorService,
+		loginGuard:          loginGuard,
+		sessionService:      sessionService,
+		ssoService:          ssoService,
+		guardianService:     guardianService,
+		userAdmin:           userAdmin,
+		impersonation:       impersonation,
+		memberships:         memberships,
+		log:                 log,
+	}
 }
 
 func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -49,11 +157,21 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orgID, err := uuid.Parse(req.OrganizationID)
-	if err != nil {
-		h.log.WithError(err).WithField("org_id", req.OrganizationID).Error("invalid organization ID")
-		u.InternalServerError(w, err.Error())
-		return
+	// At an organization's own address the body may leave the organization
+	// out, but it cannot name a different one.
+	orgID, atTenant := auth.GetTenantID(r.Context())
+	if !atTenant || req.OrganizationID != "" {
+		parsed, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			h.log.WithError(err).WithField("org_id", req.OrganizationID).Error("invalid organization ID")
+			u.InternalServerError(w, err.Error())
+			return
+		}
+		if atTenant && parsed != orgID {
+			u.BadRequest(w, "organization_id does not match the organization being addressed")
+			return
+		}
+		orgID = parsed
 	}
 	user, err := h.authService.RegisterStudent(
 		r.Context(),
@@ -88,24 +206,93 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.authService.Login(r.Context(), req.Email, req.Password)
+	result, err := h.authService.Login(r.Context(), req.Email, req.Password, clientInfo(r))
+	var blocked *service.LoginBlockedError
+	if errors.As(err, &blocked) {
+		writeLoginBlocked(w, blocked)
+		return
+	}
+	if errors.Is(err, service.ErrInvalidCredentials) {
+		u.Unauthorized(w, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrEmailNotVerified) {
+		u.Error(w, http.StatusForbidden, "EMAIL_NOT_VERIFIED", err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrAccountDeactivated) {
+		u.Error(w, http.StatusForbidden, "ACCOUNT_DEACTIVATED", err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrOrganizationSuspended) {
+		u.Error(w, http.StatusForbidden, "ORGANIZATION_SUSPENDED", err.Error())
+		return
+	}
 	if err != nil {
 		h.log.WithField("email", req.Email).Warn("login failed")
 		u.InternalServerError(w, err.Error())
 		return
 	}
 
-	u.OK(w, map[string]string{
-		"access_token": token,
-		"token_type":   "Bearer",
-	})
+	writeLoginResult(w, result)
+}
+
+func writeLoginResult(w http.ResponseWriter, result *service.LoginResult) {
+	if result.Tokens == nil {
+		u.OK(w, dto.LoginChallengeResponse{
+			MFARequired:        true,
+			EnrollmentRequired: result.EnrollmentRequired,
+			ChallengeToken:     result.ChallengeToken,
+		})
+		return
+	}
+
+	u.OK(w, toTokenResponse(result.Tokens))
+}
+
+func (h *UserHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	req := dto.RefreshRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		h.log.WithError(err).Warn("invalid request payload for refresh")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	tokens, err := h.authService.Refresh(r.Context(), req.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, auth.ErrRefreshTokenReused) {
+		u.Unauthorized(w, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrOrganizationSuspended) {
+		u.Error(w, http.StatusForbidden, "ORGANIZATION_SUSPENDED", err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("refresh failed")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, toTokenResponse(tokens))
 }
 
 func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	authHeader := r.Header.Get("Authorization")
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-	err := h.authService.Logout(r.Context(), tokenString)
+	// The body is optional; when it carries a refresh token its whole family
+	// is revoked along with the access token.
+	req := dto.LogoutRequest{}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.log.WithError(err).Warn("invalid request payload for logout")
+			u.BadRequest(w, "Invalid request payload")
+			return
+		}
+	}
+
+	err := h.authService.Logout(r.Context(), tokenString, req.RefreshToken)
 	if err != nil {
 		h.log.WithError(err).Error("logout failed")
 		u.InternalServerError(w, "Failed to logout")
@@ -117,6 +304,130 @@ func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	req := dto.ForgotPasswordRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		h.log.WithError(err).Warn("invalid request payload for forgot password")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	if err := h.passwordService.ForgotPassword(r.Context(), req.Email); err != nil {
+		h.log.WithError(err).WithField("email", req.Email).Error("forgot password failed")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "If the email is registered, a password reset link has been sent",
+	})
+}
+
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	req := dto.ResetPasswordRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		h.log.WithError(err).Warn("invalid request payload for reset password")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	err := h.passwordService.ResetPassword(r.Context(), req.Token, req.NewPassword)
+	if errors.Is(err, service.ErrInvalidResetToken) {
+		u.BadRequest(w, err.Error())
+		return
+	}
+	if errors.Is(err, domain.ErrPasswordTooShort) {
+		u.UnprocessableEntity(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("reset password failed")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Password has been reset",
+	})
+}
+
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	req := dto.VerifyEmailRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		h.log.WithError(err).Warn("invalid request payload for verify email")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	err := h.verificationService.VerifyEmail(r.Context(), req.Token)
+	if errors.Is(err, service.ErrInvalidVerificationToken) {
+		u.BadRequest(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("verify email failed")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Email verified",
+	})
+}
+
+func (h *UserHandler) MarkVerified(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	err = h.verificationService.MarkVerified(r.Context(), userID)
+	if errors.Is(err, service.ErrUserNotFound) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientPrivileges) || errors.Is(err, service.ErrManagedElsewhere) {
+		u.Forbidden(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to mark user as verified")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "User marked as verified",
+	})
+}
+
+func (h *UserHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	err = h.verificationService.ResendVerification(r.Context(), userID)
+	if errors.Is(err, service.ErrUserNotFound) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to resend verification email")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Verification email sent",
+	})
+}
+
 func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
 	user, err := h.authService.Me(r.Context())
 	if err != nil {
@@ -125,11 +436,92 @@ func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	u.OK(w, toMeResponse(user))
+}
+
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	req := dto.UpdateMeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	user, err := h.authService.UpdateMe(r.Context(), service.ProfileUpdate{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Address:   req.Address,
+		BloodType: req.BloodType,
+	})
+	if errors.Is(err, service.ErrInvalidProfileName) || errors.Is(err, domain.ErrInvalidBloodType) {
+		u.UnprocessableEntity(w, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrUserNotFound) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("failed to update profile")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, toMeResponse(user))
+}
+
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "User not found in context")
+		return
+	}
+	sessionID, _ := auth.GetSessionID(r.Context())
+
+	req := dto.ChangePasswordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CurrentPassword == "" {
+		u.BadRequest(w, "Current password is required")
+		return
+	}
+
+	err := h.passwordService.ChangePassword(r.Context(), userID, sessionID, req.CurrentPassword, req.NewPassword)
+	if errors.Is(err, service.ErrIncorrectPassword) {
+		u.Unauthorized(w, err.Error())
+		return
+	}
+	if errors.Is(err, domain.ErrPasswordTooShort) {
+		u.UnprocessableEntity(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("change password failed")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Password changed successfully",
+	})
+}
+
+func toMeResponse(user *domain.User) dto.MeResponse {
 	response := dto.MeResponse{
 		Email:     user.Email,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Roles:     user.RolesStr(),
 	}
-	u.OK(w, response)
+	if user.Metadata != nil {
+		response.Address = user.Metadata.Address
+		response.BloodType = user.Metadata.BloodType
+	}
+	return response
+}
+
+func toTokenResponse(tokens *auth.TokenPair) dto.TokenResponse {
+	return dto.TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+	}
 }
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+)
+
+func (h *UserHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	req := dto.ImpersonateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	tokens, err := h.impersonation.Start(r.Context(), userID, req.Reason, clientInfo(r))
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		u.NotFound(w, err.Error())
+		return
+	case errors.Is(err, service.ErrCannotManageSelf),
+		errors.Is(err, service.ErrInsufficientPrivileges),
+		errors.Is(err, service.ErrAlreadyImpersonating),
+		errors.Is(err, service.ErrImpersonationNotAllowed):
+		u.Forbidden(w, err.Error())
+		return
+	case errors.Is(err, service.ErrAccountDeactivated):
+		u.Error(w, http.StatusForbidden, "ACCOUNT_DEACTIVATED", err.Error())
+		return
+	case err != nil:
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to start impersonation")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, dto.ImpersonationResponse{
+		AccessToken: tokens.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(tokens.ExpiresIn.Seconds()),
+		UserID:      userID,
+	})
+}
+
+func (h *UserHandler) ListImpersonations(w http.ResponseWriter, r *http.Request) {
+	limit, offset := 20, 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if v, err := strconv.Atoi(limitStr); err == nil && v > 0 && v <= 100 {
+			limit = v
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if v, err := strconv.Atoi(offsetStr); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+
+	events, err := h.impersonation.History(r.Context(), limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("failed to list impersonation events")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := make([]dto.ImpersonationEventResponse, 0, len(events))
+	for _, e := range events {
+		res = append(res, dto.ImpersonationEventResponse{
+			ID:        e.ID,
+			ActorID:   e.ActorID,
+			UserID:    e.UserID,
+			SessionID: e.SessionID,
+			Action:    string(e.Action),
+			Method:    e.Method,
+			Path:      e.Path,
+			Status:    e.Status,
+			Reason:    e.Reason,
+			RequestID: e.RequestID,
+			IPAddress: e.IPAddress,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	u.OK(w, res)
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func (h *UserHandler) ListLockedUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.loginGuard.ListLocked(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to list locked users")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := make([]dto.LockedUserResponse, 0, len(users))
+	for _, user := range users {
+		res = append(res, dto.LockedUserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			LockedUntil: *user.LockedUntil,
+		})
+	}
+
+	u.OK(w, res)
+}
+
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	err = h.loginGuard.Unlock(r.Context(), userID)
+	if errors.Is(err, service.ErrUserNotFound) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to unlock user")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Account unlocked",
+	})
+}
+
+func (h *UserHandler) GetLoginHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	h.writeLoginHistory(w, r, userID)
+}
+
+func (h *UserHandler) GetMyLoginHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "User not found in context")
+		return
+	}
+
+	h.writeLoginHistory(w, r, userID)
+}
+
+func (h *UserHandler) writeLoginHistory(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	limit, offset := 20, 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if v, err := strconv.Atoi(limitStr); err == nil && v > 0 && v <= 100 {
+			limit = v
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if v, err := strconv.Atoi(offsetStr); err == nil && v >= 0 {
+			offset = v
+		}
+	}
+
+	events, err := h.loginGuard.History(r.Context(), userID, limit, offset)
+	if errors.Is(err, service.ErrUserNotFound) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to get login history")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := make([]dto.LoginEventResponse, 0, len(events))
+	for _, e := range events {
+		res = append(res, dto.LoginEventResponse{
+			ID:        e.ID,
+			Event:     string(e.Event),
+			Email:     e.Email,
+			IPAddress: e.IPAddress,
+			UserAgent: e.UserAgent,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	u.OK(w, res)
+}
+
+// writeLoginBlocked answers a throttled or locked login with the matching
+// status and a Retry-After header in whole seconds.
+func writeLoginBlocked(w http.ResponseWriter, blocked *service.LoginBlockedError) {
+	if blocked.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+	}
+
+	if errors.Is(blocked, service.ErrAccountLocked) {
+		u.Error(w, http.StatusLocked, "ACCOUNT_LOCKED", blocked.Error())
+		return
+	}
+	u.Error(w, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", blocked.Error())
+}
+
+func clientInfo(r *http.Request) service.ClientInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return service.ClientInfo{IP: ip, UserAgent: r.UserAgent()}
+}
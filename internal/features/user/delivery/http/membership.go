@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func (h *UserHandler) ListMyOrganizations(w http.ResponseWriter, r *http.Request) {
+	memberships, err := h.memberships.List(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to list memberships")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	currentOrgID, _ := auth.GetOrgID(r.Context())
+	res := make([]dto.MembershipResponse, 0, len(memberships))
+	for _, m := range memberships {
+		roles := make([]string, 0, len(m.Roles))
+		for _, role := range m.Roles {
+			roles = append(roles, role.Name)
+		}
+		res = append(res, dto.MembershipResponse{
+			OrganizationID:   m.OrganizationID,
+			OrganizationName: m.OrganizationName,
+			OrganizationSlug: m.OrganizationSlug,
+			IsHome:           m.IsHome,
+			IsCurrent:        m.OrganizationID == currentOrgID,
+			Roles:            roles,
+			JoinedAt:         m.CreatedAt,
+		})
+	}
+
+	u.OK(w, res)
+}
+
+func (h *UserHandler) SwitchOrganization(w http.ResponseWriter, r *http.Request) {
+	req := dto.SwitchOrganizationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OrganizationID == uuid.Nil {
+		u.BadRequest(w, "organization_id is required")
+		return
+	}
+
+	result, err := h.memberships.Switch(r.Context(), req.OrganizationID, clientInfo(r))
+	switch {
+	case errors.Is(err, service.ErrNotAMember):
+		u.NotFound(w, err.Error())
+		return
+	case errors.Is(err, service.ErrSwitchNotAllowed):
+		u.Forbidden(w, err.Error())
+		return
+	case errors.Is(err, service.ErrAccountDeactivated):
+		u.Error(w, http.StatusForbidden, "ACCOUNT_DEACTIVATED", err.Error())
+		return
+	case errors.Is(err, service.ErrOrganizationSuspended):
+		u.Error(w, http.StatusForbidden, "ORGANIZATION_SUSPENDED", err.Error())
+		return
+	case err != nil:
+		h.log.WithError(err).WithField("org_id", req.OrganizationID).Error("failed to switch organization")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	writeLoginResult(w, result)
+}
+
+func (h *UserHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
+	req := dto.InviteMemberRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		u.BadRequest(w, "email is required")
+		return
+	}
+
+	invitation, err := h.userAdmin.InviteMember(r.Context(), req.Email, req.Roles)
+	if err != nil {
+		h.writeUserAdminError(w, err, uuid.Nil)
+		return
+	}
+
+	u.Created(w, toInvitationResponse(invitation))
+}
+
+func (h *UserHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.userAdmin.ListInvitations(r.Context())
+	if err != nil {
+		h.writeUserAdminError(w, err, uuid.Nil)
+		return
+	}
+
+	u.OK(w, toInvitationResponses(invitations))
+}
+
+func (h *UserHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID, ok := invitationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userAdmin.RevokeInvitation(r.Context(), invitationID); err != nil {
+		h.writeUserAdminError(w, err, uuid.Nil)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *UserHandler) ListMyInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.memberships.ListInvitations(r.Context())
+	if err != nil {
+		h.writeInvitationError(w, err, uuid.Nil)
+		return
+	}
+
+	u.OK(w, toInvitationResponses(invitations))
+}
+
+func (h *UserHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID, ok := invitationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.memberships.AcceptInvitation(r.Context(), invitationID); err != nil {
+		h.writeInvitationError(w, err, invitationID)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *UserHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID, ok := invitationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.memberships.DeclineInvitation(r.Context(), invitationID); err != nil {
+		h.writeInvitationError(w, err, invitationID)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *UserHandler) writeInvitationError(w http.ResponseWriter, err error, invitationID uuid.UUID) {
+	switch {
+	case errors.Is(err, service.ErrInvitationNotFound), errors.Is(err, service.ErrUserNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrInvitationNotAllowed):
+		u.Forbidden(w, err.Error())
+	default:
+		h.log.WithError(err).WithField("invitation_id", invitationID).Error("invitation request failed")
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func invitationIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitationID"))
+	if err != nil {
+		u.BadRequest(w, "Invalid invitation ID")
+		return uuid.Nil, false
+	}
+	return invitationID, true
+}
+
+func toInvitationResponse(inv *domain.Invitation) dto.InvitationResponse {
+	roles := make([]string, 0, len(inv.Roles))
+	for _, role := range inv.Roles {
+		roles = append(roles, role.Name)
+	}
+	return dto.InvitationResponse{
+		ID:               inv.ID,
+		OrganizationID:   inv.OrganizationID,
+		OrganizationName: inv.OrganizationName,
+		Email:            inv.Email,
+		Roles:            roles,
+		CreatedAt:        inv.CreatedAt,
+		ExpiresAt:        inv.ExpiresAt,
+	}
+}
+
+func toInvitationResponses(invitations []domain.Invitation) []dto.InvitationResponse {
+	res := make([]dto.InvitationResponse, 0, len(invitations))
+	for i := range invitations {
+		res = append(res, toInvitationResponse(&invitations[i]))
+	}
+	return res
+}
+
+func (h *UserHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userAdmin.RemoveMember(r.Context(), userID); err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.NoContent(w)
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type RoleHandler struct {
+	roleService service.RoleManagement
+	log         *logrus.Logger
+}
+
+func NewRoleHandler(roleService service.RoleManagement, log *logrus.Logger) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+		log:         log,
+	}
+}
+
+func (h *RoleHandler) ProtectedRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(middleware.RequirePermission("role", "read")).Get("/", h.ListRoles)
+	r.With(middleware.RequirePermission("role", "read")).Get("/permissions", h.ListPermissions)
+	r.With(middleware.RequirePermission("role", "read")).Get("/{id}", h.GetRole)
+	r.With(middleware.RequirePermission("role", "create")).Post("/", h.CreateRole)
+	r.With(middleware.RequirePermission("role", "update")).Put("/{id}", h.UpdateRole)
+	r.With(middleware.RequirePermission("role", "delete")).Delete("/{id}", h.DeleteRole)
+
+	return r
+}
+
+func (h *RoleHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	catalogue := h.roleService.Catalogue()
+
+	res := make([]dto.PermissionResourceResponse, 0, len(catalogue))
+	for _, p := range catalogue {
+		res = append(res, dto.PermissionResourceResponse{
+			Resource:    p.Resource,
+			Description: p.Description,
+			Actions:     p.Actions,
+		})
+	}
+
+	u.OK(w, res)
+}
+
+func (h *RoleHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.roleService.List(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to list roles")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := make([]dto.RoleResponse, 0, len(roles))
+	for i := range roles {
+		res = append(res, toRoleResponse(&roles[i]))
+	}
+
+	u.OK(w, res)
+}
+
+func (h *RoleHandler) GetRole(w http.ResponseWriter, r *http.Request) {
+	roleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid role ID")
+		return
+	}
+
+	role, err := h.roleService.Get(r.Context(), roleID)
+	if err != nil {
+		h.writeRoleError(w, err, roleID)
+		return
+	}
+
+	u.OK(w, toRoleResponse(role))
+}
+
+func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	req := dto.RoleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	role, err := h.roleService.Create(r.Context(), service.RoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		h.writeRoleError(w, err, uuid.Nil)
+		return
+	}
+
+	u.Created(w, toRoleResponse(role))
+}
+
+func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	roleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid role ID")
+		return
+	}
+
+	req := dto.RoleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	role, err := h.roleService.Update(r.Context(), roleID, service.RoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		h.writeRoleError(w, err, roleID)
+		return
+	}
+
+	u.OK(w, toRoleResponse(role))
+}
+
+func (h *RoleHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	roleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid role ID")
+		return
+	}
+
+	if err := h.roleService.Delete(r.Context(), roleID); err != nil {
+		h.writeRoleError(w, err, roleID)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *RoleHandler) writeRoleError(w http.ResponseWriter, err error, roleID uuid.UUID) {
+	switch {
+	case errors.Is(err, service.ErrRoleNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrBuiltInRole), errors.Is(err, service.ErrInsufficientPrivileges):
+		u.Forbidden(w, err.Error())
+	case errors.Is(err, service.ErrRoleNameTaken), errors.Is(err, service.ErrRoleInUse):
+		u.Error(w, http.StatusConflict, "CONFLICT", err.Error())
+	case errors.Is(err, service.ErrInvalidRole), errors.Is(err, domain.ErrUnknownPermission):
+		u.UnprocessableEntity(w, err.Error())
+	default:
+		h.log.WithError(err).WithField("role_id", roleID).Error("role request failed")
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func toRoleResponse(role *domain.Role) dto.RoleResponse {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = map[string][]string{}
+	}
+	return dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		BuiltIn:     role.IsBuiltIn(),
+		CreatedAt:   role.CreatedAt,
+	}
+}
@@ -0,0 +1,273 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/scim"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SCIMHandler speaks SCIM 2.0 rather than the API's usual envelope, since
+// identity providers expect RFC 7644 bodies and errors.
+type SCIMHandler struct {
+	provisioning service.Provisioning
+	log          *logrus.Logger
+}
+
+func NewSCIMHandler(provisioning service.Provisioning, log *logrus.Logger) *SCIMHandler {
+	return &SCIMHandler{
+		provisioning: provisioning,
+		log:          log,
+	}
+}
+
+func (h *SCIMHandler) ProtectedRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/ServiceProviderConfig", h.ServiceProviderConfig)
+
+	r.Get("/Users", h.ListUsers)
+	r.Post("/Users", h.CreateUser)
+	r.Get("/Users/{id}", h.GetUser)
+	r.Put("/Users/{id}", h.ReplaceUser)
+	r.Patch("/Users/{id}", h.PatchUser)
+	r.Delete("/Users/{id}", h.DeleteUser)
+
+	r.Get("/Groups", h.ListGroups)
+	r.Post("/Groups", h.CreateGroup)
+	r.Get("/Groups/{id}", h.GetGroup)
+	r.Put("/Groups/{id}", h.ReplaceGroup)
+	r.Patch("/Groups/{id}", h.PatchGroup)
+	r.Delete("/Groups/{id}", h.DeleteGroup)
+
+	return r
+}
+
+func (h *SCIMHandler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	supported := func(ok bool) map[string]bool { return map[string]bool{"supported": ok} }
+	scim.WriteJSON(w, http.StatusOK, map[string]any{
+		"schemas":        []string{scim.ServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": service.SCIMDefaultPageSize},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "API key",
+			"description": "A service account API key with the scim:provision scope, sent as a bearer token",
+			"primary":     true,
+		}},
+	})
+}
+
+func (h *SCIMHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	startIndex, count := scimPaging(r)
+	res, err := h.provisioning.ListUsers(r.Context(), r.URL.Query().Get("filter"), startIndex, count)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	res, err := h.provisioning.GetUser(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req scim.User
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+	res, err := h.provisioning.CreateUser(r.Context(), &req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusCreated, res)
+}
+
+func (h *SCIMHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	var req scim.User
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+	res, err := h.provisioning.ReplaceUser(r.Context(), id, &req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	var req scim.PatchRequest
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+	res, err := h.provisioning.PatchUser(r.Context(), id, req.Operations)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.provisioning.DeactivateUser(r.Context(), id); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SCIMHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	startIndex, count := scimPaging(r)
+	res, err := h.provisioning.ListGroups(r.Context(), r.URL.Query().Get("filter"), startIndex, count)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	res, err := h.provisioning.GetGroup(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req scim.Group
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+	res, err := h.provisioning.CreateGroup(r.Context(), &req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusCreated, res)
+}
+
+func (h *SCIMHandler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	var req scim.Group
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+	res, err := h.provisioning.ReplaceGroup(r.Context(), id, &req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	var req scim.PatchRequest
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+	res, err := h.provisioning.PatchGroup(r.Context(), id, req.Operations)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scim.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *SCIMHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.provisioning.DeleteGroup(r.Context(), id); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SCIMHandler) writeError(w http.ResponseWriter, err error) {
+	var scimErr *scim.Error
+	if errors.As(err, &scimErr) {
+		scim.WriteError(w, scimErr)
+		return
+	}
+	h.log.WithError(err).Error("scim request failed")
+	scim.WriteError(w, scim.NewError(http.StatusInternalServerError, "", "internal server error"))
+}
+
+// scimID parses the resource id. Ids this server never issued cannot exist,
+// so a malformed one is reported as not found.
+func scimID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		scim.WriteError(w, scim.NewError(http.StatusNotFound, "", "resource not found"))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func decodeSCIM(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		scim.WriteError(w, scim.NewError(http.StatusBadRequest, scim.ErrInvalidSyntax, "invalid request body"))
+		return false
+	}
+	return true
+}
+
+func scimPaging(r *http.Request) (startIndex, count int) {
+	startIndex, count = 1, service.SCIMDefaultPageSize
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil {
+		count = v
+	}
+	return startIndex, count
+}
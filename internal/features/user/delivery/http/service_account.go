@@ -0,0 +1,235 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/middleware"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ServiceAccountHandler struct {
+	serviceAccounts service.ServiceAccounts
+	log             *logrus.Logger
+}
+
+func NewServiceAccountHandler(serviceAccounts service.ServiceAccounts, log *logrus.Logger) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccounts: serviceAccounts,
+		log:             log,
+	}
+}
+
+func (h *ServiceAccountHandler) ProtectedRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(middleware.RequirePermission("service_account", "read")).Get("/", h.ListServiceAccounts)
+	r.With(middleware.RequirePermission("service_account", "create")).Post("/", h.CreateServiceAccount)
+	r.With(middleware.RequirePermission("service_account", "delete")).Delete("/{id}", h.DeleteServiceAccount)
+
+	r.With(middleware.RequirePermission("service_account", "read")).Get("/{id}/keys", h.ListAPIKeys)
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RequirePermission("service_account", "update"))
+
+		r.Post("/{id}/keys", h.CreateAPIKey)
+		r.Post("/{id}/keys/{keyID}/rotate", h.RotateAPIKey)
+		r.Delete("/{id}/keys/{keyID}", h.RevokeAPIKey)
+	})
+
+	return r
+}
+
+func (h *ServiceAccountHandler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.UserFilter{Search: q.Get("search")}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 {
+		filter.Limit = limit
+	}
+	if offset, err := strconv.Atoi(q.Get("offset")); err == nil && offset > 0 {
+		filter.Offset = offset
+	}
+
+	accounts, total, err := h.serviceAccounts.ListServiceAccounts(r.Context(), &filter)
+	if err != nil {
+		h.log.WithError(err).Error("failed to list service accounts")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := dto.ServiceAccountListResponse{
+		ServiceAccounts: make([]dto.ServiceAccountResponse, 0, len(accounts)),
+		Total:           total,
+		Limit:           filter.Limit,
+		Offset:          filter.Offset,
+	}
+	for i := range accounts {
+		res.ServiceAccounts = append(res.ServiceAccounts, toServiceAccountResponse(&accounts[i]))
+	}
+
+	u.OK(w, res)
+}
+
+func (h *ServiceAccountHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	req := dto.CreateServiceAccountRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	account, err := h.serviceAccounts.CreateServiceAccount(r.Context(), req.Name)
+	if err != nil {
+		h.writeServiceAccountError(w, err, uuid.Nil)
+		return
+	}
+
+	u.Created(w, toServiceAccountResponse(account))
+}
+
+func (h *ServiceAccountHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid service account ID")
+		return
+	}
+
+	if err := h.serviceAccounts.DeleteServiceAccount(r.Context(), accountID); err != nil {
+		h.writeServiceAccountError(w, err, accountID)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *ServiceAccountHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid service account ID")
+		return
+	}
+
+	keys, err := h.serviceAccounts.ListKeys(r.Context(), accountID)
+	if err != nil {
+		h.writeServiceAccountError(w, err, accountID)
+		return
+	}
+
+	res := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		res = append(res, toAPIKeyResponse(&keys[i]))
+	}
+
+	u.OK(w, res)
+}
+
+func (h *ServiceAccountHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid service account ID")
+		return
+	}
+
+	req := dto.CreateAPIKeyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	key, plaintext, err := h.serviceAccounts.CreateKey(r.Context(), accountID, service.APIKeyInput{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		h.writeServiceAccountError(w, err, accountID)
+		return
+	}
+
+	u.Created(w, dto.CreatedAPIKeyResponse{APIKeyResponse: toAPIKeyResponse(key), Key: plaintext})
+}
+
+func (h *ServiceAccountHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	accountID, keyID, ok := apiKeyParams(w, r)
+	if !ok {
+		return
+	}
+
+	key, plaintext, err := h.serviceAccounts.RotateKey(r.Context(), accountID, keyID)
+	if err != nil {
+		h.writeServiceAccountError(w, err, accountID)
+		return
+	}
+
+	u.Created(w, dto.CreatedAPIKeyResponse{APIKeyResponse: toAPIKeyResponse(key), Key: plaintext})
+}
+
+func (h *ServiceAccountHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	accountID, keyID, ok := apiKeyParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceAccounts.RevokeKey(r.Context(), accountID, keyID); err != nil {
+		h.writeServiceAccountError(w, err, accountID)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *ServiceAccountHandler) writeServiceAccountError(w http.ResponseWriter, err error, accountID uuid.UUID) {
+	switch {
+	case errors.Is(err, service.ErrServiceAccountNotFound), errors.Is(err, service.ErrAPIKeyNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrInsufficientPrivileges):
+		u.Forbidden(w, err.Error())
+	case errors.Is(err, service.ErrInvalidAPIKeyInput), errors.Is(err, domain.ErrUnknownPermission):
+		u.UnprocessableEntity(w, err.Error())
+	default:
+		h.log.WithError(err).WithField("service_account_id", accountID).Error("service account request failed")
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func apiKeyParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid service account ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyID"))
+	if err != nil {
+		u.BadRequest(w, "Invalid API key ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return accountID, keyID, true
+}
+
+func toServiceAccountResponse(account *domain.User) dto.ServiceAccountResponse {
+	return dto.ServiceAccountResponse{
+		ID:        account.ID,
+		Name:      account.FirstName,
+		IsActive:  account.IsActive(),
+		CreatedAt: account.CreatedAt,
+	}
+}
+
+func toAPIKeyResponse(key *domain.APIKey) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func (h *UserHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "User not found in context")
+		return
+	}
+	currentID, _ := auth.GetSessionID(r.Context())
+
+	sessions, err := h.sessionService.List(r.Context(), userID)
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to list sessions")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := make([]dto.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, dto.SessionResponse{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == currentID,
+		})
+	}
+
+	u.OK(w, res)
+}
+
+func (h *UserHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "User not found in context")
+		return
+	}
+
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		u.BadRequest(w, "Invalid session ID")
+		return
+	}
+
+	err = h.sessionService.Revoke(r.Context(), userID, sessionID)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("session_id", sessionID).Error("failed to revoke session")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Session revoked",
+	})
+}
+
+func (h *UserHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "User not found in context")
+		return
+	}
+	currentID, _ := auth.GetSessionID(r.Context())
+
+	revoked, err := h.sessionService.RevokeOthers(r.Context(), userID, currentID)
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", userID).Error("failed to revoke other sessions")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, map[string]int{
+		"revoked": revoked,
+	})
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/oidc"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *UserHandler) BeginSSO(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "orgSlug")
+
+	authURL, err := h.ssoService.Begin(r.Context(), slug, r.URL.Query().Get("login_hint"))
+	if errors.Is(err, service.ErrSSONotConfigured) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("org_slug", slug).Error("failed to start sso login")
+		u.InternalServerError(w, "Failed to start single sign-on")
+		return
+	}
+
+	u.OK(w, dto.SSOAuthorizeResponse{AuthorizationURL: authURL})
+}
+
+func (h *UserHandler) CompleteSSO(w http.ResponseWriter, r *http.Request) {
+	req := dto.SSOCallbackRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.State == "" || req.Code == "" {
+		h.log.WithError(err).Warn("invalid request payload for sso callback")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	result, err := h.ssoService.Complete(r.Context(), req.State, req.Code, clientInfo(r))
+	var blocked *service.LoginBlockedError
+	switch {
+	case errors.As(err, &blocked):
+		writeLoginBlocked(w, blocked)
+	case errors.Is(err, service.ErrAccountDeactivated):
+		u.Error(w, http.StatusForbidden, "ACCOUNT_DEACTIVATED", err.Error())
+	case errors.Is(err, service.ErrOrganizationSuspended):
+		u.Error(w, http.StatusForbidden, "ORGANIZATION_SUSPENDED", err.Error())
+	case errors.Is(err, service.ErrInvalidSSOState):
+		u.BadRequest(w, err.Error())
+	case errors.Is(err, oidc.ErrInvalidIDToken):
+		u.Unauthorized(w, "Identity provider returned an invalid token")
+	case errors.Is(err, service.ErrSSOEmailNotVerified), errors.Is(err, service.ErrSSOUserNotAllowed):
+		u.Forbidden(w, err.Error())
+	case errors.Is(err, service.ErrSSONotConfigured):
+		u.NotFound(w, err.Error())
+	case err != nil:
+		h.log.WithError(err).Error("sso callback failed")
+		u.InternalServerError(w, "Failed to complete single sign-on")
+	default:
+		writeLoginResult(w, result)
+	}
+}
+
+func (h *UserHandler) GetIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	provider, err := h.ssoService.GetProvider(r.Context())
+	if errors.Is(err, service.ErrSSONotConfigured) {
+		u.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("failed to get identity provider")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, toIdentityProviderResponse(provider))
+}
+
+func (h *UserHandler) SaveIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	req := dto.IdentityProviderRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log.WithError(err).Warn("invalid request payload for identity provider")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	provider := &domain.IdentityProvider{
+		Issuer:         req.Issuer,
+		ClientID:       req.ClientID,
+		ClientSecret:   req.ClientSecret,
+		DefaultRole:    req.DefaultRole,
+		AutoProvision:  req.AutoProvision,
+		AllowedDomains: req.AllowedDomains,
+		IsEnabled:      req.IsEnabled,
+	}
+	if provider.AllowedDomains == nil {
+		provider.AllowedDomains = []string{}
+	}
+
+	err := h.ssoService.SaveProvider(r.Context(), provider)
+	if errors.Is(err, service.ErrInvalidProvider) {
+		u.UnprocessableEntity(w, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientPrivileges) {
+		u.Forbidden(w, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("failed to save identity provider")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, toIdentityProviderResponse(provider))
+}
+
+func toIdentityProviderResponse(p *domain.IdentityProvider) dto.IdentityProviderResponse {
+	return dto.IdentityProviderResponse{
+		Issuer:          p.Issuer,
+		ClientID:        p.ClientID,
+		HasClientSecret: p.ClientSecret != "",
+		DefaultRole:     p.DefaultRole,
+		AutoProvision:   p.AutoProvision,
+		AllowedDomains:  p.AllowedDomains,
+		IsEnabled:       p.IsEnabled,
+	}
+}
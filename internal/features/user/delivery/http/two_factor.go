@@ -0,0 +1,207 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/google/uuid"
+)
+
+// --- login challenge (public) ---
+
+func (h *UserHandler) VerifyTwoFactorChallenge(w http.ResponseWriter, r *http.Request) {
+	req := dto.TwoFactorChallengeRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" {
+		h.log.WithError(err).Warn("invalid request payload for two-factor verify")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	tokens, err := h.twoFactorService.VerifyChallenge(r.Context(), req.ChallengeToken, req.Code, clientInfo(r))
+	if err != nil {
+		h.writeTwoFactorError(w, err, "two-factor verification failed")
+		return
+	}
+
+	u.OK(w, toTokenResponse(tokens))
+}
+
+func (h *UserHandler) BeginChallengeEnrollment(w http.ResponseWriter, r *http.Request) {
+	req := dto.TwoFactorChallengeRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" {
+		h.log.WithError(err).Warn("invalid request payload for two-factor enrollment")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	enrollment, err := h.twoFactorService.BeginChallengeEnrollment(r.Context(), req.ChallengeToken)
+	if err != nil {
+		h.writeTwoFactorError(w, err, "failed to start two-factor enrollment")
+		return
+	}
+
+	u.OK(w, dto.TOTPEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+func (h *UserHandler) ConfirmChallengeEnrollment(w http.ResponseWriter, r *http.Request) {
+	req := dto.TwoFactorChallengeRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" {
+		h.log.WithError(err).Warn("invalid request payload for two-factor enrollment confirm")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	tokens, codes, err := h.twoFactorService.ConfirmChallengeEnrollment(r.Context(), req.ChallengeToken, req.Code, clientInfo(r))
+	if err != nil {
+		h.writeTwoFactorError(w, err, "failed to confirm two-factor enrollment")
+		return
+	}
+
+	u.OK(w, dto.TwoFactorEnrollmentLoginResponse{
+		TokenResponse: toTokenResponse(tokens),
+		RecoveryCodes: codes,
+	})
+}
+
+// --- self-service (protected) ---
+
+func (h *UserHandler) BeginTwoFactorEnrollment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r.Context())
+	if !ok {
+		u.Unauthorized(w, "User not found in context")
+		return
+	}
+
+	enrollment, err := h.twoFactorService.BeginEnrollment(r.Context(), userID)
+	if err != nil {
+		h.writeTwoFactorError(w, err, "failed to start two-factor enrollment")
+		return
+	}
+
+	u.OK(w, dto.TOTPEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+func (h *UserHandler) ConfirmTwoFactorEnrollment(w http.ResponseWriter, r *http.Request) {
+	userID, req, ok := h.decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.twoFactorService.ConfirmEnrollment(r.Context(), userID, req.Code)
+	if err != nil {
+		h.writeTwoFactorError(w, err, "failed to confirm two-factor enrollment")
+		return
+	}
+
+	u.OK(w, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *UserHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, req, ok := h.decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.twoFactorService.Disable(r.Context(), userID, req.Code); err != nil {
+		h.writeTwoFactorError(w, err, "failed to disable two-factor authentication")
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "Two-factor authentication disabled",
+	})
+}
+
+func (h *UserHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, req, ok := h.decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(r.Context(), userID, req.Code)
+	if err != nil {
+		h.writeTwoFactorError(w, err, "failed to regenerate recovery codes")
+		return
+	}
+
+	u.OK(w, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// --- organization policy (protected) ---
+
+func (h *UserHandler) GetTwoFactorPolicy(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.twoFactorService.GetPolicy(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get two-factor policy")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, dto.TwoFactorPolicy{RequiredRoles: roles})
+}
+
+func (h *UserHandler) UpdateTwoFactorPolicy(w http.ResponseWriter, r *http.Request) {
+	req := dto.TwoFactorPolicy{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.log.WithError(err).Warn("invalid request payload for two-factor policy")
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	if err := h.twoFactorService.UpdatePolicy(r.Context(), req.RequiredRoles); err != nil {
+		h.log.WithError(err).Error("failed to update two-factor policy")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	u.OK(w, req)
+}
+
+// --- helpers ---
+
+func (h *UserHandler) decodeCodeRequest(w http.ResponseWriter, r *http.Request) (userID uuid.UUID, req dto.TwoFactorCodeRequest, ok bool) {
+	id, found := auth.GetUserID(r.Context())
+	if !found {
+		u.Unauthorized(w, "User not found in context")
+		return userID, req, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		h.log.WithError(err).Warn("invalid request payload for two-factor code")
+		u.BadRequest(w, "Invalid request payload")
+		return userID, req, false
+	}
+
+	return id, req, true
+}
+
+func (h *UserHandler) writeTwoFactorError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidChallenge), errors.Is(err, service.ErrInvalidTOTPCode):
+		u.Unauthorized(w, err.Error())
+	case errors.Is(err, service.ErrTwoFactorNotEnrolled), errors.Is(err, service.ErrTwoFactorAlreadyOn):
+		u.BadRequest(w, err.Error())
+	case errors.Is(err, service.ErrTwoFactorRequiredByOrg):
+		u.Forbidden(w, err.Error())
+	case errors.Is(err, service.ErrOrganizationSuspended):
+		u.Error(w, http.StatusForbidden, "ORGANIZATION_SUSPENDED", err.Error())
+	default:
+		h.log.WithError(err).Error(msg)
+		u.InternalServerError(w, err.Error())
+	}
+}
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/delivery/dto"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/service"
+	u "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := domain.UserFilter{
+		Search: q.Get("search"),
+		Role:   q.Get("role"),
+		Status: q.Get("status"),
+	}
+	if filter.Status != "" && filter.Status != domain.UserStatusActive && filter.Status != domain.UserStatusInactive {
+		u.BadRequest(w, "status must be 'active' or 'inactive'")
+		return
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 {
+		filter.Limit = limit
+	}
+	if offset, err := strconv.Atoi(q.Get("offset")); err == nil && offset > 0 {
+		filter.Offset = offset
+	}
+
+	users, total, err := h.userAdmin.List(r.Context(), &filter)
+	if err != nil {
+		h.log.WithError(err).Error("failed to list users")
+		u.InternalServerError(w, err.Error())
+		return
+	}
+
+	res := dto.UserListResponse{
+		Users:  make([]dto.UserResponse, 0, len(users)),
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for i := range users {
+		res.Users = append(res.Users, toUserResponse(&users[i]))
+	}
+
+	u.OK(w, res)
+}
+
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userAdmin.Get(r.Context(), userID)
+	if err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.OK(w, toUserResponse(user))
+}
+
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	req := dto.UpdateUserRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		u.BadRequest(w, "Invalid request payload")
+		return
+	}
+
+	user, err := h.userAdmin.UpdateProfile(r.Context(), userID, service.UserProfileUpdate{
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	})
+	if err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.OK(w, toUserResponse(user))
+}
+
+func (h *UserHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userAdmin.Activate(r.Context(), userID); err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "User activated",
+	})
+}
+
+func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userAdmin.Deactivate(r.Context(), userID); err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.OK(w, map[string]string{
+		"message": "User deactivated",
+	})
+}
+
+func (h *UserHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	req := dto.AssignRoleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Role == "" {
+		u.BadRequest(w, "role is required")
+		return
+	}
+
+	user, err := h.userAdmin.AssignRole(r.Context(), userID, req.Role)
+	if err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.OK(w, toUserResponse(user))
+}
+
+func (h *UserHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userAdmin.RevokeRole(r.Context(), userID, chi.URLParam(r, "role"))
+	if err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.OK(w, toUserResponse(user))
+}
+
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userAdmin.Delete(r.Context(), userID); err != nil {
+		h.writeUserAdminError(w, err, userID)
+		return
+	}
+
+	u.NoContent(w)
+}
+
+func (h *UserHandler) writeUserAdminError(w http.ResponseWriter, err error, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrRoleNotFound),
+		errors.Is(err, service.ErrInvitationNotFound):
+		u.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrCannotManageSelf), errors.Is(err, service.ErrInsufficientPrivileges),
+		errors.Is(err, service.ErrManagedElsewhere):
+		u.Forbidden(w, err.Error())
+	case errors.Is(err, service.ErrEmailTaken), errors.Is(err, service.ErrHomeMembership):
+		u.Error(w, http.StatusConflict, "CONFLICT", err.Error())
+	case errors.Is(err, service.ErrInvalidProfile):
+		u.UnprocessableEntity(w, err.Error())
+	default:
+		h.log.WithError(err).WithField("user_id", userID).Error("user management request failed")
+		u.InternalServerError(w, err.Error())
+	}
+}
+
+func userIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		u.BadRequest(w, "Invalid user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func toUserResponse(user *domain.User) dto.UserResponse {
+	res := dto.UserResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Roles:            user.RolesStr(),
+		IsActive:         user.IsActive(),
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.TOTPEnabledAt != nil,
+		GuardianID:       user.GuardianID,
+		IsGuest:          user.IsGuest(),
+		CreatedAt:        user.CreatedAt,
+	}
+	if user.IsLocked(time.Now()) {
+		res.LockedUntil = user.LockedUntil
+	}
+	if res.Roles == nil {
+		res.Roles = []string{}
+	}
+	return res
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	ListByServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) ([]APIKey, error)
+	// Rotate revokes oldID and stores replacement in one transaction.
+	Rotate(ctx context.Context, oldID uuid.UUID, replacement *APIKey) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllForServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
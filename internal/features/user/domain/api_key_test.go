@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAPIKeyLookup(t *testing.T) {
+	key, plaintext, err := NewAPIKey(&User{}, "sis-sync", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "Generated key", input: plaintext, want: key.Prefix, wantOK: true},
+		{name: "JWT", input: "eyJhbGciOiJIUzI1NiJ9.e30.sig", wantOK: false},
+		{name: "Prefix only", input: key.Prefix, wantOK: false},
+		{name: "Missing separator", input: key.Prefix + "x" + "secret", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := APIKeyLookup(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("APIKeyLookup() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if HashAPIKey(plaintext) != key.KeyHash {
+		t.Error("HashAPIKey() does not match the stored hash")
+	}
+}
+
+func TestAPIKeyIsUsable(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		key  APIKey
+		want bool
+	}{
+		{name: "No expiry", key: APIKey{ID: uuid.New()}, want: true},
+		{name: "Not yet expired", key: APIKey{ExpiresAt: &future}, want: true},
+		{name: "Expired", key: APIKey{ExpiresAt: &past}, want: false},
+		{name: "Revoked", key: APIKey{RevokedAt: &past}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.IsUsable(now); got != tt.want {
+				t.Errorf("IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
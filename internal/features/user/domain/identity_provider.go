@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdentityProvider is an organization's OpenID Connect provider. Users of the
+// organization can sign in through it instead of with a password.
+type IdentityProvider struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	// DefaultRole is given to users created on their first SSO login when
+	// AutoProvision is on.
+	DefaultRole   string
+	AutoProvision bool
+	// AllowedDomains restricts which email domains may sign in. Empty allows
+	// any domain.
+	AllowedDomains []string
+
+	IsEnabled bool
+
+	// ConfiguredBy is the administrator who last saved the provider. A first
+	// SSO login only links an existing account this administrator could
+	// manage.
+	ConfiguredBy *uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p *IdentityProvider) AllowsEmail(email string) bool {
+	if len(p.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range p.AllowedDomains {
+		if strings.ToLower(strings.TrimPrefix(allowed, "@")) == domain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type IdentityProviderRepository interface {
+	GetByOrganization(ctx context.Context, orgID uuid.UUID) (*IdentityProvider, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*IdentityProvider, error)
+	Save(ctx context.Context, provider *IdentityProvider) error
+
+	FindLinkedUser(ctx context.Context, providerID uuid.UUID, subject string) (*uuid.UUID, error)
+	Link(ctx context.Context, userID, providerID uuid.UUID, subject string) error
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImpersonationAction string
+
+const (
+	ImpersonationStarted ImpersonationAction = "start"
+	ImpersonationWrite   ImpersonationAction = "write"
+)
+
+// ImpersonationEvent records something ActorID did while signed in as
+// UserID. SessionID is the "sid" of the impersonation token.
+type ImpersonationEvent struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	ActorID        uuid.UUID
+	UserID         uuid.UUID
+	SessionID      uuid.UUID
+	Action         ImpersonationAction
+	Method         string
+	Path           string
+	Status         int
+	Reason         string
+	RequestID      string
+	IPAddress      string
+	CreatedAt      time.Time
+}
+
+func NewImpersonationEvent(action ImpersonationAction, orgID, actorID, userID, sessionID uuid.UUID) *ImpersonationEvent {
+	return &ImpersonationEvent{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		UserID:         userID,
+		SessionID:      sessionID,
+		Action:         action,
+		CreatedAt:      time.Now(),
+	}
+}
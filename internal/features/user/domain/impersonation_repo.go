@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, event *ImpersonationEvent) error
+	ListByOrganization(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]ImpersonationEvent, error)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation offers the account registered under Email membership of an
+// organization with the given roles. Nothing changes for the account until
+// its owner accepts.
+type Invitation struct {
+	ID               uuid.UUID
+	OrganizationID   uuid.UUID
+	OrganizationName string
+	Email            string
+	Roles            []Role
+	InvitedBy        *uuid.UUID
+	CreatedAt        time.Time
+	ExpiresAt        time.Time
+}
+
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type InvitationRepository interface {
+	// Save stores the invitation, replacing a pending one for the same
+	// organization and email.
+	Save(ctx context.Context, invitation *Invitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	// ListByEmail returns the unexpired invitations for email across
+	// organizations.
+	ListByEmail(ctx context.Context, email string) ([]Invitation, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]Invitation, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginEventType string
+
+const (
+	LoginSucceeded  LoginEventType = "success"
+	LoginFailed     LoginEventType = "failure"
+	LoginThrottled  LoginEventType = "throttled"
+	AccountLocked   LoginEventType = "locked"
+	AccountUnlocked LoginEventType = "unlocked"
+)
+
+type LoginEvent struct {
+	ID             uuid.UUID
+	UserID         *uuid.UUID
+	OrganizationID *uuid.UUID
+	Email          string
+	Event          LoginEventType
+	IPAddress      string
+	UserAgent      string
+	CreatedAt      time.Time
+}
+
+func NewLoginEvent(event LoginEventType, email, ip, userAgent string, user *User) *LoginEvent {
+	e := &LoginEvent{
+		ID:        uuid.New(),
+		Email:     email,
+		Event:     event,
+		IPAddress: ip,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+	if user != nil {
+		e.UserID = &user.ID
+		e.OrganizationID = &user.OrganizationID
+	}
+	return e
+}
+
+// LoginBackoff returns how long a caller must wait after the given number of
+// consecutive failures. The first few failures are free, after that the delay
+// doubles up to max.
+func LoginBackoff(failures, freeAttempts int, base, max time.Duration) time.Duration {
+	if failures <= freeAttempts {
+		return 0
+	}
+	d := base
+	for i := freeAttempts + 1; i < failures; i++ {
+		d *= 2
+		if d >= max {
+			return max
+		}
+	}
+	return d
+}
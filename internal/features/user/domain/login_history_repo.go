@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type LoginHistoryRepository interface {
+	Create(ctx context.Context, event *LoginEvent) error
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]LoginEvent, error)
+}
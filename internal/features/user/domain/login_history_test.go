@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{name: "Free attempt", failures: 3, want: 0},
+		{name: "First delayed attempt", failures: 4, want: time.Second},
+		{name: "Doubles each failure", failures: 6, want: 4 * time.Second},
+		{name: "Capped at max", failures: 30, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoginBackoff(tt.failures, 3, time.Second, time.Minute); got != tt.want {
+				t.Errorf("LoginBackoff(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
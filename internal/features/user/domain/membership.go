@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Membership is a user's place in one organization and the roles they hold
+// there.
+type Membership struct {
+	UserID           uuid.UUID
+	OrganizationID   uuid.UUID
+	OrganizationName string
+	OrganizationSlug string
+	IsHome           bool
+	Roles            []Role
+	CreatedAt        time.Time
+}
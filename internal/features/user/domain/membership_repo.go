@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type MembershipRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]Membership, error)
+	// Add makes the user a member of orgID holding roles. Adding an existing
+	// member only adds the roles they do not hold yet.
+	Add(ctx context.Context, userID, orgID uuid.UUID, roles []Role) error
+	// Remove drops the membership together with the roles held through it.
+	Remove(ctx context.Context, userID, orgID uuid.UUID) error
+}
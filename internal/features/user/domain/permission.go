@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrUnknownPermission = errors.New("unknown permission")
+
+// PermissionResource lists the actions that can be granted on one resource.
+type PermissionResource struct {
+	Resource    string
+	Description string
+	Actions     []string
+}
+
+// PermissionCatalogue is every resource and action a role may grant. Routes
+// check these with middleware.RequirePermission, so a new permission
+// requirement should be added here too.
+var PermissionCatalogue = []PermissionResource{
+	{Resource: "user", Description: "User accounts", Actions: []string{"create", "read", "update", "delete", "impersonate"}},
+	{Resource: "role", Description: "Roles and their permissions", Actions: []string{"create", "read", "update", "delete"}},
+	{Resource: "service_account", Description: "Service accounts and their API keys", Actions: []string{"create", "read", "update", "delete"}},
+	{Resource: "scim", Description: "SCIM provisioning from an identity provider", Actions: []string{"provision"}},
+	{Resource: "organization", Description: "Organization profile and settings", Actions: []string{"read", "update"}},
+	{Resource: "course", Description: "Courses", Actions: []string{"create", "read", "update", "delete", "archive", "enroll"}},
+	{Resource: "content", Description: "Course content", Actions: []string{"upload", "organize"}},
+	{Resource: "student", Description: "Student progress", Actions: []string{"grade", "view_progress"}},
+	{Resource: "quiz", Description: "Quizzes", Actions: []string{"take", "view_results"}},
+	{Resource: "assessment", Description: "Assessments and grades", Actions: []string{"create", "read", "update"}},
+	{Resource: "event", Description: "Calendar events", Actions: []string{"create", "read", "update", "delete"}},
+	{Resource: "attachment", Description: "File attachments", Actions: []string{"create", "read", "delete"}},
+	{Resource: "report", Description: "Reports", Actions: []string{"read", "export"}},
+	{Resource: "billing", Description: "Billing and payments", Actions: []string{"read", "refund"}},
+	{Resource: "child", Description: "A guardian's own children", Actions: []string{"read"}},
+}
+
+// ValidatePermissions rejects resources and actions that are not in the
+// catalogue. The "all" resource and "manage" action wildcards are accepted.
+func ValidatePermissions(permissions map[string][]string) error {
+	resources := make([]string, 0, len(permissions))
+	for resource := range permissions {
+		resources = append(resources, resource)
+	}
+	// Report the same error for the same input.
+	sort.Strings(resources)
+
+	for _, resource := range resources {
+		actions := permissions[resource]
+		if len(actions) == 0 {
+			return fmt.Errorf("%w: %s has no actions", ErrUnknownPermission, resource)
+		}
+
+		known, ok := catalogueActions(resource)
+		if !ok {
+			return fmt.Errorf("%w: resource %q", ErrUnknownPermission, resource)
+		}
+		for _, action := range actions {
+			if action != ManageAction && !known[action] {
+				return fmt.Errorf("%w: %s:%s", ErrUnknownPermission, resource, action)
+			}
+		}
+	}
+	return nil
+}
+
+func catalogueActions(resource string) (map[string]bool, bool) {
+	if resource == AllResources {
+		// "all" applies an action to every resource, so any catalogued
+		// action is meaningful there.
+		actions := make(map[string]bool)
+		for _, r := range PermissionCatalogue {
+			for _, a := range r.Actions {
+				actions[a] = true
+			}
+		}
+		return actions, true
+	}
+
+	for _, r := range PermissionCatalogue {
+		if r.Resource == resource {
+			actions := make(map[string]bool, len(r.Actions))
+			for _, a := range r.Actions {
+				actions[a] = true
+			}
+			return actions, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatePermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions map[string][]string
+		wantErr     bool
+	}{
+		{name: "Empty role", permissions: map[string][]string{}, wantErr: false},
+		{name: "Known permissions", permissions: map[string][]string{"user": {"read", "update"}, "event": {"read"}}, wantErr: false},
+		{name: "Manage wildcard", permissions: map[string][]string{"event": {ManageAction}}, wantErr: false},
+		{name: "All resources", permissions: map[string][]string{AllResources: {"read"}}, wantErr: false},
+		{name: "Unknown resource", permissions: map[string][]string{"payroll": {"read"}}, wantErr: true},
+		{name: "Unknown action", permissions: map[string][]string{"user": {"promote"}}, wantErr: true},
+		{name: "No actions", permissions: map[string][]string{"user": {}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePermissions(tt.permissions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrUnknownPermission) {
+				t.Errorf("ValidatePermissions() error = %v, want ErrUnknownPermission", err)
+			}
+		})
+	}
+}
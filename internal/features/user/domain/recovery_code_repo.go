@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RecoveryCodeRepository interface {
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	DeleteForUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -2,24 +2,45 @@ package domain
 
 import (
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared"
+	"github.com/google/uuid"
 )
 
 type Role struct {
 	shared.Base
 
+	// OrganizationID is nil for built-in roles, which every organization
+	// shares and nobody can edit through the API.
+	OrganizationID *uuid.UUID `json:"organization_id"`
+
 	Name string
+	Description string `json:"description"`
 	Permissions map[string][]string `json:"permissions"`
 }
 
+const (
+	// AllResources and ManageAction are wildcards: "manage" grants every
+	// action on a resource, and "all" applies its actions to every resource.
+	AllResources = "all"
+	ManageAction = "manage"
+)
+
 func (r *Role) HasPermission(resource, action string) bool {
+	return r.allows(resource, action) || r.allows(AllResources, action)
+}
+
+func (r *Role) allows(resource, action string) bool {
     actions, ok := r.Permissions[resource]
     if !ok {
         return false
     }
     for _, a := range actions {
-        if a == action {
+        if a == action || a == ManageAction {
             return true
         }
     }
     return false
-}
\ No newline at end of file
+}
+
+func (r *Role) IsBuiltIn() bool {
+	return r.OrganizationID == nil
+}
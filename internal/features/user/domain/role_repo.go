@@ -8,7 +8,16 @@ import (
 
 type RoleRepository interface {
 	Create(ctx context.Context, role *Role) error
+	// GetByName returns the built-in role with the given name.
 	GetByName(ctx context.Context, name string) (*Role, error)
-	AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error
-	RevokeUserRole(ctx context.Context, userID, roleID uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Role, error)
+	// GetForOrganization returns the role named name that members of orgID
+	// can hold: either a built-in role or one of the organization's own.
+	GetForOrganization(ctx context.Context, orgID uuid.UUID, name string) (*Role, error)
+	ListForOrganization(ctx context.Context, orgID uuid.UUID) ([]Role, error)
+	Update(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID) error
+	CountUsers(ctx context.Context, roleID uuid.UUID) (int, error)
+	AssignRoleToUser(ctx context.Context, userID, orgID, roleID uuid.UUID) error
+	RevokeUserRole(ctx context.Context, userID, orgID, roleID uuid.UUID) error
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a single login on one device. Its ID is shared with the refresh
+// token family so revoking one revokes the other.
+type Session struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	UserAgent      string
+	IPAddress      string
+	CreatedAt      time.Time
+	LastSeenAt     time.Time
+	ExpiresAt      time.Time
+	RevokedAt      *time.Time
+}
+
+func NewSession(id uuid.UUID, user *User, ip, userAgent string, ttl time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		ID:             id,
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		UserAgent:      userAgent,
+		IPAddress:      ip,
+		CreatedAt:      now,
+		LastSeenAt:     now,
+		ExpiresAt:      now.Add(ttl),
+	}
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
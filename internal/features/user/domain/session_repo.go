@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	Touch(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// RFC 6238 Appendix B, SHA1 variant. The RFC lists 8 digit codes; we use
+	// the last 6, which is what a 6 digit TOTP yields for the same counter.
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d) error = %v", tt.unix, err)
+		}
+		if want := tt.want[len(tt.want)-TOTPDigits:]; got != want {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, got, want)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		wantOK bool
+	}{
+		{name: "Success: current step", offset: 0, wantOK: true},
+		{name: "Success: previous step within skew", offset: -TOTPPeriod * time.Second, wantOK: true},
+		{name: "Success: next step within skew", offset: TOTPPeriod * time.Second, wantOK: true},
+		{name: "Failure: two steps old", offset: -2 * TOTPPeriod * time.Second, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := TOTPCode(secret, TOTPStep(now.Add(tt.offset)))
+			step, ok := VerifyTOTP(secret, code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("VerifyTOTP() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != TOTPStep(now.Add(tt.offset)) {
+				t.Errorf("VerifyTOTP() step = %d, want %d", step, TOTPStep(now.Add(tt.offset)))
+			}
+		})
+	}
+
+	if _, ok := VerifyTOTP(secret, "12345", now); ok {
+		t.Error("VerifyTOTP() accepted a code with the wrong length")
+	}
+}
+
+func TestHashRecoveryCode_Normalises(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := codes[0]
+
+	variants := []string{code, strings.ToUpper(code), " " + strings.ReplaceAll(code, "-", "") + " "}
+	for _, v := range variants {
+		if HashRecoveryCode(v) != HashRecoveryCode(code) {
+			t.Errorf("HashRecoveryCode(%q) differs from HashRecoveryCode(%q)", v, code)
+		}
+	}
+}
@@ -1,30 +1,73 @@
 package domain
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const MinPasswordLength = 8
+
+// GuardianRole is the role of parent accounts linked to their children.
+const GuardianRole = "guardian"
+
+var (
+	ErrPasswordTooShort = errors.New("password must be at least 8 characters long")
+	ErrInvalidBloodType = errors.New("blood type must be one of A+, A-, B+, B-, AB+, AB-, O+, O-")
+)
+
+var bloodTypes = map[string]bool{
+	"A+": true, "A-": true, "B+": true, "B-": true,
+	"AB+": true, "AB-": true, "O+": true, "O-": true,
+}
+
 type UserMetadata struct {
 	Address   string `json:"address"`
 	BloodType string `json:"blood_type"`
 }
 
+// Validate accepts an empty blood type, which means it is not known.
+func (m *UserMetadata) Validate() error {
+	if m.BloodType != "" && !bloodTypes[m.BloodType] {
+		return ErrInvalidBloodType
+	}
+	return nil
+}
+
 type User struct {
 	shared.Base
 
-	OrganizationID uuid.UUID
+	// OrganizationID is the organization the user is loaded for: where they
+	// are acting, and the one their Roles belong to. HomeOrganizationID is
+	// the organization that owns the account itself.
+	OrganizationID     uuid.UUID
+	HomeOrganizationID uuid.UUID
 
 	Email        string
 	PasswordHash string
 	FirstName    string
 	LastName     string
-	Metadata     *UserMetadata // TODO: add to migration
-	GuardianID   *uuid.UUID // TODO: add to migration
+	Metadata     *UserMetadata
+	GuardianID   *uuid.UUID
 	Roles        []Role
 
-	IsSuperuser bool
+	IsSuperuser     bool
+	EmailVerifiedAt *time.Time
+
+	// IsServiceAccount marks accounts used by integrations. They sign in
+	// with API keys only.
+	IsServiceAccount bool
+
+	TOTPSecret    *string
+	TOTPEnabledAt *time.Time
+
+	LockedUntil   *time.Time
+	DeactivatedAt *time.Time
 }
 
 func NewUser(email, firstName, lastName string, orgID uuid.UUID, roles []Role) *User {
@@ -33,6 +76,7 @@ func NewUser(email, firstName, lastName string, orgID uuid.UUID, roles []Role) *
 		FirstName:      firstName,
 		LastName:       lastName,
 		OrganizationID: orgID,
+		HomeOrganizationID: orgID,
 		Roles: 			roles,
 		IsSuperuser:    false,
 		Metadata:       &UserMetadata{},
@@ -46,6 +90,42 @@ func (u *User) IsChildOf(possibleGuardian User) bool {
 	return *u.GuardianID == possibleGuardian.Base.ID
 }
 
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	return nil
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) MarkEmailVerified() {
+	if u.EmailVerifiedAt == nil {
+		now := time.Now()
+		u.EmailVerifiedAt = &now
+	}
+}
+
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// IsGuest reports whether the user is acting in an organization other than
+// the one that owns their account.
+func (u *User) IsGuest() bool {
+	return u.OrganizationID != u.HomeOrganizationID
+}
+
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+func (u *User) HasTwoFactor() bool {
+	return u.TOTPSecret != nil && u.TOTPEnabledAt != nil
+}
+
 func (u *User) HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
@@ -60,6 +140,16 @@ func (u *User) SetPassword(password string) error {
 	return nil
 }
 
+// SetRandomPassword gives accounts created on someone's behalf a password
+// nobody knows; the owner sets a real one through a password reset.
+func (u *User) SetRandomPassword() error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	return u.SetPassword(base64.RawURLEncoding.EncodeToString(b))
+}
+
 func (u *User) CheckPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
 	return err == nil
@@ -100,4 +190,34 @@ func (u *User) CanPerform(resource, action string) bool {
         }
     }
     return false
-}
\ No newline at end of file
+}
+
+// CanGrant reports whether u holds every permission role carries, so that
+// nobody can hand out more access than they have themselves.
+func (u *User) CanGrant(role Role) bool {
+	if u.IsSuperuser {
+		return true
+	}
+	for resource, actions := range role.Permissions {
+		for _, action := range actions {
+			if !u.CanPerform(resource, action) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CanManage reports whether u may edit, deactivate or delete other: u must be
+// able to grant every role other has.
+func (u *User) CanManage(other User) bool {
+	if other.IsSuperuser && !u.IsSuperuser {
+		return false
+	}
+	for _, role := range other.Roles {
+		if !u.CanGrant(role) {
+			return false
+		}
+	}
+	return true
+}
@@ -2,13 +2,43 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	UserStatusActive   = "active"
+	UserStatusInactive = "inactive"
+)
+
+// UserFilter narrows a listing of an organization's members. Search matches
+// name or email; Status is UserStatusActive, UserStatusInactive or empty.
+// ServiceAccounts lists service accounts instead of people.
+type UserFilter struct {
+	OrganizationID  uuid.UUID
+	Search          string
+	Email           string
+	Role            string
+	Status          string
+	ServiceAccounts bool
+	Limit           int
+	Offset          int
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
+	// GetInOrganization returns the user as a member of orgID, with the
+	// roles held there, or nil when they are not a member.
+	GetInOrganization(ctx context.Context, id, orgID uuid.UUID) (*User, error)
+	// Update saves the account itself. Roles are held per organization and
+	// only change through RoleRepository.AssignRoleToUser and RevokeUserRole.
 	Update(ctx context.Context, user *User) error
+	SetLockedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error
+	ListLocked(ctx context.Context, orgID uuid.UUID) ([]User, error)
+	ListByGuardian(ctx context.Context, guardianID uuid.UUID) ([]User, error)
+	List(ctx context.Context, filter UserFilter) ([]User, int, error)
+	SoftDelete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID) error
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+func TestUserCanManage(t *testing.T) {
+	admin := Role{Name: "admin", Permissions: map[string][]string{AllResources: {ManageAction}}}
+	teacher := Role{Name: "teacher", Permissions: map[string][]string{"user": {"read", "update"}, "event": {ManageAction}}}
+	student := Role{Name: "student", Permissions: map[string][]string{"event": {"read"}}}
+
+	tests := []struct {
+		name   string
+		caller User
+		other  User
+		want   bool
+	}{
+		{name: "Admin manages teacher", caller: User{Roles: []Role{admin}}, other: User{Roles: []Role{teacher}}, want: true},
+		{name: "Teacher manages student", caller: User{Roles: []Role{teacher}}, other: User{Roles: []Role{student}}, want: true},
+		{name: "Teacher cannot manage admin", caller: User{Roles: []Role{teacher}}, other: User{Roles: []Role{admin}}, want: false},
+		{name: "Student cannot manage teacher", caller: User{Roles: []Role{student}}, other: User{Roles: []Role{teacher}}, want: false},
+		{name: "Admin cannot manage superuser", caller: User{Roles: []Role{admin}}, other: User{IsSuperuser: true}, want: false},
+		{name: "Superuser manages superuser", caller: User{IsSuperuser: true}, other: User{IsSuperuser: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.caller.CanManage(tt.other); got != tt.want {
+				t.Errorf("CanManage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserMetadataValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		bloodType string
+		wantErr   bool
+	}{
+		{name: "Unknown", bloodType: "", wantErr: false},
+		{name: "Positive", bloodType: "AB+", wantErr: false},
+		{name: "Negative", bloodType: "O-", wantErr: false},
+		{name: "Missing rhesus", bloodType: "A", wantErr: true},
+		{name: "Garbage", bloodType: "C+", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := UserMetadata{BloodType: tt.bloodType}
+			if err := m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.bloodType, err, tt.wantErr)
+			}
+		})
+	}
+}
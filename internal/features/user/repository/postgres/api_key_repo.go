@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type APIKeyRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewAPIKeyRepository(db database.DB, log *logrus.Logger) domain.APIKeyRepository {
+	return &APIKeyRepoPostgres{db: db, log: log}
+}
+
+const apiKeyColumns = `id, organization_id, service_account_id, name, prefix, key_hash, scopes,
+		expires_at, last_used_at, revoked_at, created_by, created_at`
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (r *APIKeyRepoPostgres) Create(ctx context.Context, key *domain.APIKey) error {
+	return r.insert(ctx, r.db, key)
+}
+
+func (r *APIKeyRepoPostgres) insert(ctx context.Context, db execer, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scopes: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, query,
+		key.ID,
+		key.OrganizationID,
+		key.ServiceAccountID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		scopes,
+		key.ExpiresAt,
+		key.LastUsedAt,
+		key.RevokedAt,
+		key.CreatedBy,
+		key.CreatedAt,
+	)
+	if err != nil {
+		r.log.WithError(err).WithField("service_account_id", key.ServiceAccountID).Error("failed to insert api key")
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{"key_id": key.ID, "prefix": key.Prefix}).Info("api key created")
+	return nil
+}
+
+func (r *APIKeyRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("key_id", id).Error("failed to get api key")
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepoPostgres) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = $1`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("prefix", prefix).Error("failed to get api key")
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepoPostgres) ListByServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) ([]domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE service_account_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceAccountID)
+	if err != nil {
+		r.log.WithError(err).WithField("service_account_id", serviceAccountID).Error("failed to list api keys")
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepoPostgres) Rotate(ctx context.Context, oldID uuid.UUID, replacement *domain.APIKey) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`, oldID, time.Now())
+	if err != nil {
+		r.log.WithError(err).WithField("key_id", oldID).Error("failed to revoke rotated api key")
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("api key %s is already revoked", oldID)
+	}
+
+	if err := r.insert(ctx, tx, replacement); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepoPostgres) Revoke(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, id, time.Now()); err != nil {
+		r.log.WithError(err).WithField("key_id", id).Error("failed to revoke api key")
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	r.log.WithField("key_id", id).Info("api key revoked")
+	return nil
+}
+
+func (r *APIKeyRepoPostgres) RevokeAllForServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
+	query := `UPDATE api_keys SET revoked_at = $2 WHERE service_account_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, serviceAccountID, time.Now()); err != nil {
+		r.log.WithError(err).WithField("service_account_id", serviceAccountID).Error("failed to revoke api keys")
+		return fmt.Errorf("failed to revoke api keys: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepoPostgres) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, at); err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	var scopes []byte
+
+	err := row.Scan(
+		&key.ID,
+		&key.OrganizationID,
+		&key.ServiceAccountID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedBy,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(scopes, &key.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scopes: %w", err)
+	}
+	return key, nil
+}
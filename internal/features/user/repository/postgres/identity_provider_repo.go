@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type IdentityProviderRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewIdentityProviderRepository(db database.DB, log *logrus.Logger) domain.IdentityProviderRepository {
+	return &IdentityProviderRepoPostgres{db: db, log: log}
+}
+
+const identityProviderColumns = `id, organization_id, issuer, client_id, COALESCE(client_secret, ''),
+		default_role, auto_provision, allowed_domains, is_enabled, configured_by, created_at, updated_at`
+
+func (r *IdentityProviderRepoPostgres) get(ctx context.Context, where string, arg any) (*domain.IdentityProvider, error) {
+	query := `SELECT ` + identityProviderColumns + ` FROM organization_identity_providers WHERE ` + where
+
+	p := &domain.IdentityProvider{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&p.ID,
+		&p.OrganizationID,
+		&p.Issuer,
+		&p.ClientID,
+		&p.ClientSecret,
+		&p.DefaultRole,
+		&p.AutoProvision,
+		pq.Array(&p.AllowedDomains),
+		&p.IsEnabled,
+		&p.ConfiguredBy,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).Error("failed to get identity provider")
+		return nil, fmt.Errorf("failed to get identity provider: %w", err)
+	}
+
+	return p, nil
+}
+
+func (r *IdentityProviderRepoPostgres) GetByOrganization(ctx context.Context, orgID uuid.UUID) (*domain.IdentityProvider, error) {
+	return r.get(ctx, "organization_id = $1", orgID)
+}
+
+func (r *IdentityProviderRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.IdentityProvider, error) {
+	return r.get(ctx, "id = $1", id)
+}
+
+func (r *IdentityProviderRepoPostgres) Save(ctx context.Context, p *domain.IdentityProvider) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+		p.CreatedAt = time.Now()
+	}
+	p.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO organization_identity_providers (
+			id, organization_id, issuer, client_id, client_secret,
+			default_role, auto_provision, allowed_domains, is_enabled, configured_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			issuer = EXCLUDED.issuer,
+			client_id = EXCLUDED.client_id,
+			client_secret = COALESCE(EXCLUDED.client_secret, organization_identity_providers.client_secret),
+			default_role = EXCLUDED.default_role,
+			auto_provision = EXCLUDED.auto_provision,
+			allowed_domains = EXCLUDED.allowed_domains,
+			is_enabled = EXCLUDED.is_enabled,
+			configured_by = EXCLUDED.configured_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		p.ID,
+		p.OrganizationID,
+		p.Issuer,
+		p.ClientID,
+		p.ClientSecret,
+		p.DefaultRole,
+		p.AutoProvision,
+		pq.Array(p.AllowedDomains),
+		p.IsEnabled,
+		p.ConfiguredBy,
+		p.CreatedAt,
+		p.UpdatedAt,
+	).Scan(&p.ID, &p.CreatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", p.OrganizationID).Error("failed to save identity provider")
+		return fmt.Errorf("failed to save identity provider: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{"org_id": p.OrganizationID, "issuer": p.Issuer}).Info("identity provider saved")
+	return nil
+}
+
+func (r *IdentityProviderRepoPostgres) FindLinkedUser(ctx context.Context, providerID uuid.UUID, subject string) (*uuid.UUID, error) {
+	query := `SELECT user_id FROM user_identities WHERE provider_id = $1 AND subject = $2`
+
+	var userID uuid.UUID
+	err := r.db.QueryRowContext(ctx, query, providerID, subject).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("provider_id", providerID).Error("failed to find linked user")
+		return nil, fmt.Errorf("failed to find linked user: %w", err)
+	}
+
+	return &userID, nil
+}
+
+func (r *IdentityProviderRepoPostgres) Link(ctx context.Context, userID, providerID uuid.UUID, subject string) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider_id, subject)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider_id, subject) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, providerID, subject); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to link identity")
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return nil
+}
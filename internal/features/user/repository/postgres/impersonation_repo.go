@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ImpersonationRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewImpersonationRepository(db database.DB, log *logrus.Logger) domain.ImpersonationRepository {
+	return &ImpersonationRepoPostgres{db: db, log: log}
+}
+
+func (r *ImpersonationRepoPostgres) Create(ctx context.Context, event *domain.ImpersonationEvent) error {
+	query := `
+		INSERT INTO impersonation_events (
+			id, organization_id, actor_id, user_id, session_id, action,
+			method, path, status, reason, request_id, ip_address, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, 0), NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), $13)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.OrganizationID,
+		event.ActorID,
+		event.UserID,
+		event.SessionID,
+		event.Action,
+		event.Method,
+		event.Path,
+		event.Status,
+		event.Reason,
+		event.RequestID,
+		event.IPAddress,
+		event.CreatedAt,
+	)
+	if err != nil {
+		r.log.WithError(err).WithFields(logrus.Fields{"actor_id": event.ActorID, "user_id": event.UserID}).Error("failed to insert impersonation event")
+		return fmt.Errorf("failed to insert impersonation event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImpersonationRepoPostgres) ListByOrganization(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]domain.ImpersonationEvent, error) {
+	query := `
+		SELECT id, organization_id, actor_id, user_id, session_id, action,
+			COALESCE(method, ''), COALESCE(path, ''), COALESCE(status, 0), COALESCE(reason, ''),
+			COALESCE(request_id, ''), COALESCE(ip_address, ''), created_at
+		FROM impersonation_events
+		WHERE organization_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, limit, offset)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to list impersonation events")
+		return nil, fmt.Errorf("failed to list impersonation events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.ImpersonationEvent
+	for rows.Next() {
+		var e domain.ImpersonationEvent
+		if err := rows.Scan(
+			&e.ID, &e.OrganizationID, &e.ActorID, &e.UserID, &e.SessionID, &e.Action,
+			&e.Method, &e.Path, &e.Status, &e.Reason,
+			&e.RequestID, &e.IPAddress, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan impersonation event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating impersonation events: %w", err)
+	}
+
+	return events, nil
+}
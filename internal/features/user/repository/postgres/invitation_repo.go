@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type InvitationRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewInvitationRepository(db database.DB, log *logrus.Logger) domain.InvitationRepository {
+	return &InvitationRepoPostgres{db: db, log: log}
+}
+
+const invitationColumns = `
+	SELECT i.id, i.organization_id, o.name, i.email, i.invited_by, i.created_at, i.expires_at,
+		COALESCE(
+			(SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name, 'permissions', r.permissions))
+			 FROM roles r
+			 WHERE r.id = ANY(i.role_ids) AND r.deleted_at IS NULL),
+		'[]') AS roles_json
+	FROM membership_invitations i
+	JOIN organizations o ON o.id = i.organization_id`
+
+func (r *InvitationRepoPostgres) Save(ctx context.Context, invitation *domain.Invitation) error {
+	roleIDs := make([]uuid.UUID, len(invitation.Roles))
+	for i, role := range invitation.Roles {
+		roleIDs[i] = role.ID
+	}
+
+	query := `
+		INSERT INTO membership_invitations (id, organization_id, email, role_ids, invited_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (organization_id, lower(email)) DO UPDATE SET
+			role_ids = EXCLUDED.role_ids,
+			invited_by = EXCLUDED.invited_by,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		invitation.ID,
+		invitation.OrganizationID,
+		invitation.Email,
+		pq.Array(roleIDs),
+		invitation.InvitedBy,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	).Scan(&invitation.ID)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", invitation.OrganizationID).Error("failed to save invitation")
+		return fmt.Errorf("failed to save invitation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InvitationRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	rows, err := r.db.QueryContext(ctx, invitationColumns+` WHERE i.id = $1 AND o.deleted_at IS NULL`, id)
+	if err != nil {
+		r.log.WithError(err).WithField("invitation_id", id).Error("failed to get invitation")
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	invitations, err := scanInvitations(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return nil, nil
+	}
+	return &invitations[0], nil
+}
+
+func (r *InvitationRepoPostgres) ListByEmail(ctx context.Context, email string) ([]domain.Invitation, error) {
+	query := invitationColumns + `
+		WHERE lower(i.email) = lower($1) AND i.expires_at > now() AND o.deleted_at IS NULL
+		ORDER BY i.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, email)
+	if err != nil {
+		r.log.WithError(err).Error("failed to list invitations by email")
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	return scanInvitations(rows)
+}
+
+func (r *InvitationRepoPostgres) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.Invitation, error) {
+	query := invitationColumns + `
+		WHERE i.organization_id = $1 AND i.expires_at > now()
+		ORDER BY i.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to list invitations")
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	return scanInvitations(rows)
+}
+
+func (r *InvitationRepoPostgres) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM membership_invitations WHERE id = $1`, id); err != nil {
+		r.log.WithError(err).WithField("invitation_id", id).Error("failed to delete invitation")
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	return nil
+}
+
+func scanInvitations(rows database.Rows) ([]domain.Invitation, error) {
+	defer rows.Close()
+
+	var invitations []domain.Invitation
+	for rows.Next() {
+		var inv domain.Invitation
+		var rolesJSON []byte
+		if err := rows.Scan(
+			&inv.ID, &inv.OrganizationID, &inv.OrganizationName, &inv.Email, &inv.InvitedBy, &inv.CreatedAt, &inv.ExpiresAt,
+			&rolesJSON,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		if err := json.Unmarshal(rolesJSON, &inv.Roles); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal roles: %w", err)
+		}
+		invitations = append(invitations, inv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating invitations: %w", err)
+	}
+
+	return invitations, nil
+}
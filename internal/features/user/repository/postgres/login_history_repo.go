@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type LoginHistoryRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewLoginHistoryRepository(db database.DB, log *logrus.Logger) domain.LoginHistoryRepository {
+	return &LoginHistoryRepoPostgres{db: db, log: log}
+}
+
+func (r *LoginHistoryRepoPostgres) Create(ctx context.Context, event *domain.LoginEvent) error {
+	query := `
+		INSERT INTO login_history (id, user_id, organization_id, email, event, ip_address, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.UserID,
+		event.OrganizationID,
+		event.Email,
+		event.Event,
+		event.IPAddress,
+		event.UserAgent,
+		event.CreatedAt,
+	)
+	if err != nil {
+		r.log.WithError(err).WithField("email", event.Email).Error("failed to insert login event")
+		return fmt.Errorf("failed to insert login event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoginHistoryRepoPostgres) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.LoginEvent, error) {
+	query := `
+		SELECT id, user_id, organization_id, email, event, COALESCE(ip_address, ''), COALESCE(user_agent, ''), created_at
+		FROM login_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to list login history")
+		return nil, fmt.Errorf("failed to list login history: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.LoginEvent
+	for rows.Next() {
+		var e domain.LoginEvent
+		if err := rows.Scan(
+			&e.ID, &e.UserID, &e.OrganizationID, &e.Email, &e.Event,
+			&e.IPAddress, &e.UserAgent, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan login event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating login history: %w", err)
+	}
+
+	return events, nil
+}
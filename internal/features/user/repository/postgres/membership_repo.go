@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type MembershipRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewMembershipRepository(db database.DB, log *logrus.Logger) domain.MembershipRepository {
+	return &MembershipRepoPostgres{db: db, log: log}
+}
+
+func (r *MembershipRepoPostgres) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Membership, error) {
+	query := `
+		SELECT m.user_id, m.organization_id, o.name, o.slug, m.organization_id = u.organization_id, m.created_at,
+			COALESCE(
+				(SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name, 'permissions', r.permissions))
+				 FROM user_roles ur
+				 JOIN roles r ON ur.role_id = r.id
+				 WHERE ur.user_id = m.user_id AND ur.organization_id = m.organization_id),
+			'[]') AS roles_json
+		FROM organization_memberships m
+		JOIN users u ON u.id = m.user_id
+		JOIN organizations o ON o.id = m.organization_id
+		WHERE m.user_id = $1 AND o.deleted_at IS NULL
+		ORDER BY m.organization_id = u.organization_id DESC, o.name`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to list memberships")
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []domain.Membership
+	for rows.Next() {
+		var m domain.Membership
+		var rolesJSON []byte
+		if err := rows.Scan(
+			&m.UserID, &m.OrganizationID, &m.OrganizationName, &m.OrganizationSlug, &m.IsHome, &m.CreatedAt,
+			&rolesJSON,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %w", err)
+		}
+		if err := json.Unmarshal(rolesJSON, &m.Roles); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal roles: %w", err)
+		}
+		memberships = append(memberships, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating memberships: %w", err)
+	}
+
+	return memberships, nil
+}
+
+func (r *MembershipRepoPostgres) Add(ctx context.Context, userID, orgID uuid.UUID, roles []domain.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organization_memberships (user_id, organization_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		userID, orgID, time.Now(),
+	)
+	if err != nil {
+		r.log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "org_id": orgID}).Error("failed to add membership")
+		return fmt.Errorf("failed to add membership: %w", err)
+	}
+
+	if len(roles) > 0 {
+		roleIDs := make([]uuid.UUID, len(roles))
+		for i, role := range roles {
+			roleIDs[i] = role.ID
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO user_roles (user_id, organization_id, role_id)
+			SELECT $1, $2, unnest($3::uuid[])
+			ON CONFLICT DO NOTHING`,
+			userID, orgID, pq.Array(roleIDs),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to assign membership roles: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{"user_id": userID, "org_id": orgID}).Info("membership added")
+	return nil
+}
+
+func (r *MembershipRepoPostgres) Remove(ctx context.Context, userID, orgID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND organization_id = $2`, userID, orgID); err != nil {
+		return fmt.Errorf("failed to remove membership roles: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM organization_memberships WHERE user_id = $1 AND organization_id = $2`, userID, orgID); err != nil {
+		r.log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "org_id": orgID}).Error("failed to remove membership")
+		return fmt.Errorf("failed to remove membership: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{"user_id": userID, "org_id": orgID}).Info("membership removed")
+	return nil
+}
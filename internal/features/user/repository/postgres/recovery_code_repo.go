@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type RecoveryCodeRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewRecoveryCodeRepository(db database.DB, log *logrus.Logger) domain.RecoveryCodeRepository {
+	return &RecoveryCodeRepoPostgres{db: db, log: log}
+}
+
+func (r *RecoveryCodeRepoPostgres) ReplaceForUser(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("failed to begin transaction for recovery codes")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to clear recovery codes")
+		return fmt.Errorf("failed to clear recovery codes: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_recovery_codes (user_id, code_hash)
+		SELECT $1, unnest($2::varchar[])`
+
+	if _, err := tx.ExecContext(ctx, query, userID, pq.Array(codeHashes)); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to insert recovery codes")
+		return fmt.Errorf("failed to insert recovery codes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.WithError(err).Error("failed to commit recovery codes transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.WithField("user_id", userID).Info("recovery codes replaced")
+	return nil
+}
+
+func (r *RecoveryCodeRepoPostgres) Consume(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_recovery_codes
+		SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, userID, codeHash, time.Now())
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to consume recovery code")
+		return false, fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *RecoveryCodeRepoPostgres) DeleteForUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to delete recovery codes")
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	return nil
+}
@@ -5,39 +5,45 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type RoleRepoPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewRoleRepository(db *sql.DB, log *logrus.Logger) domain.RoleRepository {
+func NewRoleRepository(db database.DB, log *logrus.Logger) domain.RoleRepository {
 	return &RoleRepoPostgres{db: db, log: log}
 }
 
 func (r *RoleRepoPostgres) Create(ctx context.Context, role *domain.Role) error {
 	query := `
-		INSERT INTO roles (id, name, permissions, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO roles (id, organization_id, name, description, permissions, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 	permsJSON, err := json.Marshal(role.Permissions)
 	if err != nil {
 		r.log.WithError(err).WithField("role_name", role.Name).Error("failed to marshal permissions")
 		return fmt.Errorf("failed to marshal permissions: %w", err)
 	}
-	role.PrepareCreate(nil)
+	// Keep the creator the service recorded, if any.
+	role.PrepareCreate(role.CreatedBy)
 
 	_, err = r.db.ExecContext(ctx, query,
 		role.ID,
+		role.OrganizationID,
 		role.Name,
+		role.Description,
 		permsJSON,
 		role.CreatedAt,
 		role.UpdatedAt,
+		role.CreatedBy,
 	)
 
 	if err != nil {
@@ -49,23 +55,15 @@ func (r *RoleRepoPostgres) Create(ctx context.Context, role *domain.Role) error
 	return nil
 }
 
+const roleColumns = `id, organization_id, name, description, permissions, created_at, updated_at`
+
 func (r *RoleRepoPostgres) GetByName(ctx context.Context, name string) (*domain.Role, error) {
 	query := `
-        SELECT id, name, permissions, created_at, updated_at
+        SELECT ` + roleColumns + `
         FROM roles
-        WHERE name = $1 AND deleted_at IS NULL`
-
-	role := &domain.Role{}
-	var permissionsJSON []byte
-
-	err := r.db.QueryRowContext(ctx, query, name).Scan(
-		&role.ID,
-		&role.Name,
-		&permissionsJSON,
-		&role.CreatedAt,
-		&role.UpdatedAt,
-	)
+        WHERE name = $1 AND organization_id IS NULL AND deleted_at IS NULL`
 
+	role, err := scanRole(r.db.QueryRowContext(ctx, query, name))
 	if err == sql.ErrNoRows {
 		r.log.WithField("role_name", name).Debug("role not found by name")
 		return nil, nil
@@ -75,37 +73,184 @@ func (r *RoleRepoPostgres) GetByName(ctx context.Context, name string) (*domain.
 		return nil, fmt.Errorf("failed to get role: %w", err)
 	}
 
-	if len(permissionsJSON) > 0 {
-		if err := json.Unmarshal(permissionsJSON, &role.Permissions); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal permissions: %w", err)
-		}
+	return role, nil
+}
+
+func (r *RoleRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
+	query := `
+        SELECT ` + roleColumns + `
+        FROM roles
+        WHERE id = $1 AND deleted_at IS NULL`
+
+	role, err := scanRole(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		r.log.WithField("role_id", id).Debug("role not found by id")
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("role_id", id).Error("failed to get role")
+		return nil, fmt.Errorf("failed to get role: %w", err)
 	}
 
 	return role, nil
 }
 
-func (r *RoleRepoPostgres) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error {
-	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+func (r *RoleRepoPostgres) GetForOrganization(ctx context.Context, orgID uuid.UUID, name string) (*domain.Role, error) {
+	query := `
+        SELECT ` + roleColumns + `
+        FROM roles
+        WHERE lower(name) = lower($2) AND (organization_id IS NULL OR organization_id = $1) AND deleted_at IS NULL
+        ORDER BY organization_id NULLS FIRST
+        LIMIT 1`
 
-	_, err := r.db.ExecContext(ctx, query, userID, roleID)
+	role, err := scanRole(r.db.QueryRowContext(ctx, query, orgID, name))
+	if err == sql.ErrNoRows {
+		r.log.WithFields(logrus.Fields{"org_id": orgID, "role_name": name}).Debug("role not found for organization")
+		return nil, nil
+	}
 	if err != nil {
-		r.log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "role_id": roleID}).Error("failed to assign role to user")
+		r.log.WithError(err).WithFields(logrus.Fields{"org_id": orgID, "role_name": name}).Error("failed to get role")
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (r *RoleRepoPostgres) ListForOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.Role, error) {
+	query := `
+        SELECT ` + roleColumns + `
+        FROM roles
+        WHERE (organization_id IS NULL OR organization_id = $1) AND deleted_at IS NULL
+        ORDER BY organization_id NULLS FIRST, name`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to list roles")
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, *role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepoPostgres) Update(ctx context.Context, role *domain.Role) error {
+	query := `
+		UPDATE roles
+		SET name = $2, description = $3, permissions = $4, updated_at = $5, updated_by = $6
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	permsJSON, err := json.Marshal(role.Permissions)
+	if err != nil {
+		r.log.WithError(err).WithField("role_name", role.Name).Error("failed to marshal permissions")
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	role.UpdatedAt = time.Now()
+
+	res, err := r.db.ExecContext(ctx, query, role.ID, role.Name, role.Description, permsJSON, role.UpdatedAt, role.UpdatedBy)
+	if err != nil {
+		r.log.WithError(err).WithField("role_id", role.ID).Error("failed to update role")
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("role not found or already deleted")
+	}
+
+	r.log.WithField("role_id", role.ID).Info("role updated successfully")
+	return nil
+}
+
+func (r *RoleRepoPostgres) Delete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID) error {
+	query := `
+		UPDATE roles SET deleted_at = $2, deleted_by = $3, updated_at = $2
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, id, time.Now(), deletedBy)
+	if err != nil {
+		r.log.WithError(err).WithField("role_id", id).Error("failed to delete role")
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	r.log.WithField("role_id", id).Info("role deleted")
+	return nil
+}
+
+func (r *RoleRepoPostgres) CountUsers(ctx context.Context, roleID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_roles ur
+		JOIN users u ON u.id = ur.user_id
+		WHERE ur.role_id = $1 AND u.deleted_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, roleID).Scan(&count); err != nil {
+		r.log.WithError(err).WithField("role_id", roleID).Error("failed to count role members")
+		return 0, fmt.Errorf("failed to count role members: %w", err)
+	}
+	return count, nil
+}
+
+func (r *RoleRepoPostgres) AssignRoleToUser(ctx context.Context, userID, orgID, roleID uuid.UUID) error {
+	query := `INSERT INTO user_roles (user_id, organization_id, role_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query, userID, orgID, roleID)
+	if err != nil {
+		r.log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "org_id": orgID, "role_id": roleID}).Error("failed to assign role to user")
 		return fmt.Errorf("failed to assign role to user: %w", err)
 	}
 
-	r.log.WithFields(logrus.Fields{"user_id": userID, "role_id": roleID}).Info("role assigned to user")
+	r.log.WithFields(logrus.Fields{"user_id": userID, "org_id": orgID, "role_id": roleID}).Info("role assigned to user")
 	return nil
 }
 
-func (r *RoleRepoPostgres) RevokeUserRole(ctx context.Context, userID, roleID uuid.UUID) error {
-	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2`
+func (r *RoleRepoPostgres) RevokeUserRole(ctx context.Context, userID, orgID, roleID uuid.UUID) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND organization_id = $2 AND role_id = $3`
 
-	_, err := r.db.ExecContext(ctx, query, userID, roleID)
+	_, err := r.db.ExecContext(ctx, query, userID, orgID, roleID)
 	if err != nil {
-		r.log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "role_id": roleID}).Error("failed to revoke role")
+		r.log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "org_id": orgID, "role_id": roleID}).Error("failed to revoke role")
 		return fmt.Errorf("failed to revoke role: %w", err)
 	}
 
-	r.log.WithFields(logrus.Fields{"user_id": userID, "role_id": roleID}).Info("role revoked from user")
+	r.log.WithFields(logrus.Fields{"user_id": userID, "org_id": orgID, "role_id": roleID}).Info("role revoked from user")
 	return nil
 }
+
+func scanRole(row interface{ Scan(dest ...any) error }) (*domain.Role, error) {
+	role := &domain.Role{}
+	var permissionsJSON []byte
+
+	err := row.Scan(
+		&role.ID,
+		&role.OrganizationID,
+		&role.Name,
+		&role.Description,
+		&permissionsJSON,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permissionsJSON) > 0 {
+		if err := json.Unmarshal(permissionsJSON, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal permissions: %w", err)
+		}
+	}
+	return role, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type SessionRepoPostgres struct {
+	db  database.DB
+	log *logrus.Logger
+}
+
+func NewSessionRepository(db database.DB, log *logrus.Logger) domain.SessionRepository {
+	return &SessionRepoPostgres{db: db, log: log}
+}
+
+const sessionColumns = `id, user_id, organization_id, COALESCE(user_agent, ''), COALESCE(ip_address, ''),
+		created_at, last_seen_at, expires_at, revoked_at`
+
+func scanSession(row interface{ Scan(...any) error }, s *domain.Session) error {
+	return row.Scan(
+		&s.ID, &s.UserID, &s.OrganizationID, &s.UserAgent, &s.IPAddress,
+		&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt,
+	)
+}
+
+func (r *SessionRepoPostgres) Create(ctx context.Context, session *domain.Session) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id, organization_id, user_agent, ip_address, created_at, last_seen_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.OrganizationID,
+		session.UserAgent,
+		session.IPAddress,
+		session.CreatedAt,
+		session.LastSeenAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", session.UserID).Error("failed to insert session")
+		return fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM user_sessions WHERE id = $1`
+
+	var s domain.Session
+	err := scanSession(r.db.QueryRowContext(ctx, query, id), &s)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.WithError(err).WithField("session_id", id).Error("failed to get session")
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return &s, nil
+}
+
+func (r *SessionRepoPostgres) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+		ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to list sessions")
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []domain.Session
+	for rows.Next() {
+		var s domain.Session
+		if err := scanSession(rows, &s); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepoPostgres) Touch(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	query := `UPDATE user_sessions SET last_seen_at = $2, expires_at = $3 WHERE id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, id, time.Now(), expiresAt); err != nil {
+		r.log.WithError(err).WithField("session_id", id).Error("failed to touch session")
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepoPostgres) Revoke(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE user_sessions SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, id, time.Now()); err != nil {
+		r.log.WithError(err).WithField("session_id", id).Error("failed to revoke session")
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepoPostgres) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE user_sessions SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, time.Now()); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("failed to revoke user sessions")
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return nil
+}
@@ -5,20 +5,22 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 type UserRepoPostgres struct {
-	db  *sql.DB
+	db  database.DB
 	log *logrus.Logger
 }
 
-func NewUserRepo(db *sql.DB, log *logrus.Logger) domain.UserRepository {
+func NewUserRepo(db database.DB, log *logrus.Logger) domain.UserRepository {
 	return &UserRepoPostgres{db: db, log: log}
 }
 
@@ -32,6 +34,7 @@ func (r *UserRepoPostgres) Create(ctx context.Context, user *domain.User) error
 	defer tx.Rollback()
 
 	user.PrepareCreate(&user.OrganizationID)
+	user.HomeOrganizationID = user.OrganizationID
 
 	userQuery := `
         INSERT INTO users (
@@ -43,9 +46,18 @@ func (r *UserRepoPostgres) Create(ctx context.Context, user *domain.User) error
             created_at, 
             updated_at, 
             first_name, 
-            last_name
+            last_name,
+            email_verified_at,
+            guardian_id,
+            metadata,
+            is_service_account
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	metadata, err := marshalMetadata(user.Metadata)
+	if err != nil {
+		return err
+	}
 
 	_, err = tx.ExecContext(ctx, userQuery,
 		user.ID,
@@ -57,23 +69,36 @@ func (r *UserRepoPostgres) Create(ctx context.Context, user *domain.User) error
 		user.UpdatedAt,
 		user.FirstName,
 		user.LastName,
+		user.EmailVerifiedAt,
+		user.GuardianID,
+		metadata,
+		user.IsServiceAccount,
 	)
 	if err != nil {
 		r.log.WithError(err).WithField("email", user.Email).Error("failed to insert user")
 		return fmt.Errorf("failed to insert user: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO organization_memberships (user_id, organization_id, created_at) VALUES ($1, $2, $3)`,
+		user.ID, user.OrganizationID, user.CreatedAt,
+	)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", user.ID).Error("failed to add home membership")
+		return fmt.Errorf("failed to add home membership: %w", err)
+	}
+
 	if len(user.Roles) > 0 {
 		roleQuery := `
-            INSERT INTO user_roles (user_id, role_id)
-            SELECT $1, unnest($2::uuid[])`
+            INSERT INTO user_roles (user_id, organization_id, role_id)
+            SELECT $1, $2, unnest($3::uuid[])`
 
 		roleIDs := make([]uuid.UUID, len(user.Roles))
 		for i, role := range user.Roles {
 			roleIDs[i] = role.ID
 		}
 
-		_, err = tx.ExecContext(ctx, roleQuery, user.ID, pq.Array(roleIDs))
+		_, err = tx.ExecContext(ctx, roleQuery, user.ID, user.OrganizationID, pq.Array(roleIDs))
 		if err != nil {
 			r.log.WithError(err).WithField("user_id", user.ID).Error("failed to bulk assign roles")
 			return fmt.Errorf("failed to bulk assign roles: %w", err)
@@ -93,18 +118,20 @@ func (r *UserRepoPostgres) GetByEmail(ctx context.Context, email string) (*domai
 	query := `
         SELECT 
             u.id, u.organization_id, u.email, u.password_hash, u.first_name, u.last_name, 
-            u.is_superuser, u.created_at, u.updated_at,
+            u.is_superuser, u.created_at, u.updated_at, u.email_verified_at,
+            u.totp_secret, u.totp_enabled_at, u.locked_until, u.guardian_id, u.deactivated_at, u.metadata,
+            u.is_service_account,
             COALESCE(
-                (SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name))
+                (SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name, 'permissions', r.permissions))
                  FROM user_roles ur
                  JOIN roles r ON ur.role_id = r.id
-                 WHERE ur.user_id = u.id), 
+                 WHERE ur.user_id = u.id AND ur.organization_id = u.organization_id), 
             '[]') as roles_json
         FROM users u
         WHERE u.email = $1 AND u.deleted_at IS NULL`
 
 	user := &domain.User{}
-	var rolesJSON []byte
+	var rolesJSON, metadataJSON []byte
 
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
@@ -116,6 +143,14 @@ func (r *UserRepoPostgres) GetByEmail(ctx context.Context, email string) (*domai
 		&user.IsSuperuser,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.TOTPSecret,
+		&user.TOTPEnabledAt,
+		&user.LockedUntil,
+		&user.GuardianID,
+		&user.DeactivatedAt,
+		&metadataJSON,
+		&user.IsServiceAccount,
 		&rolesJSON,
 	)
 
@@ -127,34 +162,56 @@ func (r *UserRepoPostgres) GetByEmail(ctx context.Context, email string) (*domai
 		r.log.WithError(err).WithField("email", email).Error("failed to get user by email")
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
+	user.HomeOrganizationID = user.OrganizationID
 
 	if err := json.Unmarshal(rolesJSON, &user.Roles); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal roles: %w", err)
 	}
 
+	user.Metadata = &domain.UserMetadata{}
+	if err := json.Unmarshal(metadataJSON, user.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
 	return user, nil
 }
 
 func (r *UserRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.getByID(ctx, id, nil)
+}
+
+func (r *UserRepoPostgres) GetInOrganization(ctx context.Context, id, orgID uuid.UUID) (*domain.User, error) {
+	return r.getByID(ctx, id, &orgID)
+}
+
+// getByID loads the user in orgID, or in their home organization when orgID
+// is nil.
+func (r *UserRepoPostgres) getByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*domain.User, error) {
 	query := `
         SELECT 
-            u.id, u.organization_id, u.email, u.password_hash, u.first_name, u.last_name, 
-            u.is_superuser, u.created_at, u.updated_at,
+            u.id, COALESCE($2::uuid, u.organization_id), u.organization_id, u.email, u.password_hash, u.first_name, u.last_name, 
+            u.is_superuser, u.created_at, u.updated_at, u.email_verified_at,
+            u.totp_secret, u.totp_enabled_at, u.locked_until, u.guardian_id, u.deactivated_at, u.metadata,
+            u.is_service_account,
             COALESCE(
-                (SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name))
+                (SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name, 'permissions', r.permissions))
                  FROM user_roles ur
                  JOIN roles r ON ur.role_id = r.id
-                 WHERE ur.user_id = u.id), 
+                 WHERE ur.user_id = u.id AND ur.organization_id = COALESCE($2::uuid, u.organization_id)), 
             '[]') as roles_json
         FROM users u
-        WHERE u.id = $1 AND u.deleted_at IS NULL`
+        WHERE u.id = $1 AND u.deleted_at IS NULL
+          AND ($2::uuid IS NULL OR EXISTS (
+            SELECT 1 FROM organization_memberships m
+            WHERE m.user_id = u.id AND m.organization_id = $2::uuid))`
 
 	user := &domain.User{}
-	var rolesJSON []byte
+	var rolesJSON, metadataJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, orgID).Scan(
 		&user.ID,
 		&user.OrganizationID,
+		&user.HomeOrganizationID,
 		&user.Email,
 		&user.PasswordHash,
 		&user.FirstName,
@@ -162,6 +219,14 @@ func (r *UserRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.U
 		&user.IsSuperuser,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+		&user.TOTPSecret,
+		&user.TOTPEnabledAt,
+		&user.LockedUntil,
+		&user.GuardianID,
+		&user.DeactivatedAt,
+		&metadataJSON,
+		&user.IsServiceAccount,
 		&rolesJSON,
 	)
 
@@ -179,49 +244,231 @@ func (r *UserRepoPostgres) GetByID(ctx context.Context, id uuid.UUID) (*domain.U
 		return nil, fmt.Errorf("failed to unmarshal roles: %w", err)
 	}
 
+	user.Metadata = &domain.UserMetadata{}
+	if err := json.Unmarshal(metadataJSON, user.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
 	return user, nil
 }
 
 func (r *UserRepoPostgres) Update(ctx context.Context, user *domain.User) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		r.log.WithError(err).Error("failed to begin transaction for user update")
-		return fmt.Errorf("begin tx: %w", err)
-	}
-	defer tx.Rollback()
-
 	user.UpdatedAt = time.Now()
 
 	userQuery := `
         UPDATE users 
-        SET email = $2, password_hash = $3, first_name = $4, last_name = $5, is_superuser = $6, updated_at = $7
+        SET email = $2, password_hash = $3, first_name = $4, last_name = $5, is_superuser = $6, updated_at = $7,
+            email_verified_at = $8, totp_secret = $9, totp_enabled_at = $10, guardian_id = $11,
+            deactivated_at = $12, metadata = $13
         WHERE id = $1 AND deleted_at IS NULL`
 
-	_, err = tx.ExecContext(ctx, userQuery,
+	metadata, err := marshalMetadata(user.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, userQuery,
 		user.ID, user.Email, user.PasswordHash, user.FirstName, user.LastName, user.IsSuperuser, user.UpdatedAt,
+		user.EmailVerifiedAt, user.TOTPSecret, user.TOTPEnabledAt, user.GuardianID, user.DeactivatedAt, metadata,
 	)
 	if err != nil {
 		r.log.WithError(err).WithField("user_id", user.ID).Error("failed to update user")
 		return fmt.Errorf("update user: %w", err)
 	}
 
-	_, err = tx.ExecContext(ctx, "DELETE FROM user_roles WHERE user_id = $1", user.ID)
+	return nil
+}
+
+func (r *UserRepoPostgres) SetLockedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	query := `UPDATE users SET locked_until = $2, updated_at = $3 WHERE id = $1 AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, id, until, time.Now())
 	if err != nil {
-		return fmt.Errorf("clear roles: %w", err)
+		r.log.WithError(err).WithField("user_id", id).Error("failed to set user lock")
+		return fmt.Errorf("failed to set user lock: %w", err)
 	}
 
-	if len(user.Roles) > 0 {
-		roleIDs := make([]uuid.UUID, len(user.Roles))
-		for i, role := range user.Roles {
-			roleIDs[i] = role.ID
+	r.log.WithFields(logrus.Fields{"user_id": id, "locked_until": until}).Info("user lock updated")
+	return nil
+}
+
+func (r *UserRepoPostgres) ListLocked(ctx context.Context, orgID uuid.UUID) ([]domain.User, error) {
+	query := `
+        SELECT id, organization_id, email, first_name, last_name, locked_until
+        FROM users
+        WHERE organization_id = $1 AND locked_until > now() AND deleted_at IS NULL
+        ORDER BY locked_until DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", orgID).Error("failed to list locked users")
+		return nil, fmt.Errorf("failed to list locked users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.OrganizationID, &u.Email, &u.FirstName, &u.LastName, &u.LockedUntil); err != nil {
+			return nil, fmt.Errorf("failed to scan locked user: %w", err)
 		}
+		u.HomeOrganizationID = u.OrganizationID
+		users = append(users, u)
+	}
 
-		roleQuery := `INSERT INTO user_roles (user_id, role_id) SELECT $1, unnest($2::uuid[])`
-		_, err = tx.ExecContext(ctx, roleQuery, user.ID, pq.Array(roleIDs))
-		if err != nil {
-			return fmt.Errorf("sync roles: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating locked users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepoPostgres) ListByGuardian(ctx context.Context, guardianID uuid.UUID) ([]domain.User, error) {
+	query := `
+        SELECT id, organization_id, email, first_name, last_name, guardian_id
+        FROM users
+        WHERE guardian_id = $1 AND deleted_at IS NULL
+        ORDER BY first_name, last_name`
+
+	rows, err := r.db.QueryContext(ctx, query, guardianID)
+	if err != nil {
+		r.log.WithError(err).WithField("guardian_id", guardianID).Error("failed to list children")
+		return nil, fmt.Errorf("failed to list children: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.OrganizationID, &u.Email, &u.FirstName, &u.LastName, &u.GuardianID); err != nil {
+			return nil, fmt.Errorf("failed to scan child: %w", err)
 		}
+		u.HomeOrganizationID = u.OrganizationID
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating children: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepoPostgres) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	where := ` WHERE EXISTS (
+            SELECT 1 FROM organization_memberships m
+            WHERE m.user_id = u.id AND m.organization_id = $1)
+        AND u.is_service_account = $2 AND u.deleted_at IS NULL`
+	args := []interface{}{filter.OrganizationID, filter.ServiceAccounts}
+
+	if filter.Search != "" {
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		n := len(args)
+		where += fmt.Sprintf(` AND (u.email ILIKE $%d OR u.first_name ILIKE $%d OR u.last_name ILIKE $%d
+            OR (u.first_name || ' ' || u.last_name) ILIKE $%d)`, n, n, n, n)
+	}
+
+	if filter.Email != "" {
+		args = append(args, filter.Email)
+		where += fmt.Sprintf(` AND lower(u.email) = lower($%d)`, len(args))
+	}
+
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		where += fmt.Sprintf(` AND EXISTS (
+            SELECT 1 FROM user_roles ur JOIN roles r ON ur.role_id = r.id
+            WHERE ur.user_id = u.id AND ur.organization_id = $1 AND r.name = $%d)`, len(args))
 	}
 
-	return tx.Commit()
+	switch filter.Status {
+	case domain.UserStatusActive:
+		where += " AND u.deactivated_at IS NULL"
+	case domain.UserStatusInactive:
+		where += " AND u.deactivated_at IS NOT NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users u"+where, args...).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("org_id", filter.OrganizationID).Error("failed to count users")
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `
+        SELECT 
+            u.id, u.organization_id, u.email, u.first_name, u.last_name, 
+            u.is_superuser, u.created_at, u.updated_at, u.email_verified_at,
+            u.totp_enabled_at, u.locked_until, u.guardian_id, u.deactivated_at, u.is_service_account,
+            COALESCE(
+                (SELECT jsonb_agg(jsonb_build_object('id', r.id, 'name', r.name, 'permissions', r.permissions))
+                 FROM user_roles ur
+                 JOIN roles r ON ur.role_id = r.id
+                 WHERE ur.user_id = u.id AND ur.organization_id = $1), 
+            '[]') as roles_json
+        FROM users u` + where + `
+        ORDER BY u.last_name, u.first_name, u.id`
+
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.WithError(err).WithField("org_id", filter.OrganizationID).Error("failed to list users")
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		var rolesJSON []byte
+		if err := rows.Scan(
+			&u.ID, &u.HomeOrganizationID, &u.Email, &u.FirstName, &u.LastName,
+			&u.IsSuperuser, &u.CreatedAt, &u.UpdatedAt, &u.EmailVerifiedAt,
+			&u.TOTPEnabledAt, &u.LockedUntil, &u.GuardianID, &u.DeactivatedAt, &u.IsServiceAccount,
+			&rolesJSON,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		if err := json.Unmarshal(rolesJSON, &u.Roles); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal roles: %w", err)
+		}
+		u.OrganizationID = filter.OrganizationID
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+func (r *UserRepoPostgres) SoftDelete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID) error {
+	query := `
+        UPDATE users SET deleted_at = $2, deleted_by = $3, updated_at = $2
+        WHERE id = $1 AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, id, time.Now(), deletedBy)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", id).Error("failed to delete user")
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	r.log.WithFields(logrus.Fields{"user_id": id, "deleted_by": deletedBy}).Info("user deleted")
+	return nil
+}
+
+func marshalMetadata(m *domain.UserMetadata) ([]byte, error) {
+	if m == nil {
+		m = &domain.UserMetadata{}
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	return b, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
@@ -2,6 +2,7 @@ package seed
 
 import (
 	"context"
+	"slices"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
 )
@@ -27,26 +28,45 @@ func (s *RoleSeeder) SeedRoles(ctx context.Context) ([]*domain.Role, error) {
 		{
 			Name: "admin",
 			Permissions: map[string][]string{
-				"user":         {"create", "read", "update", "delete", "impersonate"},
-				"course":       {"read", "delete", "archive"},
-				"organization": {"read", "update"},
-				"report":       {"read", "export"},
-				"billing":      {"read", "refund"},
+				"user":            {"create", "read", "update", "delete", "impersonate"},
+				"role":            {"create", "read", "update", "delete"},
+				"service_account": {"create", "read", "update", "delete"},
+				"scim":            {"provision"},
+				"course":          {"read", "delete", "archive"},
+				"organization":    {"read", "update"},
+				"report":          {"read", "export"},
+				"billing":         {"read", "refund"},
+				"event":           {"create", "read", "update", "delete"},
+				"assessment":      {"read"},
+				"attachment":      {"create", "read", "delete"},
 			},
 		},
 		{
 			Name: "teacher",
 			Permissions: map[string][]string{
-				"course":  {"create", "read", "update", "delete"},
-				"content": {"upload", "organize"},
-				"student": {"grade", "view_progress"},
+				"course":     {"create", "read", "update", "delete"},
+				"content":    {"upload", "organize"},
+				"student":    {"grade", "view_progress"},
+				"event":      {"create", "read", "update"},
+				"assessment": {"create", "read", "update"},
+				"attachment": {"create", "read", "delete"},
 			},
 		},
 		{
 			Name: "student",
 			Permissions: map[string][]string{
-				"course": {"read", "enroll"},
-				"quiz":   {"take", "view_results"},
+				"course":     {"read", "enroll"},
+				"quiz":       {"take", "view_results"},
+				"event":      {"read"},
+				"assessment": {"read"},
+				"attachment": {"create", "read", "delete"},
+			},
+		},
+		{
+			Name: domain.GuardianRole,
+			Permissions: map[string][]string{
+				"child": {"read"},
+				"event": {"read"},
 			},
 		},
 	}
@@ -65,8 +85,33 @@ func (s *RoleSeeder) SeedRoles(ctx context.Context) ([]*domain.Role, error) {
 			}
 			seededRoles = append(seededRoles, role)
 		} else {
+			// Add actions introduced by new routes, but keep whatever an
+			// organization has granted on top of the defaults.
+			if mergePermissions(existing, role.Permissions) {
+				if err := s.rr.Update(ctx, existing); err != nil {
+					return nil, err
+				}
+			}
 			seededRoles = append(seededRoles, existing)
 		}
 	}
 	return seededRoles, nil
 }
+
+// mergePermissions adds the actions in defaults that role is missing and
+// reports whether anything changed. Existing grants are never removed.
+func mergePermissions(role *domain.Role, defaults map[string][]string) bool {
+	if role.Permissions == nil {
+		role.Permissions = map[string][]string{}
+	}
+	changed := false
+	for resource, actions := range defaults {
+		for _, action := range actions {
+			if !slices.Contains(role.Permissions[resource], action) {
+				role.Permissions[resource] = append(role.Permissions[resource], action)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,47 @@
+package seed
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+)
+
+func TestMergePermissions(t *testing.T) {
+	defaults := map[string][]string{"course": {"read", "update"}, "event": {"read"}}
+
+	tests := []struct {
+		name        string
+		permissions map[string][]string
+		want        map[string][]string
+		changed     bool
+	}{
+		{
+			name:        "adds missing actions and keeps custom grants",
+			permissions: map[string][]string{"course": {"read", "archive"}},
+			want:        map[string][]string{"course": {"read", "archive", "update"}, "event": {"read"}},
+			changed:     true,
+		},
+		{
+			name:        "leaves an up to date role alone",
+			permissions: map[string][]string{"course": {"update", "read"}, "event": {"read"}, "report": {"export"}},
+			want:        map[string][]string{"course": {"update", "read"}, "event": {"read"}, "report": {"export"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := &domain.Role{Permissions: tt.permissions}
+			if changed := mergePermissions(role, defaults); changed != tt.changed {
+				t.Errorf("changed = %v, want %v", changed, tt.changed)
+			}
+			for resource, actions := range tt.want {
+				if !slices.Equal(role.Permissions[resource], actions) {
+					t.Errorf("%s = %v, want %v", resource, role.Permissions[resource], actions)
+				}
+			}
+			if len(role.Permissions) != len(tt.want) {
+				t.Errorf("permissions = %v, want %v", role.Permissions, tt.want)
+			}
+		})
+	}
+}
@@ -34,6 +34,10 @@ func (s *UserSeeder) SeedUsers(ctx context.Context, orgID uuid.UUID) (map[string
 	if err != nil {
 		return nil, fmt.Errorf("failed to get student role: %w", err)
 	}
+	guardianRole, err := s.rr.GetByName(ctx, domain.GuardianRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guardian role: %w", err)
+	}
 
 	// 2. Define Users
 	usersToSeed := []struct {
@@ -69,6 +73,12 @@ func (s *UserSeeder) SeedUsers(ctx context.Context, orgID uuid.UUID) (map[string
 			Role:      studentRole,
 			Grade:     "11",
 		},
+		{
+			Email:     "parent@candletree.com",
+			FirstName: "Parent",
+			LastName:  "User",
+			Role:      guardianRole,
+		},
 	}
 
 	seededUsers := make(map[string]*domain.User)
@@ -94,6 +104,8 @@ func (s *UserSeeder) SeedUsers(ctx context.Context, orgID uuid.UUID) (map[string
 			[]domain.Role{*uVal.Role},
 		)
 
+		user.MarkEmailVerified()
+
 		if err := user.SetPassword("password"); err != nil {
 			return nil, fmt.Errorf("failed to set password for %s: %w", uVal.Email, err)
 		}
@@ -105,5 +117,51 @@ func (s *UserSeeder) SeedUsers(ctx context.Context, orgID uuid.UUID) (map[string
 		seededUsers[uVal.Email] = user
 	}
 
+	// 3. Link the seeded parent to both students
+	parent := seededUsers["parent@candletree.com"]
+	for _, email := range []string{"student10@candletree.com", "student11@candletree.com"} {
+		child := seededUsers[email]
+		if child.GuardianID != nil {
+			continue
+		}
+		child.GuardianID = &parent.ID
+		if err := s.ur.Update(ctx, child); err != nil {
+			return nil, fmt.Errorf("failed to link %s to guardian: %w", email, err)
+		}
+	}
+
 	return seededUsers, nil
 }
+
+// SeedSystemAdmin creates the superuser of the system organization, who can
+// use the cross-tenant administration API.
+func (s *UserSeeder) SeedSystemAdmin(ctx context.Context, systemOrgID uuid.UUID) (*domain.User, error) {
+	const email = "sysadmin@chimera.local"
+
+	existing, err := s.ur.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing user %s: %w", email, err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	role, err := s.rr.GetByName(ctx, "superadmin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get superadmin role: %w", err)
+	}
+
+	user := domain.NewUser(email, "System", "Administrator", systemOrgID, []domain.Role{*role})
+	user.IsSuperuser = true
+	user.MarkEmailVerified()
+
+	if err := user.SetPassword("password"); err != nil {
+		return nil, fmt.Errorf("failed to set password for %s: %w", email, err)
+	}
+
+	if err := s.ur.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user %s: %w", email, err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeyTouchInterval limits how often a busy key's last use is written.
+const apiKeyTouchInterval = time.Minute
+
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	ErrInvalidAPIKeyInput     = errors.New("invalid api key")
+)
+
+type serviceAccountService struct {
+	userRepo   domain.UserRepository
+	apiKeyRepo domain.APIKeyRepository
+	log        *logrus.Logger
+}
+
+func NewServiceAccountService(ur domain.UserRepository, kr domain.APIKeyRepository, log *logrus.Logger) ServiceAccounts {
+	return &serviceAccountService{
+		userRepo:   ur,
+		apiKeyRepo: kr,
+		log:        log,
+	}
+}
+
+func (s *serviceAccountService) CreateServiceAccount(ctx context.Context, name string) (*domain.User, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: service account name is required", ErrInvalidAPIKeyInput)
+	}
+
+	account := domain.NewUser("", name, "", orgID, nil)
+	account.ID = uuid.New()
+	// Email is unique and required; this address can never receive mail.
+	account.Email = fmt.Sprintf("sa-%s@service-accounts.invalid", account.ID)
+	account.IsServiceAccount = true
+	account.MarkEmailVerified()
+	if err := account.SetRandomPassword(); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Create(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{"service_account_id": account.ID, "org_id": orgID}).Info("service account created")
+	return account, nil
+}
+
+func (s *serviceAccountService) ListServiceAccounts(ctx context.Context, filter *domain.UserFilter) ([]domain.User, int, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, 0, errors.New("organization id not found")
+	}
+
+	filter.OrganizationID = orgID
+	filter.ServiceAccounts = true
+	clampPage(filter)
+
+	return s.userRepo.List(ctx, *filter)
+}
+
+func (s *serviceAccountService) DeleteServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
+	account, err := s.getServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.apiKeyRepo.RevokeAllForServiceAccount(ctx, account.ID); err != nil {
+		return err
+	}
+
+	var deletedBy *uuid.UUID
+	if callerID, ok := auth.GetUserID(ctx); ok {
+		deletedBy = &callerID
+	}
+	return s.userRepo.SoftDelete(ctx, account.ID, deletedBy)
+}
+
+func (s *serviceAccountService) CreateKey(ctx context.Context, serviceAccountID uuid.UUID, input APIKeyInput) (*domain.APIKey, string, error) {
+	account, err := s.getServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	caller, err := currentUser(ctx, s.userRepo)
+	if err != nil {
+		return nil, "", err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidAPIKeyInput)
+	}
+	if len(input.Scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKeyInput)
+	}
+	if err := domain.ValidatePermissions(input.Scopes); err != nil {
+		return nil, "", err
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, "", fmt.Errorf("%w: expiry must be in the future", ErrInvalidAPIKeyInput)
+	}
+	if !caller.CanGrant(domain.Role{Permissions: input.Scopes}) {
+		return nil, "", ErrInsufficientPrivileges
+	}
+
+	key, plaintext, err := domain.NewAPIKey(account, name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key.CreatedBy = &caller.ID
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+func (s *serviceAccountService) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]domain.APIKey, error) {
+	account, err := s.getServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.apiKeyRepo.ListByServiceAccount(ctx, account.ID)
+}
+
+// RotateKey replaces a key with a new one that has the same name, scopes
+// and expiry. The old key stops working immediately.
+func (s *serviceAccountService) RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (*domain.APIKey, string, error) {
+	account, key, err := s.getKey(ctx, serviceAccountID, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !key.IsUsable(time.Now()) {
+		return nil, "", fmt.Errorf("%w: revoked or expired keys cannot be rotated", ErrInvalidAPIKeyInput)
+	}
+
+	caller, err := currentUser(ctx, s.userRepo)
+	if err != nil {
+		return nil, "", err
+	}
+	if !caller.CanGrant(domain.Role{Permissions: key.Scopes}) {
+		return nil, "", ErrInsufficientPrivileges
+	}
+
+	replacement, plaintext, err := domain.NewAPIKey(account, key.Name, key.Scopes, key.ExpiresAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	replacement.CreatedBy = &caller.ID
+
+	if err := s.apiKeyRepo.Rotate(ctx, key.ID, replacement); err != nil {
+		return nil, "", err
+	}
+
+	s.log.WithFields(logrus.Fields{"old_key_id": key.ID, "key_id": replacement.ID}).Info("api key rotated")
+	return replacement, plaintext, nil
+}
+
+func (s *serviceAccountService) RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	_, key, err := s.getKey(ctx, serviceAccountID, keyID)
+	if err != nil {
+		return err
+	}
+	return s.apiKeyRepo.Revoke(ctx, key.ID)
+}
+
+func (s *serviceAccountService) Authenticate(ctx context.Context, plaintext string) (*auth.APIKeyPrincipal, error) {
+	// The key names its organization; nothing is known before it is found.
+	ctx = database.Unscoped(ctx)
+
+	prefix, ok := domain.APIKeyLookup(plaintext)
+	if !ok {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(domain.HashAPIKey(plaintext))) != 1 {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	account, err := s.userRepo.GetByID(ctx, key.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !account.IsServiceAccount || !account.IsActive() || account.OrganizationID != key.OrganizationID {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			s.log.WithError(err).WithField("key_id", key.ID).Warn("failed to record api key use")
+		}
+	}
+
+	return &auth.APIKeyPrincipal{
+		KeyID:          key.ID,
+		UserID:         account.ID,
+		OrganizationID: account.OrganizationID,
+		Scopes:         key.Scopes,
+	}, nil
+}
+
+func (s *serviceAccountService) getServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) (*domain.User, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	account, err := s.userRepo.GetByID(ctx, serviceAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+	if account == nil || !account.IsServiceAccount || account.OrganizationID != orgID {
+		return nil, ErrServiceAccountNotFound
+	}
+	return account, nil
+}
+
+func (s *serviceAccountService) getKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (*domain.User, *domain.APIKey, error) {
+	account, err := s.getServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if key == nil || key.ServiceAccountID != account.ID {
+		return nil, nil, ErrAPIKeyNotFound
+	}
+	return account, key, nil
+}
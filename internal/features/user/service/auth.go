@@ -4,31 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	ErrEmailNotVerified   = errors.New("email address has not been verified")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountDeactivated = errors.New("account has been deactivated")
+	ErrInvalidProfileName = errors.New("first name cannot be empty")
+)
+
 type authService struct {
 	userRepo      domain.UserRepository
 	roleRepo      domain.RoleRepository
 	tokenProvider auth.TokenProvider
+	verifier      EmailVerification
+	twoFactor     TwoFactor
+	loginGuard    LoginProtection
+	sessions      Sessions
 	log           *logrus.Logger
 }
 
-func NewAuthService(ur domain.UserRepository, rr domain.RoleRepository, tp auth.TokenProvider, log *logrus.Logger) Auth {
+func NewAuthService(ur domain.UserRepository, rr domain.RoleRepository, tp auth.TokenProvider, v EmailVerification, tf TwoFactor, lg LoginProtection, ss Sessions, log *logrus.Logger) Auth {
 	return &authService{
 		userRepo:      ur,
 		roleRepo:      rr,
 		tokenProvider: tp,
+		verifier:      v,
+		twoFactor:     tf,
+		loginGuard:    lg,
+		sessions:      ss,
 		log:           log,
 	}
 }
 
-func (s *authService) register(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID, roleName string) (*domain.User, error) {
+func (s *authService) register(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID, roleName string, verified bool) (*domain.User, error) {
 	role, err := s.roleRepo.GetByName(ctx, roleName)
 	if err != nil {
 		s.log.WithError(err).WithField("role_name", roleName).Error("failed to get role during registration")
@@ -40,6 +57,9 @@ func (s *authService) register(ctx context.Context, email, password, firstName,
 	}
 
 	user := domain.NewUser(email, firstName, lastName, orgID, []domain.Role{*role})
+	if verified {
+		user.MarkEmailVerified()
+	}
 
 	if err := user.SetPassword(password); err != nil {
 		return nil, err
@@ -51,45 +71,165 @@ func (s *authService) register(ctx context.Context, email, password, firstName,
 	}
 
 	s.log.WithFields(logrus.Fields{"user_id": user.ID, "email": email, "role": roleName}).Info("user registered successfully")
+
+	if !user.IsEmailVerified() {
+		// The account exists either way; a failed mail can be resent later.
+		if err := s.verifier.SendVerification(ctx, user); err != nil {
+			s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to send verification email after registration")
+		}
+	}
+
 	return user, nil
 }
 
 func (s *authService) RegisterStudent(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error) {
-	return s.register(ctx, email, password, firstName, lastName, orgID, "student")
+	return s.register(ctx, email, password, firstName, lastName, orgID, "student", false)
 }
 
 func (s *authService) RegisterTeacher(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error) {
-	return s.register(ctx, email, password, firstName, lastName, orgID, "teacher")
+	return s.register(ctx, email, password, firstName, lastName, orgID, "teacher", true)
 }
 
 func (s *authService) RegisterAdmin(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error) {
-	return s.register(ctx, email, password, firstName, lastName, orgID, "admin")
+	return s.register(ctx, email, password, firstName, lastName, orgID, "admin", true)
+}
+
+func (s *authService) RegisterGuardian(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error) {
+	return s.register(ctx, email, password, firstName, lastName, orgID, domain.GuardianRole, false)
 }
 
-func (s *authService) Login(ctx context.Context, email, password string) (string, error) {
+func (s *authService) Login(ctx context.Context, email, password string, client ClientInfo) (*LoginResult, error) {
+	// The account is found by email wherever it lives, before there is an
+	// organization to scope to.
+	ctx = database.Unscoped(ctx)
+
+	if err := s.loginGuard.Check(ctx, email, client); err != nil {
+		s.log.WithError(err).WithFields(logrus.Fields{"email": email, "ip": client.IP}).Warn("login throttled")
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, email)
-	if user == nil || err != nil || !user.CheckPassword(password) {
-		s.log.WithField("email", email).Warn("login failed: invalid email or password")
-		return "", errors.New("invalid email or password")
+	if err != nil {
+		user = nil
+	}
+
+	// Service accounts only ever authenticate with API keys.
+	if user == nil || user.IsServiceAccount || !user.CheckPassword(password) {
+		s.log.WithFields(logrus.Fields{"email": email, "ip": client.IP}).Warn("login failed: invalid email or password")
+		if err := s.loginGuard.RecordFailure(ctx, email, client, user); err != nil {
+			var blocked *LoginBlockedError
+			if errors.As(err, &blocked) {
+				return nil, err
+			}
+			s.log.WithError(err).WithField("email", email).Error("failed to record login failure")
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	// The lock is only revealed to someone who knows the password, so a
+	// locked account answers wrong guesses exactly like an unknown email.
+	if user.IsLocked(time.Now()) {
+		s.log.WithField("user_id", user.ID).Warn("login refused: account locked")
+		return nil, &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: time.Until(*user.LockedUntil)}
 	}
 
-	token, err := s.tokenProvider.GenerateToken(user.ID, user.OrganizationID)
+	if err := s.loginGuard.RecordSuccess(ctx, email, client, user); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to reset login failures")
+	}
+
+	if !user.IsActive() {
+		s.log.WithField("user_id", user.ID).Warn("login refused: account deactivated")
+		return nil, ErrAccountDeactivated
+	}
+
+	if !user.IsEmailVerified() {
+		s.log.WithField("user_id", user.ID).Warn("login refused: email not verified")
+		return nil, ErrEmailNotVerified
+	}
+
+	// At an organization's own address the session starts in that
+	// organization, which may be one the user belongs to as a guest.
+	if tenantID, ok := auth.GetTenantID(ctx); ok && tenantID != user.OrganizationID {
+		member, err := s.userRepo.GetInOrganization(ctx, user.ID, tenantID)
+		if err != nil {
+			s.log.WithError(err).WithField("user_id", user.ID).Error("failed to load membership of addressed organization")
+			return nil, errors.New("failed to complete login")
+		}
+		if member == nil {
+			s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": tenantID}).Warn("login refused: not a member of the addressed organization")
+			return nil, ErrInvalidCredentials
+		}
+		user = member
+	}
+
+	challenge, enrollmentRequired, err := s.twoFactor.ChallengeIfRequired(ctx, user)
 	if err != nil {
-		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to generate access token")
-		return "", errors.New("failed to generate access token")
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to evaluate two-factor requirement")
+		return nil, errors.New("failed to complete login")
+	}
+	if challenge != "" {
+		return &LoginResult{ChallengeToken: challenge, EnrollmentRequired: enrollmentRequired}, nil
+	}
+
+	tokens, err := s.sessions.Start(ctx, user, client)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationSuspended) {
+			return nil, err
+		}
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to generate tokens")
+		return nil, errors.New("failed to generate access token")
 	}
 
 	s.log.WithField("user_id", user.ID).Info("user logged in successfully")
-	return token, nil
+	return &LoginResult{Tokens: tokens}, nil
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	tokens, err := s.sessions.Rotate(ctx, refreshToken)
+	if errors.Is(err, auth.ErrRefreshTokenReused) {
+		s.log.Warn("refresh token reuse detected, token family revoked")
+		return nil, err
+	}
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		s.log.Warn("refresh failed: invalid or expired refresh token")
+		return nil, err
+	}
+	if errors.Is(err, ErrOrganizationSuspended) {
+		s.log.Warn("refresh refused: organization suspended")
+		return nil, err
+	}
+	if err != nil {
+		s.log.WithError(err).Error("failed to rotate refresh token")
+		return nil, errors.New("failed to refresh access token")
+	}
+
+	return tokens, nil
 }
 
-func (s *authService) Logout(ctx context.Context, token string) error {
-	err := s.tokenProvider.BlacklistToken(ctx, token, 15*time.Minute)
+func (s *authService) Logout(ctx context.Context, token, refreshToken string) error {
+	claims, err := s.tokenProvider.ValidateToken(token)
 	if err != nil {
+		s.log.WithError(err).Warn("logout with invalid access token")
+		return errors.New("could not invalidate session")
+	}
+
+	if err := s.tokenProvider.BlacklistToken(ctx, claims); err != nil {
 		s.log.WithError(err).Error("failed to blacklist token during logout")
 		return errors.New("could not invalidate session")
 	}
 
+	if err := s.sessions.Revoke(ctx, claims.UserID, claims.SessionID); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		s.log.WithError(err).WithField("session_id", claims.SessionID).Error("failed to revoke session during logout")
+		return errors.New("could not invalidate session")
+	}
+
+	if refreshToken != "" {
+		if err := s.tokenProvider.RevokeRefreshToken(ctx, refreshToken); err != nil && !errors.Is(err, auth.ErrInvalidRefreshToken) {
+			s.log.WithError(err).Error("failed to revoke refresh token during logout")
+			return errors.New("could not invalidate session")
+		}
+	}
+
 	s.log.Info("user logged out successfully")
 	return nil
 }
@@ -101,7 +241,13 @@ func (s *authService) Me(ctx context.Context) (*domain.User, error) {
 		return nil, errors.New("failed getting user id")
 	}
 
-	user, err := s.userRepo.GetByID(ctx, user_id)
+	var user *domain.User
+	var err error
+	if orgID, ok := auth.GetOrgID(ctx); ok {
+		user, err = s.userRepo.GetInOrganization(ctx, user_id, orgID)
+	} else {
+		user, err = s.userRepo.GetByID(ctx, user_id)
+	}
 	if err != nil {
 		s.log.WithError(err).WithField("user_id", user_id).Error("failed to get user by id")
 		return nil, errors.New("user does not exists")
@@ -109,3 +255,45 @@ func (s *authService) Me(ctx context.Context) (*domain.User, error) {
 
 	return user, nil
 }
+
+func (s *authService) UpdateMe(ctx context.Context, update ProfileUpdate) (*domain.User, error) {
+	user, err := s.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if update.FirstName != nil {
+		name := strings.TrimSpace(*update.FirstName)
+		if name == "" {
+			return nil, ErrInvalidProfileName
+		}
+		user.FirstName = name
+	}
+	if update.LastName != nil {
+		user.LastName = strings.TrimSpace(*update.LastName)
+	}
+
+	if user.Metadata == nil {
+		user.Metadata = &domain.UserMetadata{}
+	}
+	if update.Address != nil {
+		user.Metadata.Address = strings.TrimSpace(*update.Address)
+	}
+	if update.BloodType != nil {
+		user.Metadata.BloodType = strings.ToUpper(strings.TrimSpace(*update.BloodType))
+	}
+	if err := user.Metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to update profile")
+		return nil, errors.New("failed to update profile")
+	}
+
+	s.log.WithField("user_id", user.ID).Info("user updated own profile")
+	return user, nil
+}
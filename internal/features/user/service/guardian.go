@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNotGuardian     = errors.New("user is not a guardian")
+	ErrChildNotFound   = errors.New("child not found")
+	ErrInvalidGuardian = errors.New("a guardian cannot be linked as a child")
+)
+
+type guardianService struct {
+	userRepo domain.UserRepository
+	log      *logrus.Logger
+}
+
+func NewGuardianService(ur domain.UserRepository, log *logrus.Logger) Guardianship {
+	return &guardianService{
+		userRepo: ur,
+		log:      log,
+	}
+}
+
+func (s *guardianService) LinkChild(ctx context.Context, guardianID, childID uuid.UUID) error {
+	if guardianID == childID {
+		return ErrInvalidGuardian
+	}
+
+	guardian, err := s.getOrgUser(ctx, guardianID)
+	if err != nil {
+		return err
+	}
+	if !guardian.HasAnyRole(domain.GuardianRole) {
+		return ErrNotGuardian
+	}
+
+	child, err := s.getOrgUser(ctx, childID)
+	if err != nil {
+		return err
+	}
+	if child.HasAnyRole(domain.GuardianRole) {
+		return ErrInvalidGuardian
+	}
+	// The link is stored on the account, which only its own organization
+	// may change.
+	if child.IsGuest() {
+		return ErrManagedElsewhere
+	}
+
+	child.GuardianID = &guardian.ID
+	if err := s.userRepo.Update(ctx, child); err != nil {
+		return fmt.Errorf("failed to link child: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{"guardian_id": guardianID, "child_id": childID}).Info("child linked to guardian")
+	return nil
+}
+
+func (s *guardianService) UnlinkChild(ctx context.Context, childID uuid.UUID) error {
+	child, err := s.getOrgUser(ctx, childID)
+	if err != nil {
+		return err
+	}
+	if child.GuardianID == nil {
+		return nil
+	}
+	if child.IsGuest() {
+		return ErrManagedElsewhere
+	}
+
+	child.GuardianID = nil
+	if err := s.userRepo.Update(ctx, child); err != nil {
+		return fmt.Errorf("failed to unlink child: %w", err)
+	}
+
+	s.log.WithField("child_id", childID).Info("child unlinked from guardian")
+	return nil
+}
+
+func (s *guardianService) ListChildren(ctx context.Context, guardianID uuid.UUID) ([]domain.User, error) {
+	if _, err := s.getOrgUser(ctx, guardianID); err != nil {
+		return nil, err
+	}
+	return s.userRepo.ListByGuardian(ctx, guardianID)
+}
+
+// GetChild returns the child only when it is linked to the guardian, so
+// callers can use it as the access check for anything shown about a child.
+func (s *guardianService) GetChild(ctx context.Context, guardianID, childID uuid.UUID) (*domain.User, error) {
+	guardian, err := s.getOrgUser(ctx, guardianID)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := s.getOrgUser(ctx, childID)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, ErrChildNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !child.IsChildOf(*guardian) {
+		s.log.WithFields(logrus.Fields{"guardian_id": guardianID, "child_id": childID}).Warn("guardian requested a child that is not linked")
+		return nil, ErrChildNotFound
+	}
+	return child, nil
+}
+
+func (s *guardianService) getOrgUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	user, err := s.userRepo.GetInOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func TestLinkChild(t *testing.T) {
+	guardianRole := domain.Role{Name: domain.GuardianRole}
+	student := domain.Role{Name: "student"}
+
+	orgID := uuid.New()
+	// membersRepo holds each user as loaded in orgID, so the roles are the
+	// ones held there rather than in the home organization.
+	newUser := func(homeOrgID uuid.UUID, roles ...domain.Role) *domain.User {
+		u := &domain.User{Roles: roles, HomeOrganizationID: homeOrgID}
+		u.ID = uuid.New()
+		u.OrganizationID = orgID
+		return u
+	}
+	guardian := newUser(orgID, guardianRole)
+	guestGuardian := newUser(uuid.New(), guardianRole)
+	child := newUser(orgID, student)
+	guestChild := newUser(uuid.New(), student)
+
+	tests := []struct {
+		name     string
+		guardian *domain.User
+		child    *domain.User
+		wantErr  error
+	}{
+		{"Member guardian", guardian, child, nil},
+		{"Guardian here through a membership", guestGuardian, child, nil},
+		{"Student is not a guardian", child, guardian, ErrNotGuardian},
+		{"Guest child belongs to its home organization", guardian, guestChild, ErrManagedElsewhere},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &verifiedRepo{membersRepo: membersRepo{users: map[uuid.UUID]*domain.User{
+				tt.guardian.ID: tt.guardian,
+				tt.child.ID:    tt.child,
+			}}}
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+			s := NewGuardianService(repo, log)
+
+			ctx := context.WithValue(context.Background(), auth.OrgIDKey, orgID)
+			if err := s.LinkChild(ctx, tt.guardian.ID, tt.child.ID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LinkChild() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantUpdated := tt.wantErr == nil; (len(repo.updated) == 1) != wantUpdated {
+				t.Errorf("updated = %v, want update %v", repo.updated, wantUpdated)
+			}
+		})
+	}
+}
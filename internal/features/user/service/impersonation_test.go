@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+)
+
+func TestImpersonationStartRefusedSessions(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     any
+		wantErr error
+	}{
+		{"API key", auth.APIKeyIDKey, ErrImpersonationNotAllowed},
+		{"Impersonation token", auth.ActorIDKey, ErrAlreadyImpersonating},
+	}
+
+	// Both are refused before anything is loaded, so the service needs no
+	// dependencies.
+	s := &impersonationService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), tt.key, uuid.New())
+			ctx = context.WithValue(ctx, auth.UserIDKey, uuid.New())
+			ctx = context.WithValue(ctx, auth.OrgIDKey, uuid.New())
+			if _, err := s.Start(ctx, uuid.New(), "support", ClientInfo{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Start() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
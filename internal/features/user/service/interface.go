@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/scim"
 	"github.com/google/uuid"
 )
 
@@ -11,7 +14,210 @@ type Auth interface {
     RegisterStudent(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error)
 	RegisterTeacher(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error)
 	RegisterAdmin(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error)
-    Login(ctx context.Context, email, password string) (string, error)
-	Logout(ctx context.Context, token string) (error)
+	RegisterGuardian(ctx context.Context, email, password, firstName, lastName string, orgID uuid.UUID) (*domain.User, error)
+    Login(ctx context.Context, email, password string, client ClientInfo) (*LoginResult, error)
+	Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error)
+	Logout(ctx context.Context, token, refreshToken string) (error)
 	Me(ctx context.Context) (*domain.User, error)
-}
\ No newline at end of file
+	UpdateMe(ctx context.Context, update ProfileUpdate) (*domain.User, error)
+}
+
+// ProfileUpdate holds the fields users may change on their own account. Nil
+// fields are left as they are.
+type ProfileUpdate struct {
+	FirstName *string
+	LastName  *string
+	Address   *string
+	BloodType *string
+}
+
+// LoginResult carries either the issued tokens or, when a second factor is
+// needed, the challenge token to present to the two-factor endpoints.
+type LoginResult struct {
+	Tokens             *auth.TokenPair
+	ChallengeToken     string
+	EnrollmentRequired bool
+}
+
+// ClientInfo identifies where a login attempt came from.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type PasswordRecovery interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	// ChangePassword keeps the session identified by currentSessionID and
+	// revokes every other one.
+	ChangePassword(ctx context.Context, userID, currentSessionID uuid.UUID, currentPassword, newPassword string) error
+}
+
+type EmailVerification interface {
+	SendVerification(ctx context.Context, user *domain.User) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
+	MarkVerified(ctx context.Context, userID uuid.UUID) error
+}
+
+type TwoFactor interface {
+	ChallengeIfRequired(ctx context.Context, user *domain.User) (challengeToken string, enrollmentRequired bool, err error)
+	VerifyChallenge(ctx context.Context, challengeToken, code string, client ClientInfo) (*auth.TokenPair, error)
+	BeginChallengeEnrollment(ctx context.Context, challengeToken string) (*TOTPEnrollment, error)
+	ConfirmChallengeEnrollment(ctx context.Context, challengeToken, code string, client ClientInfo) (*auth.TokenPair, []string, error)
+
+	BeginEnrollment(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+
+	GetPolicy(ctx context.Context) ([]string, error)
+	UpdatePolicy(ctx context.Context, roleNames []string) error
+}
+
+type LoginProtection interface {
+	Check(ctx context.Context, email string, client ClientInfo) error
+	RecordFailure(ctx context.Context, email string, client ClientInfo, user *domain.User) error
+	RecordSuccess(ctx context.Context, email string, client ClientInfo, user *domain.User) error
+
+	ListLocked(ctx context.Context) ([]domain.User, error)
+	Unlock(ctx context.Context, userID uuid.UUID) error
+	History(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.LoginEvent, error)
+}
+
+type Sessions interface {
+	Start(ctx context.Context, user *domain.User, client ClientInfo) (*auth.TokenPair, error)
+	Rotate(ctx context.Context, refreshToken string) (*auth.TokenPair, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeOthers(ctx context.Context, userID, currentSessionID uuid.UUID) (int, error)
+	RevokeAll(ctx context.Context, userID uuid.UUID) error
+}
+
+type SingleSignOn interface {
+	Begin(ctx context.Context, orgSlug, loginHint string) (authorizationURL string, err error)
+	Complete(ctx context.Context, state, code string, client ClientInfo) (*LoginResult, error)
+
+	GetProvider(ctx context.Context) (*domain.IdentityProvider, error)
+	SaveProvider(ctx context.Context, provider *domain.IdentityProvider) error
+}
+
+type Guardianship interface {
+	LinkChild(ctx context.Context, guardianID, childID uuid.UUID) error
+	UnlinkChild(ctx context.Context, childID uuid.UUID) error
+	ListChildren(ctx context.Context, guardianID uuid.UUID) ([]domain.User, error)
+	GetChild(ctx context.Context, guardianID, childID uuid.UUID) (*domain.User, error)
+}
+
+// UserProfileUpdate holds the profile fields an admin may change; nil
+// fields are left as they are.
+type UserProfileUpdate struct {
+	Email     *string
+	FirstName *string
+	LastName  *string
+}
+
+type UserManagement interface {
+	// List fills in the organization and page defaults on filter before
+	// querying, so callers can echo the effective paging back.
+	List(ctx context.Context, filter *domain.UserFilter) ([]domain.User, int, error)
+	Get(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, update UserProfileUpdate) (*domain.User, error)
+	Activate(ctx context.Context, userID uuid.UUID) error
+	Deactivate(ctx context.Context, userID uuid.UUID) error
+	AssignRole(ctx context.Context, userID uuid.UUID, roleName string) (*domain.User, error)
+	RevokeRole(ctx context.Context, userID uuid.UUID, roleName string) (*domain.User, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// InviteMember offers the account registered under email the named
+	// roles in the caller's organization. It becomes a member only once its
+	// owner accepts.
+	InviteMember(ctx context.Context, email string, roleNames []string) (*domain.Invitation, error)
+	ListInvitations(ctx context.Context) ([]domain.Invitation, error)
+	RevokeInvitation(ctx context.Context, invitationID uuid.UUID) error
+	RemoveMember(ctx context.Context, userID uuid.UUID) error
+}
+
+// Memberships lets users see the organizations they belong to and move
+// between them.
+type Memberships interface {
+	List(ctx context.Context) ([]domain.Membership, error)
+	// Switch starts a session for the caller in orgID, or a two-factor
+	// challenge when that organization requires one.
+	Switch(ctx context.Context, orgID uuid.UUID, client ClientInfo) (*LoginResult, error)
+	// ListInvitations returns the pending invitations for the caller's email.
+	ListInvitations(ctx context.Context) ([]domain.Invitation, error)
+	AcceptInvitation(ctx context.Context, invitationID uuid.UUID) error
+	DeclineInvitation(ctx context.Context, invitationID uuid.UUID) error
+}
+
+// RoleInput describes a custom role. Permissions replace the role's current
+// ones entirely.
+type RoleInput struct {
+	Name        string
+	Description string
+	Permissions map[string][]string
+}
+
+type RoleManagement interface {
+	Catalogue() []domain.PermissionResource
+	List(ctx context.Context) ([]domain.Role, error)
+	Get(ctx context.Context, roleID uuid.UUID) (*domain.Role, error)
+	Create(ctx context.Context, input RoleInput) (*domain.Role, error)
+	Update(ctx context.Context, roleID uuid.UUID, input RoleInput) (*domain.Role, error)
+	Delete(ctx context.Context, roleID uuid.UUID) error
+}
+
+type Impersonation interface {
+	// Start issues a short-lived access token for userID that carries the
+	// caller as its actor.
+	Start(ctx context.Context, userID uuid.UUID, reason string, client ClientInfo) (*auth.TokenPair, error)
+	History(ctx context.Context, limit, offset int) ([]domain.ImpersonationEvent, error)
+}
+
+// APIKeyInput describes a new API key. Scopes use the same resource/action
+// shape as role permissions and must not exceed the caller's own access.
+type APIKeyInput struct {
+	Name      string
+	Scopes    map[string][]string
+	ExpiresAt *time.Time
+}
+
+type ServiceAccounts interface {
+	auth.APIKeyAuthenticator
+
+	CreateServiceAccount(ctx context.Context, name string) (*domain.User, error)
+	ListServiceAccounts(ctx context.Context, filter *domain.UserFilter) ([]domain.User, int, error)
+	DeleteServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error
+
+	// CreateKey and RotateKey return the plaintext key, which is only ever
+	// available in that response.
+	CreateKey(ctx context.Context, serviceAccountID uuid.UUID, input APIKeyInput) (*domain.APIKey, string, error)
+	ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]domain.APIKey, error)
+	RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (*domain.APIKey, string, error)
+	RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error
+}
+
+// Provisioning serves SCIM 2.0 for an identity provider. Users are the
+// organization's members and groups are the roles they can hold. Client
+// errors are returned as *scim.Error.
+type Provisioning interface {
+	ListUsers(ctx context.Context, filter string, startIndex, count int) (*scim.ListResponse, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*scim.User, error)
+	CreateUser(ctx context.Context, user *scim.User) (*scim.User, error)
+	ReplaceUser(ctx context.Context, userID uuid.UUID, user *scim.User) (*scim.User, error)
+	PatchUser(ctx context.Context, userID uuid.UUID, ops []scim.Operation) (*scim.User, error)
+	// DeactivateUser answers DELETE without removing the account.
+	DeactivateUser(ctx context.Context, userID uuid.UUID) error
+
+	ListGroups(ctx context.Context, filter string, startIndex, count int) (*scim.ListResponse, error)
+	GetGroup(ctx context.Context, roleID uuid.UUID) (*scim.Group, error)
+	CreateGroup(ctx context.Context, group *scim.Group) (*scim.Group, error)
+	ReplaceGroup(ctx context.Context, roleID uuid.UUID, group *scim.Group) (*scim.Group, error)
+	PatchGroup(ctx context.Context, roleID uuid.UUID, ops []scim.Operation) (*scim.Group, error)
+	DeleteGroup(ctx context.Context, roleID uuid.UUID) error
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrTooManyAttempts = errors.New("too many login attempts, try again later")
+	ErrAccountLocked   = errors.New("account is temporarily locked")
+)
+
+// LoginBlockedError is returned when a login is refused because of throttling
+// or a lockout. RetryAfter tells the client how long to wait.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string { return e.Err.Error() }
+func (e *LoginBlockedError) Unwrap() error { return e.Err }
+
+// LoginPolicy holds the brute-force thresholds. Failures are counted per
+// email and per IP inside a sliding Window.
+type LoginPolicy struct {
+	MaxAttempts   int
+	IPMaxAttempts int
+	FreeAttempts  int
+	Window        time.Duration
+	LockoutPeriod time.Duration
+	BackoffBase   time.Duration
+	BackoffMax    time.Duration
+}
+
+type loginGuard struct {
+	userRepo    domain.UserRepository
+	historyRepo domain.LoginHistoryRepository
+	redis       *redis.Client
+	policy      LoginPolicy
+	log         *logrus.Logger
+}
+
+func NewLoginGuard(ur domain.UserRepository, lhr domain.LoginHistoryRepository, redisClient *redis.Client, policy LoginPolicy, log *logrus.Logger) LoginProtection {
+	return &loginGuard{
+		userRepo:    ur,
+		historyRepo: lhr,
+		redis:       redisClient,
+		policy:      policy,
+		log:         log,
+	}
+}
+
+func emailFailKey(email string) string    { return "login_fail:email:" + normaliseEmail(email) }
+func ipFailKey(ip string) string          { return "login_fail:ip:" + ip }
+func emailBackoffKey(email string) string { return "login_backoff:email:" + normaliseEmail(email) }
+
+func normaliseEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *loginGuard) Check(ctx context.Context, email string, client ClientInfo) error {
+	ttl, err := s.redis.PTTL(ctx, emailBackoffKey(email)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read login backoff: %w", err)
+	}
+	if ttl > 0 {
+		s.record(ctx, domain.LoginThrottled, email, client, nil)
+		return &LoginBlockedError{Err: ErrTooManyAttempts, RetryAfter: ttl}
+	}
+
+	if client.IP == "" || s.policy.IPMaxAttempts <= 0 {
+		return nil
+	}
+
+	count, err := s.redis.Get(ctx, ipFailKey(client.IP)).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to read ip login failures: %w", err)
+	}
+	if count >= s.policy.IPMaxAttempts {
+		ttl, err := s.redis.PTTL(ctx, ipFailKey(client.IP)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read ip login failures: %w", err)
+		}
+		s.record(ctx, domain.LoginThrottled, email, client, nil)
+		return &LoginBlockedError{Err: ErrTooManyAttempts, RetryAfter: ttl}
+	}
+
+	return nil
+}
+
+func (s *loginGuard) RecordFailure(ctx context.Context, email string, client ClientInfo, user *domain.User) error {
+	pipe := s.redis.TxPipeline()
+	emailCount := pipe.Incr(ctx, emailFailKey(email))
+	pipe.Expire(ctx, emailFailKey(email), s.policy.Window)
+	if client.IP != "" {
+		pipe.Incr(ctx, ipFailKey(client.IP))
+		pipe.Expire(ctx, ipFailKey(client.IP), s.policy.Window)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	s.record(ctx, domain.LoginFailed, email, client, user)
+
+	failures := int(emailCount.Val())
+	if failures >= s.policy.MaxAttempts {
+		if user == nil {
+			return s.lockUnknown(ctx, email)
+		}
+		return s.lock(ctx, email, client, user)
+	}
+
+	if backoff := domain.LoginBackoff(failures, s.policy.FreeAttempts, s.policy.BackoffBase, s.policy.BackoffMax); backoff > 0 {
+		if err := s.redis.Set(ctx, emailBackoffKey(email), 1, backoff).Err(); err != nil {
+			return fmt.Errorf("failed to set login backoff: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *loginGuard) lock(ctx context.Context, email string, client ClientInfo, user *domain.User) error {
+	until := time.Now().Add(s.policy.LockoutPeriod)
+	if err := s.userRepo.SetLockedUntil(ctx, user.ID, &until); err != nil {
+		return err
+	}
+	// The lock itself now gates logins; start counting afresh once it lapses.
+	if err := s.redis.Del(ctx, emailFailKey(email), emailBackoffKey(email)).Err(); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to clear login counters after lockout")
+	}
+
+	user.LockedUntil = &until
+	s.record(ctx, domain.AccountLocked, email, client, user)
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "locked_until": until}).Warn("account locked after repeated login failures")
+
+	return &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: s.policy.LockoutPeriod}
+}
+
+// lockUnknown answers like lock for an email with no account behind it, so
+// the lockout cannot be used to find out which addresses are registered.
+func (s *loginGuard) lockUnknown(ctx context.Context, email string) error {
+	if err := s.redis.Del(ctx, emailFailKey(email), emailBackoffKey(email)).Err(); err != nil {
+		s.log.WithError(err).WithField("email", email).Warn("failed to clear login counters after lockout")
+	}
+	return &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: s.policy.LockoutPeriod}
+}
+
+func (s *loginGuard) RecordSuccess(ctx context.Context, email string, client ClientInfo, user *domain.User) error {
+	if err := s.redis.Del(ctx, emailFailKey(email), emailBackoffKey(email)).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	s.record(ctx, domain.LoginSucceeded, email, client, user)
+	return nil
+}
+
+func (s *loginGuard) ListLocked(ctx context.Context) ([]domain.User, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	return s.userRepo.ListLocked(ctx, orgID)
+}
+
+func (s *loginGuard) Unlock(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.getOrgUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SetLockedUntil(ctx, user.ID, nil); err != nil {
+		return err
+	}
+	if err := s.redis.Del(ctx, emailFailKey(user.Email), emailBackoffKey(user.Email)).Err(); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to clear login counters on unlock")
+	}
+
+	s.record(ctx, domain.AccountUnlocked, user.Email, ClientInfo{}, user)
+	s.log.WithField("user_id", user.ID).Info("account unlocked")
+	return nil
+}
+
+func (s *loginGuard) History(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.LoginEvent, error) {
+	if _, err := s.getOrgUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.historyRepo.ListByUser(ctx, userID, limit, offset)
+}
+
+// record writes a login history entry. History is best effort and never
+// fails the login it describes.
+func (s *loginGuard) record(ctx context.Context, event domain.LoginEventType, email string, client ClientInfo, user *domain.User) {
+	e := domain.NewLoginEvent(event, normaliseEmail(email), client.IP, client.UserAgent, user)
+	if err := s.historyRepo.Create(ctx, e); err != nil {
+		s.log.WithError(err).WithField("event", event).Warn("failed to record login event")
+	}
+}
+
+func (s *loginGuard) getOrgUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || user.OrganizationID != orgID {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type lockingRepo struct{ domain.UserRepository }
+
+func (lockingRepo) SetLockedUntil(ctx context.Context, id uuid.UUID, until *time.Time) error {
+	return nil
+}
+
+type discardHistory struct{ domain.LoginHistoryRepository }
+
+func (discardHistory) Create(ctx context.Context, e *domain.LoginEvent) error { return nil }
+
+// Unknown emails must hit the same lockout as real accounts, or the lockout
+// tells an attacker which addresses are registered.
+func TestRecordFailureLockout(t *testing.T) {
+	known := &domain.User{}
+	known.ID = uuid.New()
+
+	tests := []struct {
+		name string
+		user *domain.User
+	}{
+		{"Registered email", known},
+		{"Unknown email", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+			policy := LoginPolicy{MaxAttempts: 3, FreeAttempts: 10, Window: time.Hour, LockoutPeriod: 15 * time.Minute}
+			g := NewLoginGuard(lockingRepo{}, discardHistory{}, redis.NewClient(&redis.Options{Addr: mr.Addr()}), policy, log)
+
+			var err error
+			for i := 0; i < policy.MaxAttempts; i++ {
+				err = g.RecordFailure(context.Background(), "someone@example.com", ClientInfo{}, tt.user)
+			}
+
+			var blocked *LoginBlockedError
+			if !errors.As(err, &blocked) || !errors.Is(err, ErrAccountLocked) || blocked.RetryAfter != policy.LockoutPeriod {
+				t.Fatalf("RecordFailure() error = %v, want a %s lockout", err, policy.LockoutPeriod)
+			}
+			if mr.Exists(emailFailKey("someone@example.com")) {
+				t.Error("failure counter not reset after lockout")
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNotAMember       = errors.New("you are not a member of this organization")
+	ErrSwitchNotAllowed = errors.New("this session cannot switch organizations")
+	// ErrInvitationNotAllowed is returned to API keys and impersonators,
+	// since an invitation is for the person who owns the account.
+	ErrInvitationNotAllowed = errors.New("this session cannot act on invitations")
+)
+
+type membershipService struct {
+	membershipRepo domain.MembershipRepository
+	invitationRepo domain.InvitationRepository
+	userRepo       domain.UserRepository
+	twoFactor      TwoFactor
+	sessions       Sessions
+	log            *logrus.Logger
+}
+
+func NewMembershipService(mr domain.MembershipRepository, ir domain.InvitationRepository, ur domain.UserRepository, tf TwoFactor, ss Sessions, log *logrus.Logger) Memberships {
+	return &membershipService{
+		membershipRepo: mr,
+		invitationRepo: ir,
+		userRepo:       ur,
+		twoFactor:      tf,
+		sessions:       ss,
+		log:            log,
+	}
+}
+
+// List covers every organization of the user, not just the current one.
+func (s *membershipService) List(ctx context.Context) ([]domain.Membership, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, errors.New("user id not found")
+	}
+	return s.membershipRepo.ListByUser(database.Unscoped(ctx), userID)
+}
+
+// Switch issues a fresh token pair scoped to orgID. The current session is
+// left alone so the client can keep both. When orgID requires a second
+// factor the result is a challenge, completed the same way as at login.
+func (s *membershipService) Switch(ctx context.Context, orgID uuid.UUID, client ClientInfo) (*LoginResult, error) {
+	// API keys and impersonation tokens are bound to one organization and
+	// must not turn into a full session.
+	if _, ok := auth.GetAPIKeyID(ctx); ok {
+		return nil, ErrSwitchNotAllowed
+	}
+	if _, ok := auth.GetActorID(ctx); ok {
+		return nil, ErrSwitchNotAllowed
+	}
+
+	// The listing leaves out organizations that have been deleted.
+	memberships, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	member := false
+	for _, m := range memberships {
+		if m.OrganizationID == orgID {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return nil, ErrNotAMember
+	}
+
+	userID, _ := auth.GetUserID(ctx)
+	user, err := s.userRepo.GetInOrganization(database.Unscoped(ctx), userID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrNotAMember
+	}
+	if user.IsServiceAccount {
+		return nil, ErrSwitchNotAllowed
+	}
+	if !user.IsActive() {
+		return nil, ErrAccountDeactivated
+	}
+
+	challenge, enrollmentRequired, err := s.twoFactor.ChallengeIfRequired(database.Unscoped(ctx), user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate two-factor requirement: %w", err)
+	}
+	if challenge != "" {
+		s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": orgID}).Info("organization switch awaits two-factor challenge")
+		return &LoginResult{ChallengeToken: challenge, EnrollmentRequired: enrollmentRequired}, nil
+	}
+
+	tokens, err := s.sessions.Start(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": orgID, "session_id": tokens.SessionID}).Info("user switched organization")
+	return &LoginResult{Tokens: tokens}, nil
+}
+
+// Invitations are addressed to an email rather than to an organization's
+// account, so they are read and answered unscoped.
+func (s *membershipService) ListInvitations(ctx context.Context) ([]domain.Invitation, error) {
+	user, err := s.invitee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.invitationRepo.ListByEmail(database.Unscoped(ctx), user.Email)
+}
+
+func (s *membershipService) AcceptInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	user, invitation, err := s.invitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+
+	ctx = database.Unscoped(ctx)
+	if err := s.membershipRepo.Add(ctx, user.ID, invitation.OrganizationID, invitation.Roles); err != nil {
+		return err
+	}
+	if err := s.invitationRepo.Delete(ctx, invitation.ID); err != nil {
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": invitation.OrganizationID}).Info("invitation accepted")
+	return nil
+}
+
+func (s *membershipService) DeclineInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	user, invitation, err := s.invitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.invitationRepo.Delete(database.Unscoped(ctx), invitation.ID); err != nil {
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": invitation.OrganizationID}).Info("invitation declined")
+	return nil
+}
+
+// invitation returns the caller's account and the invitation, provided it is
+// still pending and addressed to the caller's email.
+func (s *membershipService) invitation(ctx context.Context, invitationID uuid.UUID) (*domain.User, *domain.Invitation, error) {
+	user, err := s.invitee(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invitation, err := s.invitationRepo.GetByID(database.Unscoped(ctx), invitationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invitation == nil || !strings.EqualFold(invitation.Email, user.Email) || invitation.IsExpired(time.Now()) {
+		return nil, nil, ErrInvitationNotFound
+	}
+	return user, invitation, nil
+}
+
+func (s *membershipService) invitee(ctx context.Context) (*domain.User, error) {
+	if _, ok := auth.GetAPIKeyID(ctx); ok {
+		return nil, ErrInvitationNotAllowed
+	}
+	if _, ok := auth.GetActorID(ctx); ok {
+		return nil, ErrInvitationNotAllowed
+	}
+
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, errors.New("user id not found")
+	}
+	user, err := s.userRepo.GetByID(database.Unscoped(ctx), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
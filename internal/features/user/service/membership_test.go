@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type memberOf struct {
+	domain.MembershipRepository
+	orgID uuid.UUID
+}
+
+func (r memberOf) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Membership, error) {
+	return []domain.Membership{{OrganizationID: r.orgID}}, nil
+}
+
+type fixedChallenge struct {
+	TwoFactor
+	token string
+}
+
+func (f fixedChallenge) ChallengeIfRequired(ctx context.Context, user *domain.User) (string, bool, error) {
+	return f.token, false, nil
+}
+
+type countingSessions struct {
+	Sessions
+	started int
+}
+
+func (s *countingSessions) Start(ctx context.Context, user *domain.User, client ClientInfo) (*auth.TokenPair, error) {
+	s.started++
+	return &auth.TokenPair{AccessToken: "access"}, nil
+}
+
+// Switching into an organization that requires a second factor must not
+// hand out tokens before the challenge is passed.
+func TestSwitchTwoFactor(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+	}{
+		{"Organization requires a second factor", "challenge"},
+		{"No second factor required", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgID := uuid.New()
+			user := &domain.User{}
+			user.ID = uuid.New()
+			user.OrganizationID = orgID
+
+			sessions := &countingSessions{}
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+			s := NewMembershipService(
+				memberOf{orgID: orgID},
+				nil,
+				membersRepo{users: map[uuid.UUID]*domain.User{user.ID: user}},
+				fixedChallenge{token: tt.challenge},
+				sessions,
+				log,
+			)
+
+			ctx := context.WithValue(context.Background(), auth.UserIDKey, user.ID)
+			result, err := s.Switch(ctx, orgID, ClientInfo{})
+			if err != nil {
+				t.Fatalf("Switch() error = %v", err)
+			}
+			if result.ChallengeToken != tt.challenge {
+				t.Errorf("ChallengeToken = %q, want %q", result.ChallengeToken, tt.challenge)
+			}
+			if wantTokens := tt.challenge == ""; (result.Tokens != nil) != wantTokens || (sessions.started == 1) != wantTokens {
+				t.Errorf("tokens = %v, sessions started = %d, want tokens %v", result.Tokens, sessions.started, wantTokens)
+			}
+		})
+	}
+}
+
+type account struct {
+	domain.UserRepository
+	user *domain.User
+}
+
+func (r account) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.user, nil
+}
+
+type addedMemberships struct {
+	domain.MembershipRepository
+	added []uuid.UUID
+}
+
+func (r *addedMemberships) Add(ctx context.Context, userID, orgID uuid.UUID, roles []domain.Role) error {
+	r.added = append(r.added, orgID)
+	return nil
+}
+
+type oneInvitation struct {
+	domain.InvitationRepository
+	invitation *domain.Invitation
+	deleted    bool
+}
+
+func (r *oneInvitation) GetByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	return r.invitation, nil
+}
+
+func (r *oneInvitation) Delete(ctx context.Context, id uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		expiresIn time.Duration
+		key       any
+		wantErr   error
+	}{
+		{"Invitation for the caller", "Pat@Example.com", time.Hour, nil, nil},
+		{"Invitation for someone else", "someone@example.com", time.Hour, nil, ErrInvitationNotFound},
+		{"Expired invitation", "pat@example.com", -time.Minute, nil, ErrInvitationNotFound},
+		{"API key", "pat@example.com", time.Hour, auth.APIKeyIDKey, ErrInvitationNotAllowed},
+		{"Impersonation token", "pat@example.com", time.Hour, auth.ActorIDKey, ErrInvitationNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &domain.User{Email: "pat@example.com"}
+			user.ID = uuid.New()
+			orgID := uuid.New()
+
+			memberships := &addedMemberships{}
+			invitations := &oneInvitation{invitation: &domain.Invitation{
+				ID:             uuid.New(),
+				OrganizationID: orgID,
+				Email:          tt.email,
+				ExpiresAt:      time.Now().Add(tt.expiresIn),
+			}}
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+			s := NewMembershipService(memberships, invitations, account{user: user}, nil, nil, log)
+
+			ctx := context.WithValue(context.Background(), auth.UserIDKey, user.ID)
+			if tt.key != nil {
+				ctx = context.WithValue(ctx, tt.key, uuid.New())
+			}
+			if err := s.AcceptInvitation(ctx, invitations.invitation.ID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AcceptInvitation() error = %v, want %v", err, tt.wantErr)
+			}
+
+			accepted := tt.wantErr == nil
+			if (len(memberships.added) == 1 && memberships.added[0] == orgID) != accepted || invitations.deleted != accepted {
+				t.Errorf("memberships added = %v, invitation deleted = %v, want accepted %v", memberships.added, invitations.deleted, accepted)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/mailer"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+)
+
+type passwordService struct {
+	userRepo    domain.UserRepository
+	sessions    Sessions
+	mailer      mailer.Mailer
+	redis       *redis.Client
+	resetURL    string
+	resetExpiry time.Duration
+	log         *logrus.Logger
+}
+
+func NewPasswordService(
+	ur domain.UserRepository,
+	ss Sessions,
+	m mailer.Mailer,
+	redis *redis.Client,
+	resetURL string,
+	resetExpiry time.Duration,
+	log *logrus.Logger,
+) PasswordRecovery {
+	return &passwordService{
+		userRepo:    ur,
+		sessions:    ss,
+		mailer:      m,
+		redis:       redis,
+		resetURL:    resetURL,
+		resetExpiry: resetExpiry,
+		log:         log,
+	}
+}
+
+// Reset tokens are single use: Redis stores only their digest, and ResetPassword
+// consumes the key with GETDEL before touching the user.
+func passwordResetKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "password_reset:" + hex.EncodeToString(sum[:])
+}
+
+func (s *passwordService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(database.Unscoped(ctx), email)
+	if err != nil {
+		s.log.WithError(err).WithField("email", email).Error("failed to look up user for password reset")
+		return errors.New("failed to start password reset")
+	}
+	if user == nil || user.IsServiceAccount {
+		// Do not reveal whether the email is registered.
+		s.log.WithField("email", email).Info("password reset requested for unknown email")
+		return nil
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	if err := s.redis.Set(ctx, passwordResetKey(token), user.ID.String(), s.resetExpiry).Err(); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to store password reset token")
+		return errors.New("failed to start password reset")
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to choose a new password. It expires in %d minutes.\n\n%s?token=%s\n\nIf you did not ask for this, you can ignore this email.\n",
+			user.FirstName, int(s.resetExpiry.Minutes()), s.resetURL, token,
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to send password reset mail")
+		return errors.New("failed to send password reset email")
+	}
+
+	s.log.WithField("user_id", user.ID).Info("password reset requested")
+	return nil
+}
+
+func (s *passwordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if err := domain.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	val, err := s.redis.GetDel(ctx, passwordResetKey(token)).Result()
+	if err == redis.Nil {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		s.log.WithError(err).Error("failed to load password reset token")
+		return errors.New("failed to reset password")
+	}
+
+	userID, err := uuid.Parse(val)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		s.log.WithField("user_id", userID).Warn("password reset for missing user")
+		return ErrInvalidResetToken
+	}
+
+	if err := user.SetPassword(newPassword); err != nil {
+		return err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to save new password")
+		return errors.New("failed to reset password")
+	}
+
+	if err := s.sessions.RevokeAll(ctx, user.ID); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to revoke tokens after password reset")
+		return errors.New("password changed but existing sessions could not be revoked")
+	}
+
+	s.log.WithField("user_id", userID).Info("password reset successfully")
+	return nil
+}
+
+func (s *passwordService) ChangePassword(ctx context.Context, userID, currentSessionID uuid.UUID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to get user for password change")
+		return errors.New("failed to change password")
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if !user.CheckPassword(currentPassword) {
+		s.log.WithField("user_id", userID).Warn("password change with wrong current password")
+		return ErrIncorrectPassword
+	}
+	if err := domain.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	if err := user.SetPassword(newPassword); err != nil {
+		return err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to save new password")
+		return errors.New("failed to change password")
+	}
+
+	// Anyone holding the old password may already be signed in elsewhere.
+	if _, err := s.sessions.RevokeOthers(ctx, userID, currentSessionID); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to revoke sessions after password change")
+		return errors.New("password changed but other sessions could not be revoked")
+	}
+
+	s.log.WithField("user_id", userID).Info("password changed")
+	return nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const maxRoleNameLength = 64
+
+var (
+	ErrBuiltInRole   = errors.New("built-in roles cannot be changed")
+	ErrRoleNameTaken = errors.New("a role with this name already exists")
+	ErrRoleInUse     = errors.New("role is still assigned to users")
+	ErrInvalidRole   = errors.New("invalid role")
+)
+
+type roleService struct {
+	roleRepo domain.RoleRepository
+	userRepo domain.UserRepository
+	log      *logrus.Logger
+}
+
+func NewRoleService(rr domain.RoleRepository, ur domain.UserRepository, log *logrus.Logger) RoleManagement {
+	return &roleService{
+		roleRepo: rr,
+		userRepo: ur,
+		log:      log,
+	}
+}
+
+func (s *roleService) Catalogue() []domain.PermissionResource {
+	return domain.PermissionCatalogue
+}
+
+func (s *roleService) List(ctx context.Context) ([]domain.Role, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+	return s.roleRepo.ListForOrganization(ctx, orgID)
+}
+
+func (s *roleService) Get(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil || (!role.IsBuiltIn() && *role.OrganizationID != orgID) {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (s *roleService) Create(ctx context.Context, input RoleInput) (*domain.Role, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &domain.Role{OrganizationID: &orgID}
+	if err := s.apply(ctx, caller, role, input); err != nil {
+		return nil, err
+	}
+
+	role.CreatedBy = &caller.ID
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"role_id": role.ID, "org_id": orgID, "role_name": role.Name}).Info("custom role created")
+	return role, nil
+}
+
+func (s *roleService) Update(ctx context.Context, roleID uuid.UUID, input RoleInput) (*domain.Role, error) {
+	role, err := s.customRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Holders of the role keep it after the change, so the caller must be
+	// able to grant what it carried before as well as what it will carry.
+	if !caller.CanGrant(*role) {
+		return nil, ErrInsufficientPrivileges
+	}
+
+	if err := s.apply(ctx, caller, role, input); err != nil {
+		return nil, err
+	}
+
+	role.UpdatedBy = &caller.ID
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"role_id": role.ID, "role_name": role.Name}).Info("custom role updated")
+	return role, nil
+}
+
+func (s *roleService) Delete(ctx context.Context, roleID uuid.UUID) error {
+	role, err := s.customRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return err
+	}
+	if !caller.CanGrant(*role) {
+		return ErrInsufficientPrivileges
+	}
+
+	members, err := s.roleRepo.CountUsers(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+	if members > 0 {
+		return fmt.Errorf("%w: %d users", ErrRoleInUse, members)
+	}
+
+	return s.roleRepo.Delete(ctx, role.ID, &caller.ID)
+}
+
+// apply validates input and copies it onto role. Name clashes are checked
+// against the built-in roles and the organization's own.
+func (s *roleService) apply(ctx context.Context, caller *domain.User, role *domain.Role, input RoleInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxRoleNameLength {
+		return fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidRole, maxRoleNameLength)
+	}
+
+	permissions := input.Permissions
+	if permissions == nil {
+		permissions = map[string][]string{}
+	}
+	if err := domain.ValidatePermissions(permissions); err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(name, role.Name) {
+		existing, err := s.roleRepo.GetForOrganization(ctx, *role.OrganizationID, name)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ID != role.ID {
+			return ErrRoleNameTaken
+		}
+	}
+
+	role.Name = name
+	role.Description = strings.TrimSpace(input.Description)
+	role.Permissions = permissions
+
+	if !caller.CanGrant(*role) {
+		return ErrInsufficientPrivileges
+	}
+	return nil
+}
+
+// customRole returns one of the caller's organization's own roles.
+func (s *roleService) customRole(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
+	role, err := s.Get(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role.IsBuiltIn() {
+		return nil, ErrBuiltInRole
+	}
+	return role, nil
+}
+
+func (s *roleService) caller(ctx context.Context) (*domain.User, error) {
+	return currentUser(ctx, s.userRepo)
+}
@@ -0,0 +1,936 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/scim"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	SCIMDefaultPageSize = 100
+	scimMaxPageSize     = 500
+	scimScanBatch       = 500
+)
+
+type scimService struct {
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	membershipRepo domain.MembershipRepository
+	sessions       Sessions
+	log            *logrus.Logger
+}
+
+func NewSCIMService(ur domain.UserRepository, rr domain.RoleRepository, mr domain.MembershipRepository, ss Sessions, log *logrus.Logger) Provisioning {
+	return &scimService{
+		userRepo:       ur,
+		roleRepo:       rr,
+		membershipRepo: mr,
+		sessions:       ss,
+		log:            log,
+	}
+}
+
+func scimNotFound(resource string) *scim.Error {
+	return scim.NewError(http.StatusNotFound, "", resource+" not found")
+}
+
+func (s *scimService) ListUsers(ctx context.Context, filter string, startIndex, count int) (*scim.ListResponse, error) {
+	f, err := parseSCIMFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listing everyone and looking up a userName, which is what clients
+	// send before every create, are answered by the database a page at a
+	// time. Other filters are rare and matched in memory.
+	attribute, value, eq := scim.Equality(f)
+	switch {
+	case f == nil, eq && attribute == "username":
+		startIndex, count = scimPageBounds(startIndex, count)
+		page, total, err := s.userRepo.List(ctx, domain.UserFilter{
+			OrganizationID: orgID,
+			Email:          value,
+			Limit:          count,
+			Offset:         startIndex - 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resources := make([]any, 0, len(page))
+		for i := range page {
+			resources = append(resources, toSCIMUser(&page[i]))
+		}
+		return scim.NewListResponse(resources, total, startIndex), nil
+	case eq && attribute == "externalid":
+		// External ids are not stored, so none can match.
+		return scimPage(nil, startIndex, count), nil
+	}
+
+	users, err := s.members(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []any
+	for i := range users {
+		user := toSCIMUser(&users[i])
+		if f.Match(user.Attributes()) {
+			matched = append(matched, user)
+		}
+	}
+	return scimPage(matched, startIndex, count), nil
+}
+
+func (s *scimService) GetUser(ctx context.Context, id uuid.UUID) (*scim.User, error) {
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user), nil
+}
+
+// CreateUser provisions an account owned by the organization. An address
+// already in use is a conflict, even when the account belongs to another
+// organization: a directory may not pull in accounts it does not own, and
+// admins invite those through POST /users/members instead.
+func (s *scimService) CreateUser(ctx context.Context, in *scim.User) (*scim.User, error) {
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ch userChanges
+	if err := ch.fromResource(in); err != nil {
+		return nil, err
+	}
+	if ch.email == nil {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "userName is required")
+	}
+	email, err := scimEmail(*ch.email)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.userRepo.GetByEmail(database.Unscoped(ctx), email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.OrganizationID != orgID {
+			s.log.WithFields(logrus.Fields{"user_id": existing.ID, "org_id": orgID}).Info("scim create refused for an account of another organization")
+		}
+		return nil, scim.NewError(http.StatusConflict, scim.ErrUniqueness, "userName is already taken")
+	}
+
+	var firstName, lastName string
+	if ch.givenName != nil {
+		firstName = strings.TrimSpace(*ch.givenName)
+	}
+	if ch.familyName != nil {
+		lastName = strings.TrimSpace(*ch.familyName)
+	}
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(strings.TrimSpace(in.DisplayName), " ")
+	}
+	if firstName == "" {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "name.givenName is required")
+	}
+
+	user := domain.NewUser(email, firstName, lastName, orgID, nil)
+	// The identity provider owns the address, and users sign in through it.
+	user.MarkEmailVerified()
+	if err := user.SetRandomPassword(); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+	if ch.active != nil && !*ch.active {
+		if err := s.applyUserChanges(ctx, user, userChanges{active: ch.active}); err != nil {
+			return nil, err
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": orgID}).Info("user provisioned via scim")
+	return toSCIMUser(user), nil
+}
+
+func (s *scimService) ReplaceUser(ctx context.Context, id uuid.UUID, in *scim.User) (*scim.User, error) {
+	user, err := s.editableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var ch userChanges
+	if err := ch.fromResource(in); err != nil {
+		return nil, err
+	}
+	if err := s.applyUserChanges(ctx, user, ch); err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user), nil
+}
+
+func (s *scimService) PatchUser(ctx context.Context, id uuid.UUID, ops []scim.Operation) (*scim.User, error) {
+	user, err := s.editableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var ch userChanges
+	for _, op := range ops {
+		if err := ch.fromOperation(op); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.applyUserChanges(ctx, user, ch); err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user), nil
+}
+
+// DeactivateUser answers DELETE. Accounts are deactivated rather than
+// removed so their coursework and history stay intact; guests only lose
+// their membership.
+func (s *scimService) DeactivateUser(ctx context.Context, id uuid.UUID) error {
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return err
+	}
+	grantor, err := s.grantor(ctx)
+	if err != nil {
+		return err
+	}
+	if !grantor.CanManage(*user) {
+		return scim.NewError(http.StatusForbidden, "", ErrInsufficientPrivileges.Error())
+	}
+
+	if user.IsGuest() {
+		if err := s.membershipRepo.Remove(ctx, user.ID, user.OrganizationID); err != nil {
+			return err
+		}
+		s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": user.OrganizationID}).Info("guest removed via scim")
+		return nil
+	}
+
+	inactive := false
+	return s.applyUserChanges(ctx, user, userChanges{active: &inactive})
+}
+
+func (s *scimService) ListGroups(ctx context.Context, filter string, startIndex, count int) (*scim.ListResponse, error) {
+	f, err := parseSCIMFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.ListForOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.members(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []any
+	for i := range roles {
+		group := toSCIMGroup(&roles[i], users)
+		if f == nil || f.Match(group.Attributes()) {
+			matched = append(matched, group)
+		}
+	}
+	return scimPage(matched, startIndex, count), nil
+}
+
+func (s *scimService) GetGroup(ctx context.Context, id uuid.UUID) (*scim.Group, error) {
+	role, users, err := s.group(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMGroup(role, users), nil
+}
+
+// CreateGroup adds a custom role without permissions; administrators decide
+// what it grants.
+func (s *scimService) CreateGroup(ctx context.Context, in *scim.Group) (*scim.Group, error) {
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(in.DisplayName)
+	if name == "" {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "displayName is required")
+	}
+	if err := s.checkGroupName(ctx, orgID, name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	role := &domain.Role{
+		OrganizationID: &orgID,
+		Name:           name,
+		Description:    "Provisioned by the identity provider",
+		Permissions:    map[string][]string{},
+	}
+	if callerID, ok := auth.GetUserID(ctx); ok {
+		role.CreatedBy = &callerID
+	}
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+	s.log.WithFields(logrus.Fields{"role_id": role.ID, "org_id": orgID}).Info("group provisioned via scim")
+
+	if len(in.Members) > 0 {
+		if err := s.setMembers(ctx, role, nil, memberIDs(in.Members)); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetGroup(ctx, role.ID)
+}
+
+func (s *scimService) ReplaceGroup(ctx context.Context, id uuid.UUID, in *scim.Group) (*scim.Group, error) {
+	role, users, err := s.group(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(in.DisplayName); name != "" && name != role.Name {
+		if err := s.renameGroup(ctx, role, name); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.setMembers(ctx, role, roleMembers(role, users), memberIDs(in.Members)); err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, role.ID)
+}
+
+func (s *scimService) PatchGroup(ctx context.Context, id uuid.UUID, ops []scim.Operation) (*scim.Group, error) {
+	role, users, err := s.group(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	current := roleMembers(role, users)
+	members := make(map[uuid.UUID]string, len(current))
+	for userID, name := range current {
+		members[userID] = name
+	}
+
+	for _, op := range ops {
+		name, err := patchGroupMembers(op, members)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" && name != role.Name {
+			if err := s.renameGroup(ctx, role, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	wanted := make([]uuid.UUID, 0, len(members))
+	for userID := range members {
+		wanted = append(wanted, userID)
+	}
+	if err := s.setMembers(ctx, role, current, wanted); err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, role.ID)
+}
+
+// DeleteGroup removes a custom role after taking it away from its members.
+// Built-in roles cannot be deleted.
+func (s *scimService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	role, users, err := s.group(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role.IsBuiltIn() {
+		return scim.NewError(http.StatusBadRequest, scim.ErrMutability, ErrBuiltInRole.Error())
+	}
+
+	if err := s.setMembers(ctx, role, roleMembers(role, users), nil); err != nil {
+		return err
+	}
+
+	var deletedBy *uuid.UUID
+	if callerID, ok := auth.GetUserID(ctx); ok {
+		deletedBy = &callerID
+	}
+	if err := s.roleRepo.Delete(ctx, role.ID, deletedBy); err != nil {
+		return err
+	}
+
+	s.log.WithField("role_id", role.ID).Info("group deleted via scim")
+	return nil
+}
+
+func scimOrgID(ctx context.Context) (uuid.UUID, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return uuid.Nil, errors.New("organization id not found")
+	}
+	return orgID, nil
+}
+
+func parseSCIMFilter(filter string) (scim.Filter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	return scim.ParseFilter(filter)
+}
+
+func scimEmail(value string) (string, error) {
+	email := strings.ToLower(strings.TrimSpace(value))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "", scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "userName must be an email address")
+	}
+	return email, nil
+}
+
+// scimPage applies the 1-based startIndex and count of RFC 7644 section
+// 3.4.2.4.
+func scimPage(resources []any, startIndex, count int) *scim.ListResponse {
+	startIndex, count = scimPageBounds(startIndex, count)
+
+	total := len(resources)
+	from := min(startIndex-1, total)
+	to := min(from+count, total)
+	return scim.NewListResponse(resources[from:to], total, startIndex)
+}
+
+func scimPageBounds(startIndex, count int) (int, int) {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > scimMaxPageSize {
+		count = scimMaxPageSize
+	}
+	return startIndex, count
+}
+
+// members lists every person in the organization, guests included.
+func (s *scimService) members(ctx context.Context, orgID uuid.UUID) ([]domain.User, error) {
+	filter := domain.UserFilter{OrganizationID: orgID, Limit: scimScanBatch}
+	var users []domain.User
+	for {
+		page, total, err := s.userRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) == 0 || len(users) >= total {
+			return users, nil
+		}
+		filter.Offset += len(page)
+	}
+}
+
+func (s *scimService) user(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetInOrganization(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsServiceAccount {
+		return nil, scimNotFound("user")
+	}
+	return user, nil
+}
+
+// editableUser loads a user whose account the organization owns and the
+// identity provider may change.
+func (s *scimService) editableUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsGuest() {
+		return nil, scim.NewError(http.StatusForbidden, "", ErrManagedElsewhere.Error())
+	}
+
+	grantor, err := s.grantor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !grantor.CanManage(*user) {
+		return nil, scim.NewError(http.StatusForbidden, "", ErrInsufficientPrivileges.Error())
+	}
+	return user, nil
+}
+
+// grantor is who the identity provider acts as. An API key acts with its
+// scopes, so it can only manage users and groups within them.
+func (s *scimService) grantor(ctx context.Context) (*domain.User, error) {
+	caller, err := currentUser(ctx, s.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	if scopes, ok := auth.GetScopes(ctx); ok {
+		caller.Roles = []domain.Role{{Name: "api_key", Permissions: scopes}}
+	}
+	return caller, nil
+}
+
+// userChanges collects the attributes a request sets; nil means unchanged.
+type userChanges struct {
+	email      *string
+	givenName  *string
+	familyName *string
+	active     *bool
+}
+
+func (ch *userChanges) fromResource(in *scim.User) error {
+	if in.UserName != "" {
+		ch.email = &in.UserName
+	} else if email := in.PrimaryEmail(); email != "" {
+		ch.email = &email
+	}
+	if in.Name != nil {
+		if in.Name.GivenName != "" {
+			ch.givenName = &in.Name.GivenName
+		}
+		if in.Name.FamilyName != "" {
+			ch.familyName = &in.Name.FamilyName
+		}
+	}
+	ch.active = in.Active
+	return nil
+}
+
+func (ch *userChanges) fromOperation(op scim.Operation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return scim.Errorf(http.StatusBadRequest, scim.ErrInvalidSyntax, "unknown operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		if kind == "remove" {
+			return scim.NewError(http.StatusBadRequest, scim.ErrNoTarget, "remove requires a path")
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "value must be an object when path is omitted")
+		}
+		for attr, value := range values {
+			path, err := scim.ParsePath(attr)
+			if err != nil {
+				return err
+			}
+			if err := ch.set(path.Attribute, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := scim.ParsePath(op.Path)
+	if err != nil {
+		return err
+	}
+	attr := path.Attribute
+	if path.Filter != nil && attr == "emails" && path.SubAttribute == "value" {
+		attr = "emails.value"
+	}
+
+	if kind == "remove" {
+		switch attr {
+		case "name.familyname":
+			empty := ""
+			ch.familyName = &empty
+		case "username", "emails", "emails.value", "name", "name.givenname", "active":
+			return scim.Errorf(http.StatusBadRequest, scim.ErrMutability, "%s cannot be removed", op.Path)
+		}
+		return nil
+	}
+	return ch.set(attr, op.Value)
+}
+
+// set applies one attribute. Attributes this system does not store, such
+// as phone numbers or titles, are accepted and ignored.
+func (ch *userChanges) set(attr string, value json.RawMessage) error {
+	invalid := func() error {
+		return scim.Errorf(http.StatusBadRequest, scim.ErrInvalidValue, "invalid value for %s", attr)
+	}
+
+	switch attr {
+	case "username", "emails.value":
+		var email string
+		if err := json.Unmarshal(value, &email); err != nil {
+			return invalid()
+		}
+		ch.email = &email
+	case "emails":
+		var emails []scim.Email
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return invalid()
+		}
+		if email := (&scim.User{Emails: emails}).PrimaryEmail(); email != "" {
+			ch.email = &email
+		}
+	case "name":
+		var name scim.Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return invalid()
+		}
+		if name.GivenName != "" {
+			ch.givenName = &name.GivenName
+		}
+		if name.FamilyName != "" {
+			ch.familyName = &name.FamilyName
+		}
+	case "name.givenname", "name.familyname":
+		var v string
+		if err := json.Unmarshal(value, &v); err != nil {
+			return invalid()
+		}
+		if attr == "name.givenname" {
+			ch.givenName = &v
+		} else {
+			ch.familyName = &v
+		}
+	case "active":
+		active, err := scim.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		ch.active = &active
+	}
+	return nil
+}
+
+func (s *scimService) applyUserChanges(ctx context.Context, user *domain.User, ch userChanges) error {
+	if ch.email != nil {
+		email, err := scimEmail(*ch.email)
+		if err != nil {
+			return err
+		}
+		if email != user.Email {
+			existing, err := s.userRepo.GetByEmail(database.Unscoped(ctx), email)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return scim.NewError(http.StatusConflict, scim.ErrUniqueness, "userName is already taken")
+			}
+			user.Email = email
+		}
+	}
+	if ch.givenName != nil {
+		name := strings.TrimSpace(*ch.givenName)
+		if name == "" {
+			return scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "name.givenName cannot be empty")
+		}
+		user.FirstName = name
+	}
+	if ch.familyName != nil {
+		user.LastName = strings.TrimSpace(*ch.familyName)
+	}
+
+	deactivated := false
+	if ch.active != nil {
+		switch {
+		case *ch.active && !user.IsActive():
+			user.DeactivatedAt = nil
+		case !*ch.active && user.IsActive():
+			now := time.Now()
+			user.DeactivatedAt = &now
+			deactivated = true
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if deactivated {
+		if err := s.sessions.RevokeAll(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to end sessions of deactivated user: %w", err)
+		}
+		s.log.WithField("user_id", user.ID).Info("user deactivated via scim")
+	}
+	return nil
+}
+
+// group loads a role the organization can use together with everyone in
+// the organization, from which its members are picked.
+func (s *scimService) group(ctx context.Context, id uuid.UUID) (*domain.Role, []domain.User, error) {
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if role == nil || (!role.IsBuiltIn() && *role.OrganizationID != orgID) {
+		return nil, nil, scimNotFound("group")
+	}
+
+	users, err := s.members(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return role, users, nil
+}
+
+func (s *scimService) checkGroupName(ctx context.Context, orgID uuid.UUID, name string, roleID uuid.UUID) error {
+	existing, err := s.roleRepo.GetForOrganization(ctx, orgID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != roleID {
+		return scim.NewError(http.StatusConflict, scim.ErrUniqueness, ErrRoleNameTaken.Error())
+	}
+	return nil
+}
+
+func (s *scimService) renameGroup(ctx context.Context, role *domain.Role, name string) error {
+	if role.IsBuiltIn() {
+		return scim.NewError(http.StatusBadRequest, scim.ErrMutability, ErrBuiltInRole.Error())
+	}
+	if err := s.checkGroupName(ctx, *role.OrganizationID, name, role.ID); err != nil {
+		return err
+	}
+
+	role.Name = name
+	if callerID, ok := auth.GetUserID(ctx); ok {
+		role.UpdatedBy = &callerID
+	}
+	return s.roleRepo.Update(ctx, role)
+}
+
+// setMembers assigns and revokes the role so that exactly wanted hold it.
+// Changing who holds a role requires being able to grant it.
+func (s *scimService) setMembers(ctx context.Context, role *domain.Role, current map[uuid.UUID]string, wanted []uuid.UUID) error {
+	orgID, err := scimOrgID(ctx)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[uuid.UUID]bool, len(wanted))
+	var add, remove []uuid.UUID
+	for _, userID := range wanted {
+		keep[userID] = true
+		if _, ok := current[userID]; !ok {
+			add = append(add, userID)
+		}
+	}
+	for userID := range current {
+		if !keep[userID] {
+			remove = append(remove, userID)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	grantor, err := s.grantor(ctx)
+	if err != nil {
+		return err
+	}
+	if !grantor.CanGrant(*role) {
+		return scim.NewError(http.StatusForbidden, "", ErrInsufficientPrivileges.Error())
+	}
+
+	for _, userID := range add {
+		user, err := s.userRepo.GetInOrganization(ctx, userID, orgID)
+		if err != nil {
+			return err
+		}
+		if user == nil || user.IsServiceAccount {
+			return scim.Errorf(http.StatusBadRequest, scim.ErrInvalidValue, "member %s is not a user of this organization", userID)
+		}
+		if err := s.roleRepo.AssignRoleToUser(ctx, userID, orgID, role.ID); err != nil {
+			return err
+		}
+	}
+	for _, userID := range remove {
+		if err := s.roleRepo.RevokeUserRole(ctx, userID, orgID, role.ID); err != nil {
+			return err
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{"role_id": role.ID, "added": len(add), "removed": len(remove)}).Info("group members updated via scim")
+	return nil
+}
+
+// patchGroupMembers applies op to members (user ID to display name) and
+// returns the new display name when op sets one.
+func patchGroupMembers(op scim.Operation, members map[uuid.UUID]string) (string, error) {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return "", scim.Errorf(http.StatusBadRequest, scim.ErrInvalidSyntax, "unknown operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		if kind == "remove" {
+			return "", scim.NewError(http.StatusBadRequest, scim.ErrNoTarget, "remove requires a path")
+		}
+		var in scim.Group
+		if err := json.Unmarshal(op.Value, &in); err != nil {
+			return "", scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "value must be a group")
+		}
+		if in.Members != nil {
+			if err := updateMembers(kind, in.Members, members); err != nil {
+				return "", err
+			}
+		}
+		return strings.TrimSpace(in.DisplayName), nil
+	}
+
+	path, err := scim.ParsePath(op.Path)
+	if err != nil {
+		return "", err
+	}
+
+	switch path.Attribute {
+	case "displayname":
+		if kind == "remove" {
+			return "", scim.NewError(http.StatusBadRequest, scim.ErrMutability, "displayName cannot be removed")
+		}
+		var name string
+		if err := json.Unmarshal(op.Value, &name); err != nil {
+			return "", scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "displayName must be a string")
+		}
+		return strings.TrimSpace(name), nil
+	case "members":
+		if path.Filter != nil {
+			if kind != "remove" {
+				return "", scim.NewError(http.StatusBadRequest, scim.ErrInvalidPath, "member filters are only supported for remove")
+			}
+			for userID, display := range members {
+				if path.Filter.Match(scim.Attributes{"value": {userID.String()}, "display": {display}}) {
+					delete(members, userID)
+				}
+			}
+			return "", nil
+		}
+
+		var refs []scim.Ref
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &refs); err != nil {
+				return "", scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "members must be a list")
+			}
+		} else if kind == "remove" {
+			clear(members)
+			return "", nil
+		}
+		return "", updateMembers(kind, refs, members)
+	default:
+		return "", scim.Errorf(http.StatusBadRequest, scim.ErrInvalidPath, "unsupported path %q", op.Path)
+	}
+}
+
+func updateMembers(kind string, refs []scim.Ref, members map[uuid.UUID]string) error {
+	ids := memberIDs(refs)
+	if len(ids) != len(refs) {
+		return scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "member values must be user ids")
+	}
+
+	if kind == "replace" {
+		clear(members)
+	}
+	for _, id := range ids {
+		if kind == "remove" {
+			delete(members, id)
+		} else {
+			members[id] = ""
+		}
+	}
+	return nil
+}
+
+func memberIDs(refs []scim.Ref) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(refs))
+	for _, ref := range refs {
+		if id, err := uuid.Parse(ref.Value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func roleMembers(role *domain.Role, users []domain.User) map[uuid.UUID]string {
+	members := make(map[uuid.UUID]string)
+	for _, user := range users {
+		for _, r := range user.Roles {
+			if r.ID == role.ID {
+				members[user.ID] = user.Email
+			}
+		}
+	}
+	return members
+}
+
+func toSCIMUser(user *domain.User) *scim.User {
+	active := user.IsActive()
+	res := &scim.User{
+		Schemas:     []string{scim.UserSchema},
+		ID:          user.ID.String(),
+		UserName:    user.Email,
+		DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+		Name: &scim.Name{
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+			Formatted:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+		},
+		Emails: []scim.Email{{Value: user.Email, Type: "work", Primary: true}},
+		Active: &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      &user.CreatedAt,
+			LastModified: &user.UpdatedAt,
+		},
+	}
+	for _, role := range user.Roles {
+		res.Groups = append(res.Groups, scim.Ref{Value: role.ID.String(), Display: role.Name})
+	}
+	return res
+}
+
+func toSCIMGroup(role *domain.Role, users []domain.User) *scim.Group {
+	res := &scim.Group{
+		Schemas:     []string{scim.GroupSchema},
+		ID:          role.ID.String(),
+		DisplayName: role.Name,
+		Members:     []scim.Ref{},
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      &role.CreatedAt,
+			LastModified: &role.UpdatedAt,
+		},
+	}
+	for userID, email := range roleMembers(role, users) {
+		res.Members = append(res.Members, scim.Ref{Value: userID.String(), Display: email})
+	}
+	return res
+}
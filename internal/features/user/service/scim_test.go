@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// listedUsers answers List with a single user and records the filters.
+type listedUsers struct {
+	domain.UserRepository
+	filters []domain.UserFilter
+}
+
+func (r *listedUsers) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	r.filters = append(r.filters, filter)
+	user := domain.User{Email: "ada@example.edu"}
+	user.ID = uuid.New()
+	return []domain.User{user}, 1, nil
+}
+
+func TestSCIMListUsersQuery(t *testing.T) {
+	orgID := uuid.New()
+
+	tests := []struct {
+		name       string
+		filter     string
+		startIndex int
+		count      int
+		want       []domain.UserFilter
+	}{
+		{"Page of every user", "", 11, 10,
+			[]domain.UserFilter{{OrganizationID: orgID, Limit: 10, Offset: 10}}},
+		{"userName lookup", `userName eq "Ada@Example.edu"`, 1, 100,
+			[]domain.UserFilter{{OrganizationID: orgID, Email: "ada@example.edu", Limit: 100}}},
+		{"Count is capped", "", 0, 10000,
+			[]domain.UserFilter{{OrganizationID: orgID, Limit: scimMaxPageSize}}},
+		{"externalId is never stored", `externalId eq "a1"`, 1, 100, nil},
+		{"Other filters scan the organization", `name.givenName sw "a"`, 1, 100,
+			[]domain.UserFilter{{OrganizationID: orgID, Limit: scimScanBatch}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &listedUsers{}
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+			s := NewSCIMService(repo, nil, nil, nil, log)
+
+			ctx := context.WithValue(context.Background(), auth.OrgIDKey, orgID)
+			if _, err := s.ListUsers(ctx, tt.filter, tt.startIndex, tt.count); err != nil {
+				t.Fatalf("ListUsers() error = %v", err)
+			}
+			if !reflect.DeepEqual(repo.filters, tt.want) {
+				t.Errorf("List() filters = %+v, want %+v", repo.filters, tt.want)
+			}
+		})
+	}
+}
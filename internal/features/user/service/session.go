@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrOrganizationSuspended = errors.New("organization has been suspended")
+)
+
+// sessionService works on a user's sessions in all their organizations, and
+// Switch starts one in an organization other than the request's, so every
+// method runs unscoped.
+type sessionService struct {
+	sessionRepo   domain.SessionRepository
+	orgRepo       o.OrganizationRepository
+	tokenProvider auth.TokenProvider
+	log           *logrus.Logger
+}
+
+func NewSessionService(sr domain.SessionRepository, or o.OrganizationRepository, tp auth.TokenProvider, log *logrus.Logger) Sessions {
+	return &sessionService{
+		sessionRepo:   sr,
+		orgRepo:       or,
+		tokenProvider: tp,
+		log:           log,
+	}
+}
+
+// checkOrganization refuses sessions in organizations that have been
+// suspended.
+func (s *sessionService) checkOrganization(ctx context.Context, orgID uuid.UUID) error {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil || org.IsSuspended() {
+		return ErrOrganizationSuspended
+	}
+	return nil
+}
+
+func (s *sessionService) Start(ctx context.Context, user *domain.User, client ClientInfo) (*auth.TokenPair, error) {
+	ctx = database.Unscoped(ctx)
+	if err := s.checkOrganization(ctx, user.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.tokenProvider.GenerateTokenPair(ctx, user.ID, user.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	session := domain.NewSession(tokens.SessionID, user, client.IP, client.UserAgent, tokens.RefreshExpiresIn)
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		// Without a row the session could not be listed or revoked, so do
+		// not hand out tokens for it.
+		if revokeErr := s.tokenProvider.RevokeSession(ctx, tokens.SessionID); revokeErr != nil {
+			s.log.WithError(revokeErr).WithField("session_id", tokens.SessionID).Error("failed to revoke unrecorded session")
+		}
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "session_id": session.ID}).Info("session started")
+	return tokens, nil
+}
+
+func (s *sessionService) Rotate(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	ctx = database.Unscoped(ctx)
+	tokens, err := s.tokenProvider.RotateRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, tokens.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil {
+		if err := s.checkOrganization(ctx, session.OrganizationID); err != nil {
+			if revokeErr := s.revoke(ctx, session.ID); revokeErr != nil {
+				s.log.WithError(revokeErr).WithField("session_id", session.ID).Error("failed to revoke session of suspended organization")
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.sessionRepo.Touch(ctx, tokens.SessionID, time.Now().Add(tokens.RefreshExpiresIn)); err != nil {
+		s.log.WithError(err).WithField("session_id", tokens.SessionID).Warn("failed to update session activity")
+	}
+
+	return tokens, nil
+}
+
+func (s *sessionService) List(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	ctx = database.Unscoped(ctx)
+	return s.sessionRepo.ListActiveByUser(ctx, userID)
+}
+
+func (s *sessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx = database.Unscoped(ctx)
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return s.revoke(ctx, sessionID)
+}
+
+func (s *sessionService) RevokeOthers(ctx context.Context, userID, currentSessionID uuid.UUID) (int, error) {
+	ctx = database.Unscoped(ctx)
+	sessions, err := s.sessionRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := s.revoke(ctx, session.ID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": userID, "revoked": revoked}).Info("other sessions revoked")
+	return revoked, nil
+}
+
+func (s *sessionService) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	ctx = database.Unscoped(ctx)
+	if err := s.tokenProvider.RevokeUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	return s.sessionRepo.RevokeAllForUser(ctx, userID)
+}
+
+func (s *sessionService) revoke(ctx context.Context, sessionID uuid.UUID) error {
+	if err := s.tokenProvider.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session tokens: %w", err)
+	}
+	if err := s.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+
+	s.log.WithField("session_id", sessionID).Info("session revoked")
+	return nil
+}
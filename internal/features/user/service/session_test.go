@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// memorySessions keeps sessions in a map.
+type memorySessions struct {
+	sessions map[uuid.UUID]*domain.Session
+}
+
+func (m *memorySessions) Create(ctx context.Context, session *domain.Session) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *memorySessions) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	return m.sessions[id], nil
+}
+
+func (m *memorySessions) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	var active []domain.Session
+	for _, s := range m.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			active = append(active, *s)
+		}
+	}
+	return active, nil
+}
+
+func (m *memorySessions) Touch(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	return nil
+}
+
+func (m *memorySessions) Revoke(ctx context.Context, id uuid.UUID) error {
+	if s, ok := m.sessions[id]; ok {
+		now := time.Now()
+		s.RevokedAt = &now
+	}
+	return nil
+}
+
+func (m *memorySessions) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	for _, s := range m.sessions {
+		if s.UserID == userID {
+			m.Revoke(ctx, s.ID)
+		}
+	}
+	return nil
+}
+
+// recordingTokens records revocations; the session service uses no other
+// part of the provider here.
+type recordingTokens struct {
+	auth.TokenProvider
+	revokedSessions map[uuid.UUID]bool
+	revokedUsers    map[uuid.UUID]bool
+	err             error
+}
+
+func (r *recordingTokens) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.revokedSessions[sessionID] = true
+	return nil
+}
+
+func (r *recordingTokens) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.revokedUsers[userID] = true
+	return nil
+}
+
+func newTestSessions(t *testing.T, sessions ...*domain.Session) (*sessionService, *memorySessions, *recordingTokens) {
+	t.Helper()
+	repo := &memorySessions{sessions: map[uuid.UUID]*domain.Session{}}
+	for _, s := range sessions {
+		repo.sessions[s.ID] = s
+	}
+	tokens := &recordingTokens{revokedSessions: map[uuid.UUID]bool{}, revokedUsers: map[uuid.UUID]bool{}}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return NewSessionService(repo, nil, tokens, log).(*sessionService), repo, tokens
+}
+
+func TestSessionRevoke(t *testing.T) {
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+	own := &domain.Session{ID: uuid.New(), UserID: userID}
+	foreign := &domain.Session{ID: uuid.New(), UserID: otherID}
+	s, repo, tokens := newTestSessions(t, own, foreign)
+
+	if err := s.Revoke(ctx, userID, own.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if repo.sessions[own.ID].RevokedAt == nil || !tokens.revokedSessions[own.ID] {
+		t.Error("own session was not revoked in both the database and the token store")
+	}
+
+	tests := []struct {
+		name      string
+		sessionID uuid.UUID
+	}{
+		{"Another user's session", foreign.ID},
+		{"Unknown session", uuid.New()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Revoke(ctx, userID, tt.sessionID); !errors.Is(err, ErrSessionNotFound) {
+				t.Errorf("Revoke() error = %v, want ErrSessionNotFound", err)
+			}
+			if tokens.revokedSessions[tt.sessionID] {
+				t.Error("Revoke() revoked a session the user does not own")
+			}
+		})
+	}
+}
+
+func TestSessionRevokeOthers(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	current := &domain.Session{ID: uuid.New(), UserID: userID}
+	phone := &domain.Session{ID: uuid.New(), UserID: userID}
+	laptop := &domain.Session{ID: uuid.New(), UserID: userID}
+	stranger := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+	s, repo, tokens := newTestSessions(t, current, phone, laptop, stranger)
+
+	n, err := s.RevokeOthers(ctx, userID, current.ID)
+	if err != nil {
+		t.Fatalf("RevokeOthers() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("RevokeOthers() = %d, want 2", n)
+	}
+	for _, sess := range []*domain.Session{phone, laptop} {
+		if repo.sessions[sess.ID].RevokedAt == nil || !tokens.revokedSessions[sess.ID] {
+			t.Errorf("session %s was not revoked", sess.ID)
+		}
+	}
+	if current.RevokedAt != nil || tokens.revokedSessions[current.ID] {
+		t.Error("the current session was revoked")
+	}
+	if stranger.RevokedAt != nil {
+		t.Error("another user's session was revoked")
+	}
+}
+
+func TestSessionRevokeAll(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	a := &domain.Session{ID: uuid.New(), UserID: userID}
+	b := &domain.Session{ID: uuid.New(), UserID: userID}
+	s, _, tokens := newTestSessions(t, a, b)
+
+	if err := s.RevokeAll(ctx, userID); err != nil {
+		t.Fatalf("RevokeAll() error = %v", err)
+	}
+	if !tokens.revokedUsers[userID] {
+		t.Error("RevokeAll() did not revoke the user's tokens")
+	}
+	if a.RevokedAt == nil || b.RevokedAt == nil {
+		t.Error("RevokeAll() left sessions active")
+	}
+}
+
+func TestSessionRevokeAllTokenStoreDown(t *testing.T) {
+	userID := uuid.New()
+	sess := &domain.Session{ID: uuid.New(), UserID: userID}
+	s, _, tokens := newTestSessions(t, sess)
+	tokens.err = errors.New("redis unavailable")
+
+	if err := s.RevokeAll(context.Background(), userID); err == nil {
+		t.Fatal("RevokeAll() succeeded without revoking tokens")
+	}
+	// Sessions stay listed as active, so the user can see and retry.
+	if sess.RevokedAt != nil {
+		t.Error("RevokeAll() marked sessions revoked although their tokens still work")
+	}
+}
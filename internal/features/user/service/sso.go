@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/oidc"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const ssoStateTTL = 10 * time.Minute
+
+var (
+	ErrSSONotConfigured    = errors.New("single sign-on is not configured for this organization")
+	ErrInvalidSSOState     = errors.New("invalid or expired single sign-on request")
+	ErrSSOEmailNotVerified = errors.New("identity provider did not verify the email address")
+	ErrSSOUserNotAllowed   = errors.New("this account cannot sign in to the organization")
+	ErrInvalidProvider     = errors.New("invalid identity provider configuration")
+)
+
+type ssoService struct {
+	providerRepo domain.IdentityProviderRepository
+	userRepo     domain.UserRepository
+	roleRepo     domain.RoleRepository
+	orgRepo      o.OrganizationRepository
+	oidc         *oidc.Client
+	sessions     Sessions
+	twoFactor    TwoFactor
+	redis        *redis.Client
+	redirectURL  string
+	log          *logrus.Logger
+}
+
+func NewSSOService(
+	ipr domain.IdentityProviderRepository,
+	ur domain.UserRepository,
+	rr domain.RoleRepository,
+	or o.OrganizationRepository,
+	oc *oidc.Client,
+	ss Sessions,
+	tf TwoFactor,
+	redisClient *redis.Client,
+	redirectURL string,
+	log *logrus.Logger,
+) SingleSignOn {
+	return &ssoService{
+		providerRepo: ipr,
+		userRepo:     ur,
+		roleRepo:     rr,
+		orgRepo:      or,
+		oidc:         oc,
+		sessions:     ss,
+		twoFactor:    tf,
+		redis:        redisClient,
+		redirectURL:  redirectURL,
+		log:          log,
+	}
+}
+
+// ssoState is what we remember between sending the browser to the provider
+// and it coming back. It is keyed by the digest of the state parameter:
+//
+//	sso_state:<digest> -> JSON ssoState
+type ssoState struct {
+	ProviderID   string `json:"provider_id"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+func ssoStateKey(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return "sso_state:" + hex.EncodeToString(sum[:])
+}
+
+func (s *ssoService) config(p *domain.IdentityProvider) oidc.Config {
+	return oidc.Config{
+		Issuer:       p.Issuer,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  s.redirectURL,
+	}
+}
+
+func (s *ssoService) Begin(ctx context.Context, orgSlug, loginHint string) (string, error) {
+	org, err := s.orgRepo.GetBySlug(ctx, orgSlug)
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil {
+		return "", ErrSSONotConfigured
+	}
+
+	provider, err := s.providerRepo.GetByOrganization(ctx, org.ID)
+	if err != nil {
+		return "", err
+	}
+	if provider == nil || !provider.IsEnabled {
+		return "", ErrSSONotConfigured
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return "", err
+	}
+
+	payload, _ := json.Marshal(ssoState{
+		ProviderID:   provider.ID.String(),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	})
+	if err := s.redis.Set(ctx, ssoStateKey(state), payload, ssoStateTTL).Err(); err != nil {
+		return "", fmt.Errorf("failed to store sso state: %w", err)
+	}
+
+	authURL, err := s.oidc.AuthCodeURL(ctx, s.config(provider), state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		s.log.WithError(err).WithField("issuer", provider.Issuer).Error("failed to build sso authorization url")
+		return "", err
+	}
+	if loginHint != "" {
+		authURL += "&login_hint=" + url.QueryEscape(loginHint)
+	}
+
+	return authURL, nil
+}
+
+func (s *ssoService) Complete(ctx context.Context, state, code string, client ClientInfo) (*LoginResult, error) {
+	raw, err := s.redis.GetDel(ctx, ssoStateKey(state)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidSSOState
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sso state: %w", err)
+	}
+
+	var st ssoState
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return nil, ErrInvalidSSOState
+	}
+
+	providerID, err := uuid.Parse(st.ProviderID)
+	if err != nil {
+		return nil, ErrInvalidSSOState
+	}
+
+	provider, err := s.providerRepo.GetByID(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil || !provider.IsEnabled {
+		return nil, ErrSSONotConfigured
+	}
+
+	claims, err := s.oidc.Exchange(ctx, s.config(provider), code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		s.log.WithError(err).WithField("issuer", provider.Issuer).Warn("sso code exchange failed")
+		if errors.Is(err, oidc.ErrInvalidIDToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to complete sign-in with identity provider: %w", err)
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrSSOEmailNotVerified
+	}
+
+	user, err := s.resolveUser(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsLocked(time.Now()) {
+		return nil, &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: time.Until(*user.LockedUntil)}
+	}
+	if !user.IsActive() {
+		return nil, ErrAccountDeactivated
+	}
+
+	challenge, enrollmentRequired, err := s.twoFactor.ChallengeIfRequired(ctx, user)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to evaluate two-factor requirement")
+		return nil, errors.New("failed to complete login")
+	}
+	if challenge != "" {
+		return &LoginResult{ChallengeToken: challenge, EnrollmentRequired: enrollmentRequired}, nil
+	}
+
+	tokens, err := s.sessions.Start(ctx, user, client)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationSuspended) {
+			return nil, err
+		}
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to generate tokens")
+		return nil, errors.New("failed to generate access token")
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "issuer": provider.Issuer}).Info("user logged in with single sign-on")
+	return &LoginResult{Tokens: tokens}, nil
+}
+
+// resolveUser maps the identity to a local user: first by a previous link,
+// then by verified email within the organization, and finally by creating
+// the user when the provider allows just-in-time provisioning.
+func (s *ssoService) resolveUser(ctx context.Context, provider *domain.IdentityProvider, claims *oidc.Claims) (*domain.User, error) {
+	linkedID, err := s.providerRepo.FindLinkedUser(ctx, provider.ID, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if linkedID != nil {
+		user, err := s.userRepo.GetByID(ctx, *linkedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get linked user: %w", err)
+		}
+		if user != nil && user.OrganizationID == provider.OrganizationID {
+			return user, nil
+		}
+	}
+
+	if !provider.AllowsEmail(claims.Email) {
+		s.log.WithField("email", claims.Email).Warn("sso login refused: email domain not allowed")
+		return nil, ErrSSOUserNotAllowed
+	}
+
+	user, err := s.userRepo.GetByEmail(database.Unscoped(ctx), claims.Email)
+	if err != nil {
+		user = nil
+	}
+	if user != nil && user.OrganizationID != provider.OrganizationID {
+		s.log.WithField("user_id", user.ID).Warn("sso login refused: user belongs to another organization")
+		return nil, ErrSSOUserNotAllowed
+	}
+
+	if user == nil {
+		if !provider.AutoProvision {
+			return nil, ErrSSOUserNotAllowed
+		}
+		if user, err = s.provision(ctx, provider, claims); err != nil {
+			return nil, err
+		}
+	} else {
+		allowed, err := s.canAutoLink(ctx, provider, user)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			s.log.WithField("user_id", user.ID).Warn("sso login refused: account is beyond the authority of the provider's configurer")
+			return nil, ErrSSOUserNotAllowed
+		}
+		if !user.IsEmailVerified() {
+			// The provider vouched for the address, which is what
+			// verification would have established.
+			user.MarkEmailVerified()
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return nil, fmt.Errorf("failed to mark email verified: %w", err)
+			}
+		}
+	}
+
+	if err := s.providerRepo.Link(ctx, user.ID, provider.ID, claims.Subject); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// canAutoLink decides whether a first SSO login may take over an existing
+// account. Whoever configures the provider controls the identities it
+// vouches for, so superusers and accounts that administrator could not
+// manage keep signing in with their password.
+func (s *ssoService) canAutoLink(ctx context.Context, provider *domain.IdentityProvider, user *domain.User) (bool, error) {
+	if user.IsSuperuser || provider.ConfiguredBy == nil {
+		return false, nil
+	}
+	configurer, err := s.userRepo.GetInOrganization(ctx, *provider.ConfiguredBy, provider.OrganizationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get provider configurer: %w", err)
+	}
+	return configurer != nil && configurer.IsActive() && configurer.CanManage(*user), nil
+}
+
+func (s *ssoService) provision(ctx context.Context, provider *domain.IdentityProvider, claims *oidc.Claims) (*domain.User, error) {
+	role, err := s.roleRepo.GetForOrganization(ctx, provider.OrganizationID, provider.DefaultRole)
+	if err != nil || role == nil {
+		s.log.WithError(err).WithField("role_name", provider.DefaultRole).Error("sso default role is missing")
+		return nil, fmt.Errorf("sso default role '%s' does not exist", provider.DefaultRole)
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+
+	user := domain.NewUser(claims.Email, firstName, lastName, provider.OrganizationID, []domain.Role{*role})
+	user.MarkEmailVerified()
+
+	// SSO users have no usable password until they set one through a reset.
+	if err := user.SetRandomPassword(); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to provision sso user: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": provider.OrganizationID, "role": role.Name}).Info("user provisioned via single sign-on")
+	return user, nil
+}
+
+func (s *ssoService) GetProvider(ctx context.Context) (*domain.IdentityProvider, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	provider, err := s.providerRepo.GetByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, ErrSSONotConfigured
+	}
+	return provider, nil
+}
+
+func (s *ssoService) SaveProvider(ctx context.Context, provider *domain.IdentityProvider) error {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return errors.New("organization id not found")
+	}
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return errors.New("user id not found")
+	}
+
+	if provider.Issuer == "" || provider.ClientID == "" || provider.DefaultRole == "" {
+		return fmt.Errorf("%w: issuer, client id and default role are required", ErrInvalidProvider)
+	}
+	role, err := s.roleRepo.GetForOrganization(ctx, orgID, provider.DefaultRole)
+	if err != nil {
+		return fmt.Errorf("failed to get role: %w", err)
+	}
+	if role == nil {
+		return fmt.Errorf("%w: role '%s' does not exist", ErrInvalidProvider, provider.DefaultRole)
+	}
+
+	// Every account the provider creates gets the default role, so it is
+	// a grant like any other.
+	caller, err := currentUser(ctx, s.userRepo)
+	if err != nil {
+		return err
+	}
+	if !caller.CanGrant(*role) {
+		return ErrInsufficientPrivileges
+	}
+
+	// Fail early on a wrong issuer rather than at the first login.
+	// The fetch error is logged rather than returned, so the endpoint
+	// cannot be used to probe what the server can reach.
+	if _, err := s.oidc.Discover(ctx, provider.Issuer); err != nil {
+		s.log.WithError(err).WithFields(logrus.Fields{"org_id": orgID, "issuer": provider.Issuer}).Warn("sso provider discovery failed")
+		if errors.Is(err, oidc.ErrUnsafeURL) {
+			return fmt.Errorf("%w: issuer must be a public https URL", ErrInvalidProvider)
+		}
+		return fmt.Errorf("%w: issuer discovery failed", ErrInvalidProvider)
+	}
+
+	provider.OrganizationID = orgID
+	provider.ConfiguredBy = &userID
+	return s.providerRepo.Save(ctx, provider)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/oidc"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// membersRepo answers GetInOrganization from a map.
+type membersRepo struct {
+	domain.UserRepository
+	users map[uuid.UUID]*domain.User
+}
+
+func (r membersRepo) GetInOrganization(ctx context.Context, id, orgID uuid.UUID) (*domain.User, error) {
+	return r.users[id], nil
+}
+
+func TestSSOCanAutoLink(t *testing.T) {
+	admin := domain.Role{Name: "admin", Permissions: map[string][]string{domain.AllResources: {domain.ManageAction}}}
+	teacher := domain.Role{Name: "teacher", Permissions: map[string][]string{"user": {"read"}, "course": {"create"}}}
+	student := domain.Role{Name: "student", Permissions: map[string][]string{"course": {"read"}}}
+
+	orgAdmin := &domain.User{Roles: []domain.Role{admin}}
+	orgAdmin.ID = uuid.New()
+	orgTeacher := &domain.User{Roles: []domain.Role{teacher}}
+	orgTeacher.ID = uuid.New()
+
+	s := &ssoService{userRepo: membersRepo{users: map[uuid.UUID]*domain.User{
+		orgAdmin.ID:   orgAdmin,
+		orgTeacher.ID: orgTeacher,
+	}}}
+
+	tests := []struct {
+		name         string
+		configuredBy *uuid.UUID
+		user         domain.User
+		want         bool
+	}{
+		{"Admin links a student", &orgAdmin.ID, domain.User{Roles: []domain.Role{student}}, true},
+		{"Admin links another admin", &orgAdmin.ID, domain.User{Roles: []domain.Role{admin}}, true},
+		{"Superuser is never linked", &orgAdmin.ID, domain.User{IsSuperuser: true}, false},
+		{"Teacher cannot link an admin", &orgTeacher.ID, domain.User{Roles: []domain.Role{admin}}, false},
+		{"Configurer no longer a member", ptr(uuid.New()), domain.User{Roles: []domain.Role{student}}, false},
+		{"Provider saved before configurers were recorded", nil, domain.User{Roles: []domain.Role{student}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &domain.IdentityProvider{OrganizationID: uuid.New(), ConfiguredBy: tt.configuredBy}
+			got, err := s.canAutoLink(context.Background(), provider, &tt.user)
+			if err != nil {
+				t.Fatalf("canAutoLink() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("canAutoLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// namedRoles answers GetForOrganization from a map.
+type namedRoles struct {
+	domain.RoleRepository
+	roles map[string]*domain.Role
+}
+
+func (r namedRoles) GetForOrganization(ctx context.Context, orgID uuid.UUID, name string) (*domain.Role, error) {
+	return r.roles[name], nil
+}
+
+func TestSaveProviderDefaultRole(t *testing.T) {
+	admin := &domain.Role{Name: "admin", Permissions: map[string][]string{domain.AllResources: {domain.ManageAction}}}
+	teacher := &domain.Role{Name: "teacher", Permissions: map[string][]string{"user": {"read"}, "course": {"create", "read"}}}
+	student := &domain.Role{Name: "student", Permissions: map[string][]string{"course": {"read"}}}
+
+	orgAdmin := &domain.User{Roles: []domain.Role{*admin}}
+	orgAdmin.ID = uuid.New()
+	orgTeacher := &domain.User{Roles: []domain.Role{*teacher}}
+	orgTeacher.ID = uuid.New()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s := &ssoService{
+		userRepo: membersRepo{users: map[uuid.UUID]*domain.User{orgAdmin.ID: orgAdmin, orgTeacher.ID: orgTeacher}},
+		roleRepo: namedRoles{roles: map[string]*domain.Role{"admin": admin, "student": student}},
+		oidc:     oidc.NewClient(oidc.NewPublicHTTPClient(false), time.Hour),
+		log:      log,
+	}
+
+	// A role the caller may grant gets as far as discovery, which refuses
+	// the plain http issuer.
+	tests := []struct {
+		name    string
+		caller  uuid.UUID
+		role    string
+		wantErr error
+	}{
+		{"Admin defaults to student", orgAdmin.ID, "student", ErrInvalidProvider},
+		{"Teacher defaults to student", orgTeacher.ID, "student", ErrInvalidProvider},
+		{"Teacher cannot default to admin", orgTeacher.ID, "admin", ErrInsufficientPrivileges},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), auth.UserIDKey, tt.caller)
+			ctx = context.WithValue(ctx, auth.OrgIDKey, uuid.New())
+			provider := &domain.IdentityProvider{Issuer: "http://127.0.0.1", ClientID: "lms", DefaultRole: tt.role}
+			if err := s.SaveProvider(ctx, provider); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SaveProvider() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+)
+
+var (
+	ErrInvalidChallenge       = errors.New("invalid or expired two-factor challenge")
+	ErrInvalidTOTPCode        = errors.New("invalid two-factor code")
+	ErrTwoFactorNotEnrolled   = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyOn     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorRequiredByOrg = errors.New("two-factor authentication is required by your organization")
+)
+
+type twoFactorService struct {
+	userRepo     domain.UserRepository
+	recoveryRepo domain.RecoveryCodeRepository
+	orgRepo      o.OrganizationRepository
+	sessions     Sessions
+	redis        *redis.Client
+	issuer       string
+	log          *logrus.Logger
+}
+
+func NewTwoFactorService(
+	ur domain.UserRepository,
+	rcr domain.RecoveryCodeRepository,
+	or o.OrganizationRepository,
+	ss Sessions,
+	redis *redis.Client,
+	issuer string,
+	log *logrus.Logger,
+) TwoFactor {
+	return &twoFactorService{
+		userRepo:     ur,
+		recoveryRepo: rcr,
+		orgRepo:      or,
+		sessions:     ss,
+		redis:        redis,
+		issuer:       issuer,
+		log:          log,
+	}
+}
+
+// --- login challenge ---
+
+// Challenge tokens stand in for the access token between the password step
+// and the second factor. Redis keeps only their digest:
+//
+//	mfa_challenge:<digest> -> user_id, attempts
+func challengeKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "mfa_challenge:" + hex.EncodeToString(sum[:])
+}
+
+func (s *twoFactorService) ChallengeIfRequired(ctx context.Context, user *domain.User) (string, bool, error) {
+	enrollmentRequired := false
+	if !user.HasTwoFactor() {
+		required, err := s.requiredFor(ctx, user)
+		if err != nil {
+			return "", false, err
+		}
+		if !required {
+			return "", false, nil
+		}
+		enrollmentRequired = true
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", false, fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	key := challengeKey(token)
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", user.ID.String(), "org_id", user.OrganizationID.String(), "attempts", 0)
+	pipe.Expire(ctx, key, mfaChallengeTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to store two-factor challenge")
+		return "", false, errors.New("failed to start two-factor challenge")
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "enrollment_required": enrollmentRequired}).Info("two-factor challenge issued")
+	return token, enrollmentRequired, nil
+}
+
+func (s *twoFactorService) requiredFor(ctx context.Context, user *domain.User) (bool, error) {
+	org, err := s.orgRepo.GetByID(ctx, user.OrganizationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load organization policy: %w", err)
+	}
+	if org == nil {
+		return false, nil
+	}
+	return org.RequiresMFAFor(user.RolesStr()), nil
+}
+
+// challengeUser resolves a challenge token and counts the attempt against it.
+// challengeUser continues a login, so like Login it reads the account
+// outside any organization.
+func (s *twoFactorService) challengeUser(ctx context.Context, token string) (*domain.User, error) {
+	ctx = database.Unscoped(ctx)
+	key := challengeKey(token)
+
+	vals, err := s.redis.HMGet(ctx, key, "user_id", "org_id").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load two-factor challenge: %w", err)
+	}
+	val, ok := vals[0].(string)
+	if !ok {
+		return nil, ErrInvalidChallenge
+	}
+
+	attempts, err := s.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load two-factor challenge: %w", err)
+	}
+	if attempts > mfaChallengeMaxAttempts {
+		s.redis.Del(ctx, key)
+		return nil, ErrInvalidChallenge
+	}
+
+	userID, err := uuid.Parse(val)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	// The login may have been for an organization the user is a guest of.
+	if val, ok := vals[1].(string); ok {
+		orgID, err := uuid.Parse(val)
+		if err == nil && orgID != user.OrganizationID {
+			user, err = s.userRepo.GetInOrganization(ctx, userID, orgID)
+			if err != nil || user == nil {
+				return nil, ErrInvalidChallenge
+			}
+		}
+	}
+	return user, nil
+}
+
+func (s *twoFactorService) VerifyChallenge(ctx context.Context, challengeToken, code string, client ClientInfo) (*auth.TokenPair, error) {
+	user, err := s.challengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !user.HasTwoFactor() {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	ok, err := s.verifyCode(ctx, user, code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		s.log.WithField("user_id", user.ID).Warn("two-factor challenge failed: invalid code")
+		return nil, ErrInvalidTOTPCode
+	}
+
+	return s.completeChallenge(ctx, challengeToken, user, client)
+}
+
+func (s *twoFactorService) BeginChallengeEnrollment(ctx context.Context, challengeToken string) (*TOTPEnrollment, error) {
+	user, err := s.challengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.beginEnrollment(ctx, user)
+}
+
+func (s *twoFactorService) ConfirmChallengeEnrollment(ctx context.Context, challengeToken, code string, client ClientInfo) (*auth.TokenPair, []string, error) {
+	user, err := s.challengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codes, err := s.confirmEnrollment(ctx, user, code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.completeChallenge(ctx, challengeToken, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, codes, nil
+}
+
+func (s *twoFactorService) completeChallenge(ctx context.Context, challengeToken string, user *domain.User, client ClientInfo) (*auth.TokenPair, error) {
+	if err := s.redis.Del(ctx, challengeKey(challengeToken)).Err(); err != nil {
+		return nil, fmt.Errorf("failed to clear two-factor challenge: %w", err)
+	}
+
+	tokens, err := s.sessions.Start(ctx, user, client)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationSuspended) {
+			return nil, err
+		}
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to generate tokens")
+		return nil, errors.New("failed to generate access token")
+	}
+
+	s.log.WithField("user_id", user.ID).Info("user logged in with two-factor authentication")
+	return tokens, nil
+}
+
+// --- self-service enrollment ---
+
+func (s *twoFactorService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.beginEnrollment(ctx, user)
+}
+
+func (s *twoFactorService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.confirmEnrollment(ctx, user, code)
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.HasTwoFactor() {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	required, err := s.requiredFor(ctx, user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequiredByOrg
+	}
+
+	ok, err := s.verifyCode(ctx, user, code, true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTOTPCode
+	}
+
+	user.TOTPSecret = nil
+	user.TOTPEnabledAt = nil
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to disable two-factor authentication")
+		return errors.New("failed to disable two-factor authentication")
+	}
+	if err := s.recoveryRepo.DeleteForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.log.WithField("user_id", user.ID).Info("two-factor authentication disabled")
+	return nil
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.HasTwoFactor() {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	ok, err := s.verifyCode(ctx, user, code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	return s.issueRecoveryCodes(ctx, user)
+}
+
+func (s *twoFactorService) beginEnrollment(ctx context.Context, user *domain.User) (*TOTPEnrollment, error) {
+	if user.HasTwoFactor() {
+		return nil, ErrTwoFactorAlreadyOn
+	}
+
+	secret, err := domain.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret is stored right away but stays inactive until confirmed.
+	user.TOTPSecret = &secret
+	user.TOTPEnabledAt = nil
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to store pending totp secret")
+		return nil, errors.New("failed to start two-factor enrollment")
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: domain.TOTPProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+func (s *twoFactorService) confirmEnrollment(ctx context.Context, user *domain.User, code string) ([]string, error) {
+	if user.HasTwoFactor() {
+		return nil, ErrTwoFactorAlreadyOn
+	}
+	if user.TOTPSecret == nil {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	ok, err := s.verifyCode(ctx, user, code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("failed to enable two-factor authentication")
+		return nil, errors.New("failed to enable two-factor authentication")
+	}
+
+	s.log.WithField("user_id", user.ID).Info("two-factor authentication enabled")
+	return s.issueRecoveryCodes(ctx, user)
+}
+
+func (s *twoFactorService) issueRecoveryCodes(ctx context.Context, user *domain.User) ([]string, error) {
+	codes, err := domain.GenerateRecoveryCodes(domain.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = domain.HashRecoveryCode(c)
+	}
+
+	if err := s.recoveryRepo.ReplaceForUser(ctx, user.ID, hashes); err != nil {
+		return nil, errors.New("failed to store recovery codes")
+	}
+	return codes, nil
+}
+
+// verifyCode accepts a current TOTP code, rejecting one that was already used
+// in its time step, or, when allowed, an unused recovery code.
+func (s *twoFactorService) verifyCode(ctx context.Context, user *domain.User, code string, allowRecovery bool) (bool, error) {
+	if user.TOTPSecret == nil {
+		return false, nil
+	}
+
+	if step, ok := domain.VerifyTOTP(*user.TOTPSecret, code, time.Now()); ok {
+		key := fmt.Sprintf("totp_used:%s:%d", user.ID, step)
+		ttl := time.Duration(2*domain.TOTPSkew+1) * domain.TOTPPeriod * time.Second
+		fresh, err := s.redis.SetNX(ctx, key, 1, ttl).Result()
+		if err != nil {
+			return false, fmt.Errorf("failed to record totp use: %w", err)
+		}
+		return fresh, nil
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+
+	used, err := s.recoveryRepo.Consume(ctx, user.ID, domain.HashRecoveryCode(code))
+	if err != nil {
+		return false, err
+	}
+	if used {
+		s.log.WithField("user_id", user.ID).Info("recovery code used")
+	}
+	return used, nil
+}
+
+func (s *twoFactorService) getUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// --- organization policy ---
+
+func (s *twoFactorService) GetPolicy(ctx context.Context) ([]string, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, errors.New("organization not found")
+	}
+	return org.MFARequiredRoles, nil
+}
+
+func (s *twoFactorService) UpdatePolicy(ctx context.Context, roleNames []string) error {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return errors.New("organization id not found")
+	}
+
+	if roleNames == nil {
+		roleNames = []string{}
+	}
+	return s.orgRepo.UpdateMFARequiredRoles(ctx, orgID, roleNames)
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+	invitationTTL       = 7 * 24 * time.Hour
+)
+
+var (
+	ErrCannotManageSelf       = errors.New("you cannot perform this action on your own account")
+	ErrInsufficientPrivileges = errors.New("you cannot manage users or roles with more access than your own")
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrEmailTaken             = errors.New("email is already in use")
+	ErrInvalidProfile         = errors.New("invalid profile")
+	ErrManagedElsewhere       = errors.New("this account is managed by another organization")
+	ErrHomeMembership         = errors.New("users cannot be removed from the organization that owns their account")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+)
+
+type userAdminService struct {
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	membershipRepo domain.MembershipRepository
+	invitationRepo domain.InvitationRepository
+	sessions       Sessions
+	verifier       EmailVerification
+	log            *logrus.Logger
+}
+
+func NewUserAdminService(ur domain.UserRepository, rr domain.RoleRepository, mr domain.MembershipRepository, ir domain.InvitationRepository, ss Sessions, v EmailVerification, log *logrus.Logger) UserManagement {
+	return &userAdminService{
+		userRepo:       ur,
+		roleRepo:       rr,
+		membershipRepo: mr,
+		invitationRepo: ir,
+		sessions:       ss,
+		verifier:       v,
+		log:            log,
+	}
+}
+
+func (s *userAdminService) List(ctx context.Context, filter *domain.UserFilter) ([]domain.User, int, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, 0, errors.New("organization id not found")
+	}
+
+	filter.OrganizationID = orgID
+	clampPage(filter)
+
+	return s.userRepo.List(ctx, *filter)
+}
+
+// clampPage fills in the default page size and keeps the page within bounds.
+func clampPage(filter *domain.UserFilter) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserPageSize
+	}
+	if filter.Limit > maxUserPageSize {
+		filter.Limit = maxUserPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+}
+
+func (s *userAdminService) Get(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	return s.getOrgUser(ctx, userID)
+}
+
+func (s *userAdminService) UpdateProfile(ctx context.Context, userID uuid.UUID, update UserProfileUpdate) (*domain.User, error) {
+	user, err := s.manageableAccount(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.FirstName != nil {
+		name := strings.TrimSpace(*update.FirstName)
+		if name == "" {
+			return nil, fmt.Errorf("%w: first name cannot be empty", ErrInvalidProfile)
+		}
+		user.FirstName = name
+	}
+	if update.LastName != nil {
+		user.LastName = strings.TrimSpace(*update.LastName)
+	}
+
+	emailChanged := false
+	if update.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*update.Email))
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			return nil, fmt.Errorf("%w: email is not a valid address", ErrInvalidProfile)
+		}
+		if email != user.Email {
+			existing, err := s.userRepo.GetByEmail(database.Unscoped(ctx), email)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, ErrEmailTaken
+			}
+			user.Email = email
+			// The new address has to be confirmed by its owner.
+			user.EmailVerifiedAt = nil
+			emailChanged = true
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if emailChanged {
+		if err := s.verifier.SendVerification(ctx, user); err != nil {
+			s.log.WithError(err).WithField("user_id", user.ID).Warn("failed to send verification email after email change")
+		}
+	}
+
+	s.log.WithField("user_id", user.ID).Info("user profile updated by admin")
+	return user, nil
+}
+
+func (s *userAdminService) Activate(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.manageableAccount(ctx, userID, false)
+	if err != nil {
+		return err
+	}
+	if user.IsActive() {
+		return nil
+	}
+
+	user.DeactivatedAt = nil
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	s.log.WithField("user_id", userID).Info("user activated")
+	return nil
+}
+
+func (s *userAdminService) Deactivate(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.manageableAccount(ctx, userID, false)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive() {
+		return nil
+	}
+
+	now := time.Now()
+	user.DeactivatedAt = &now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		return fmt.Errorf("failed to end sessions of deactivated user: %w", err)
+	}
+
+	s.log.WithField("user_id", userID).Info("user deactivated")
+	return nil
+}
+
+func (s *userAdminService) AssignRole(ctx context.Context, userID uuid.UUID, roleName string) (*domain.User, error) {
+	user, role, err := s.roleChange(ctx, userID, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasAnyRole(role.Name) {
+		if err := s.roleRepo.AssignRoleToUser(ctx, user.ID, user.OrganizationID, role.ID); err != nil {
+			return nil, err
+		}
+		user.Roles = append(user.Roles, *role)
+	}
+	return user, nil
+}
+
+func (s *userAdminService) RevokeRole(ctx context.Context, userID uuid.UUID, roleName string) (*domain.User, error) {
+	user, role, err := s.roleChange(ctx, userID, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.RevokeUserRole(ctx, user.ID, user.OrganizationID, role.ID); err != nil {
+		return nil, err
+	}
+
+	roles := user.Roles[:0]
+	for _, r := range user.Roles {
+		if r.Name != role.Name {
+			roles = append(roles, r)
+		}
+	}
+	user.Roles = roles
+	return user, nil
+}
+
+func (s *userAdminService) Delete(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.manageableAccount(ctx, userID, false); err != nil {
+		return err
+	}
+
+	var deletedBy *uuid.UUID
+	if callerID, ok := auth.GetUserID(ctx); ok {
+		deletedBy = &callerID
+	}
+
+	if err := s.userRepo.SoftDelete(ctx, userID, deletedBy); err != nil {
+		return err
+	}
+
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("failed to end sessions of deleted user")
+	}
+	return nil
+}
+
+// InviteMember never looks the email up, so the answer is the same whether
+// or not an account is registered under it. Service accounts cannot sign in
+// to accept, so they are never added this way either.
+func (s *userAdminService) InviteMember(ctx context.Context, email string, roleNames []string) (*domain.Invitation, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, fmt.Errorf("%w: email is not a valid address", ErrInvalidProfile)
+	}
+
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]domain.Role, 0, len(roleNames))
+	for _, name := range roleNames {
+		role, err := s.roleRepo.GetForOrganization(ctx, orgID, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role: %w", err)
+		}
+		if role == nil {
+			return nil, ErrRoleNotFound
+		}
+		if !caller.CanGrant(*role) {
+			return nil, ErrInsufficientPrivileges
+		}
+		roles = append(roles, *role)
+	}
+
+	now := time.Now()
+	invitation := &domain.Invitation{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Email:          email,
+		Roles:          roles,
+		InvitedBy:      &caller.ID,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(invitationTTL),
+	}
+	if err := s.invitationRepo.Save(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"invitation_id": invitation.ID, "org_id": orgID}).Info("member invited to organization")
+	return invitation, nil
+}
+
+func (s *userAdminService) ListInvitations(ctx context.Context) ([]domain.Invitation, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+	return s.invitationRepo.ListByOrganization(ctx, orgID)
+}
+
+func (s *userAdminService) RevokeInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return errors.New("organization id not found")
+	}
+
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation == nil || invitation.OrganizationID != orgID {
+		return ErrInvitationNotFound
+	}
+
+	if err := s.invitationRepo.Delete(ctx, invitation.ID); err != nil {
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{"invitation_id": invitation.ID, "org_id": orgID}).Info("invitation revoked")
+	return nil
+}
+
+// RemoveMember takes a guest out of the organization and ends the sessions
+// they have in it. Their account and other memberships are untouched.
+func (s *userAdminService) RemoveMember(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.manageable(ctx, userID, false)
+	if err != nil {
+		return err
+	}
+	if !user.IsGuest() {
+		return ErrHomeMembership
+	}
+
+	if err := s.membershipRepo.Remove(ctx, user.ID, user.OrganizationID); err != nil {
+		return err
+	}
+
+	sessions, err := s.sessions.List(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions of removed member: %w", err)
+	}
+	for _, session := range sessions {
+		if session.OrganizationID != user.OrganizationID {
+			continue
+		}
+		if err := s.sessions.Revoke(ctx, user.ID, session.ID); err != nil {
+			return fmt.Errorf("failed to end sessions of removed member: %w", err)
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{"user_id": user.ID, "org_id": user.OrganizationID}).Info("member removed from organization")
+	return nil
+}
+
+// roleChange loads the target user and the role, and checks that the caller
+// could hold the role themselves.
+func (s *userAdminService) roleChange(ctx context.Context, userID uuid.UUID, roleName string) (*domain.User, *domain.Role, error) {
+	user, err := s.manageable(ctx, userID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role, err := s.roleRepo.GetForOrganization(ctx, user.OrganizationID, roleName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	if role == nil {
+		return nil, nil, ErrRoleNotFound
+	}
+
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caller.CanGrant(*role) {
+		return nil, nil, ErrInsufficientPrivileges
+	}
+
+	return user, role, nil
+}
+
+// manageable returns the target user when the caller may administer it.
+// Callers can edit their own profile but not lock themselves out.
+func (s *userAdminService) manageable(ctx context.Context, userID uuid.UUID, allowSelf bool) (*domain.User, error) {
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if caller.ID == userID && !allowSelf {
+		return nil, ErrCannotManageSelf
+	}
+
+	user, err := s.getOrgUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.CanManage(*user) {
+		return nil, ErrInsufficientPrivileges
+	}
+	return user, nil
+}
+
+// manageableAccount is manageable for changes to the account itself, which
+// only the organization that owns it may make.
+func (s *userAdminService) manageableAccount(ctx context.Context, userID uuid.UUID, allowSelf bool) (*domain.User, error) {
+	user, err := s.manageable(ctx, userID, allowSelf)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsGuest() {
+		return nil, ErrManagedElsewhere
+	}
+	return user, nil
+}
+
+func (s *userAdminService) caller(ctx context.Context) (*domain.User, error) {
+	return currentUser(ctx, s.userRepo)
+}
+
+// currentUser loads the authenticated user from the database, with the
+// roles they hold in the current organization rather than any narrower API
+// key scopes.
+func currentUser(ctx context.Context, ur domain.UserRepository) (*domain.User, error) {
+	callerID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, errors.New("user id not found")
+	}
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	caller, err := ur.GetInOrganization(ctx, callerID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if caller == nil {
+		return nil, ErrUserNotFound
+	}
+	return caller, nil
+}
+
+func (s *userAdminService) getOrgUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	orgID, ok := auth.GetOrgID(ctx)
+	if !ok {
+		return nil, errors.New("organization id not found")
+	}
+
+	user, err := s.userRepo.GetInOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
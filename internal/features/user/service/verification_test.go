@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// verifiedRepo records the users written back by Update.
+type verifiedRepo struct {
+	membersRepo
+	updated []uuid.UUID
+}
+
+func (r *verifiedRepo) Update(ctx context.Context, user *domain.User) error {
+	r.updated = append(r.updated, user.ID)
+	return nil
+}
+
+func TestMarkVerified(t *testing.T) {
+	admin := domain.Role{Name: "admin", Permissions: map[string][]string{domain.AllResources: {domain.ManageAction}}}
+	teacher := domain.Role{Name: "teacher", Permissions: map[string][]string{"user": {"read"}, "course": {"create"}}}
+	student := domain.Role{Name: "student", Permissions: map[string][]string{"course": {"read"}}}
+
+	orgID := uuid.New()
+	newUser := func(homeOrgID uuid.UUID, roles ...domain.Role) *domain.User {
+		u := &domain.User{Roles: roles, HomeOrganizationID: homeOrgID}
+		u.ID = uuid.New()
+		u.OrganizationID = orgID
+		return u
+	}
+	orgAdmin := newUser(orgID, admin)
+	orgTeacher := newUser(orgID, teacher)
+	orgStudent := newUser(orgID, student)
+	otherAdmin := newUser(orgID, admin)
+	guest := newUser(uuid.New(), student)
+
+	tests := []struct {
+		name    string
+		caller  *domain.User
+		target  *domain.User
+		wantErr error
+	}{
+		{"Admin verifies a student", orgAdmin, orgStudent, nil},
+		{"Admin verifies another admin", orgAdmin, otherAdmin, nil},
+		{"Teacher cannot verify an admin", orgTeacher, orgAdmin, ErrInsufficientPrivileges},
+		{"Guest accounts belong to their home organization", orgAdmin, guest, ErrManagedElsewhere},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &verifiedRepo{membersRepo: membersRepo{users: map[uuid.UUID]*domain.User{
+				tt.caller.ID: tt.caller,
+				tt.target.ID: tt.target,
+			}}}
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+			s := NewVerificationService(repo, nil, "secret", "", 0, log)
+
+			ctx := context.WithValue(context.Background(), auth.UserIDKey, tt.caller.ID)
+			ctx = context.WithValue(ctx, auth.OrgIDKey, orgID)
+			err := s.MarkVerified(ctx, tt.target.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkVerified() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantUpdated := tt.wantErr == nil; (len(repo.updated) == 1) != wantUpdated {
+				t.Errorf("updated = %v, want update %v", repo.updated, wantUpdated)
+			}
+		})
+	}
+}
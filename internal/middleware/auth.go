@@ -5,14 +5,26 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
 	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
 )
 
 
-func AuthMiddleware(tokenProvider auth.TokenProvider) func(http.Handler) http.Handler {
+// AuthMiddleware accepts a Bearer JWT, or an API key sent either as the
+// Bearer credential or in the X-API-Key header.
+func AuthMiddleware(tokenProvider auth.TokenProvider, apiKeys auth.APIKeyAuthenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func (w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
+			apiKey := r.Header.Get("X-API-Key")
+			if apiKey == "" && strings.HasPrefix(authHeader, "Bearer "+domain.APIKeyPrefix) {
+				apiKey = strings.TrimPrefix(authHeader, "Bearer ")
+			}
+			if apiKey != "" {
+				authenticateAPIKey(apiKeys, apiKey, next, w, r)
+				return
+			}
+
 			if !strings.HasPrefix(authHeader, "Bearer ") {
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
@@ -25,15 +37,44 @@ func AuthMiddleware(tokenProvider auth.TokenProvider) func(http.Handler) http.Ha
 				return
 			}
 			
-			blacklisted, err := tokenProvider.IsBlacklisted(r.Context(), tokenString)
+			blacklisted, err := tokenProvider.IsBlacklisted(r.Context(), claims)
 			if err != nil || blacklisted {
 				http.Error(w, "Token has been revoked", http.StatusUnauthorized)
 				return
 			}
+
+			revoked, err := tokenProvider.IsRevokedForUser(r.Context(), claims)
+			if err != nil || revoked {
+				http.Error(w, "Token has been revoked", http.StatusUnauthorized)
+				return
+			}
 			
 			ctx := context.WithValue(r.Context(), auth.UserIDKey, claims.UserID)
 			ctx = context.WithValue(ctx, auth.OrgIDKey, claims.OrganizationID)
+			ctx = context.WithValue(ctx, auth.SessionIDKey, claims.SessionID)
+			if claims.Actor != nil {
+				ctx = context.WithValue(ctx, auth.ActorIDKey, claims.Actor.Subject)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
-}
\ No newline at end of file
+}
+
+func authenticateAPIKey(apiKeys auth.APIKeyAuthenticator, key string, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	if apiKeys == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	principal, err := apiKeys.Authenticate(r.Context(), key)
+	if err != nil {
+		http.Error(w, "Invalid API key", http.StatusUnauthorized)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), auth.UserIDKey, principal.UserID)
+	ctx = context.WithValue(ctx, auth.OrgIDKey, principal.OrganizationID)
+	ctx = context.WithValue(ctx, auth.APIKeyIDKey, principal.KeyID)
+	ctx = context.WithValue(ctx, auth.ScopesKey, principal.Scopes)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// AuditImpersonation must run after AuthMiddleware. Requests made with an
+// impersonation token are logged with both identities, and every request
+// that can change state is also recorded in the impersonation audit trail.
+func AuditImpersonation(events domain.ImpersonationRepository, log *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			actorID, ok := auth.GetActorID(ctx)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			userID, _ := auth.GetUserID(ctx)
+			orgID, _ := auth.GetOrgID(ctx)
+			sessionID, _ := auth.GetSessionID(ctx)
+			requestID := chiMiddleware.GetReqID(ctx)
+
+			log.WithFields(logrus.Fields{
+				"user_id":    userID,
+				"act":        actorID,
+				"session_id": sessionID,
+				"request_id": requestID,
+				"method":     r.Method,
+				"path":       r.URL.Path,
+				"status":     ww.Status(),
+			}).Info("impersonated request")
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return
+			}
+
+			event := domain.NewImpersonationEvent(domain.ImpersonationWrite, orgID, actorID, userID, sessionID)
+			event.Method = r.Method
+			event.Path = r.URL.Path
+			event.Status = ww.Status()
+			event.RequestID = requestID
+			event.IPAddress = r.RemoteAddr
+			if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+				event.IPAddress = host
+			}
+			// The client may already be gone; the record is still owed.
+			if err := events.Create(context.WithoutCancel(ctx), event); err != nil {
+				log.WithError(err).WithFields(logrus.Fields{"user_id": userID, "act": actorID}).Error("failed to record impersonated write")
+			}
+		})
+	}
+}
+
+// DenyImpersonation keeps impersonation tokens away from routes that change
+// how the user signs in, such as passwords, second factors and sessions.
+func DenyImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.GetActorID(r.Context()); ok {
+			response.Error(w, http.StatusForbidden, "IMPERSONATION_NOT_ALLOWED", "This action is not available while impersonating")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+
+	o "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+)
+
+// RequireActiveOrganization must run after AuthMiddleware. It rejects
+// requests made in an organization that has been suspended, which covers
+// access tokens issued before the suspension as well as API keys.
+func RequireActiveOrganization(orgRepo o.OrganizationRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			orgID, ok := auth.GetOrgID(r.Context())
+			if !ok {
+				response.Unauthorized(w, "Organization not found in context")
+				return
+			}
+
+			org, err := orgRepo.GetByID(r.Context(), orgID)
+			if err != nil {
+				response.InternalServerError(w, "Failed to load organization")
+				return
+			}
+			if org == nil || org.IsSuspended() {
+				response.Error(w, http.StatusForbidden, "ORGANIZATION_SUSPENDED", "organization has been suspended")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSystemAdmin lets through only superusers signed in to the system
+// organization. API keys and impersonated sessions are refused, so every
+// cross-tenant action is taken by a person under their own name.
+func RequireSystemAdmin(orgRepo o.OrganizationRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if _, ok := auth.GetAPIKeyID(ctx); ok {
+				response.Forbidden(w, "System administration is not available to API keys")
+				return
+			}
+			if _, ok := auth.GetActorID(ctx); ok {
+				response.Forbidden(w, "System administration is not available while impersonating")
+				return
+			}
+
+			user, err := CurrentUser(ctx)
+			if err != nil {
+				writePrincipalError(w, err)
+				return
+			}
+
+			org, err := orgRepo.GetByID(ctx, user.OrganizationID)
+			if err != nil {
+				response.InternalServerError(w, "Failed to load organization")
+				return
+			}
+			if !user.IsSuperuser || org == nil || !org.IsSystem() {
+				response.Forbidden(w, "System administration requires a superuser of the system organization")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
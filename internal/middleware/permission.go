@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+)
+
+type principalKey struct{}
+
+// ErrNoPrincipal means the request carries no usable identity, as opposed
+// to the user failing to load.
+var ErrNoPrincipal = errors.New("no principal for this request")
+
+// principal lazily loads the authenticated user (with roles and permissions)
+// at most once per request, no matter how many permission checks run.
+type principal struct {
+	once sync.Once
+	load func() (*domain.User, error)
+	user *domain.User
+	err  error
+}
+
+func (p *principal) get() (*domain.User, error) {
+	p.once.Do(func() {
+		p.user, p.err = p.load()
+	})
+	return p.user, p.err
+}
+
+// LoadPrincipal must run after AuthMiddleware. It does not hit the database
+// itself; the user is fetched the first time a handler or RequirePermission
+// asks for it.
+func LoadPrincipal(userRepo domain.UserRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			p := &principal{load: func() (*domain.User, error) {
+				userID, ok := auth.GetUserID(ctx)
+				if !ok {
+					return nil, fmt.Errorf("%w: user id not found in context", ErrNoPrincipal)
+				}
+				orgID, ok := auth.GetOrgID(ctx)
+				if !ok {
+					return nil, fmt.Errorf("%w: organization id not found in context", ErrNoPrincipal)
+				}
+				user, err := userRepo.GetInOrganization(ctx, userID, orgID)
+				if err != nil {
+					return nil, err
+				}
+				if user == nil {
+					return nil, fmt.Errorf("%w: user is not a member of the organization", ErrNoPrincipal)
+				}
+				if !user.IsActive() {
+					return nil, fmt.Errorf("%w: account has been deactivated", ErrNoPrincipal)
+				}
+				// An API key acts with its scopes, whatever roles its
+				// service account may hold.
+				if scopes, ok := auth.GetScopes(ctx); ok {
+					user.Roles = []domain.Role{{Name: "api_key", Permissions: scopes}}
+				}
+				return user, nil
+			}}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, principalKey{}, p)))
+		})
+	}
+}
+
+// CurrentUser returns the authenticated user cached for this request.
+func CurrentUser(ctx context.Context) (*domain.User, error) {
+	p, ok := ctx.Value(principalKey{}).(*principal)
+	if !ok {
+		return nil, fmt.Errorf("%w: principal not loaded", ErrNoPrincipal)
+	}
+	return p.get()
+}
+
+// RequirePermission rejects the request with 403 unless one of the caller's
+// roles grants action on resource. Superusers always pass.
+func RequirePermission(resource, action string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := CurrentUser(r.Context())
+			if err != nil {
+				writePrincipalError(w, err)
+				return
+			}
+
+			if !user.CanPerform(resource, action) {
+				response.Forbidden(w, "You do not have permission to "+action+" "+resource)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writePrincipalError answers 401 when the caller has no usable identity.
+// Failures to load the user are server errors, so an outage does not look
+// like every client being signed out.
+func writePrincipalError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNoPrincipal) {
+		response.Unauthorized(w, "Unable to resolve the current user")
+		return
+	}
+	response.InternalServerError(w, "Failed to load the current user")
+}
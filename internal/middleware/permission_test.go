@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/domain"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+)
+
+// stubUserRepo answers GetInOrganization only.
+type stubUserRepo struct {
+	domain.UserRepository
+	user *domain.User
+	err  error
+}
+
+func (r stubUserRepo) GetInOrganization(ctx context.Context, id, orgID uuid.UUID) (*domain.User, error) {
+	return r.user, r.err
+}
+
+func TestRequirePermissionPrincipalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		repo stubUserRepo
+		want int
+	}{
+		{"Database error", stubUserRepo{err: errors.New("connection refused")}, http.StatusInternalServerError},
+		{"Not a member", stubUserRepo{}, http.StatusUnauthorized},
+		{"Permitted", stubUserRepo{user: &domain.User{IsSuperuser: true}}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := LoadPrincipal(tt.repo)(RequirePermission("course", "read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx := context.WithValue(req.Context(), auth.UserIDKey, uuid.New())
+			ctx = context.WithValue(ctx, auth.OrgIDKey, uuid.New())
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req.WithContext(ctx))
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	orgService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	response "github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/utils"
+)
+
+// TenantPathPrefix addresses an organization without a subdomain of its
+// own, as in /o/{slug}/api/v1/auth/login.
+const TenantPathPrefix = "/o/"
+
+// reservedSubdomains are hosts under the base domain that serve the
+// platform itself rather than one organization.
+var reservedSubdomains = map[string]bool{"www": true, "api": true}
+
+// ResolveTenant finds the organization a request is addressed to, from a
+// /o/{slug} path prefix or else from a {slug}.{baseDomain} host, and stores
+// it for auth.GetTenantID. The prefix is stripped before routing, so it must
+// be installed on the root router. Hosts are ignored when baseDomain is
+// empty, and requests naming neither pass through unchanged.
+func ResolveTenant(tenants orgService.TenantResolver, baseDomain string) func(http.Handler) http.Handler {
+	baseDomain = strings.ToLower(strings.Trim(baseDomain, "."))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hostSlug := slugFromHost(r.Host, baseDomain)
+			pathSlug, rest, fromPath := slugFromPath(r.URL.Path)
+			if fromPath && hostSlug != "" && pathSlug != hostSlug {
+				response.BadRequest(w, "Host and path name different organizations")
+				return
+			}
+
+			slug := hostSlug
+			if fromPath {
+				slug = pathSlug
+			}
+			if slug == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			orgID, err := tenants.ResolveSlug(r.Context(), slug)
+			if errors.Is(err, orgService.ErrOrganizationNotFound) {
+				response.NotFound(w, "Organization not found")
+				return
+			}
+			if err != nil {
+				response.InternalServerError(w, "Failed to resolve organization")
+				return
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), auth.TenantIDKey, orgID))
+			if fromPath {
+				r.URL = stripTenantPrefix(r.URL, rest)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireTenantMatch must run after AuthMiddleware. It refuses tokens and
+// API keys of one organization at another organization's address, so a
+// school's pages only ever act on that school.
+func RequireTenantMatch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, ok := auth.GetTenantID(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if orgID, _ := auth.GetOrgID(r.Context()); orgID != tenantID {
+			response.Error(w, http.StatusForbidden, "TENANT_MISMATCH", "This token belongs to a different organization")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// slugFromPath splits /o/{slug}/rest into the slug and /rest.
+func slugFromPath(path string) (slug, rest string, ok bool) {
+	if !strings.HasPrefix(path, TenantPathPrefix) {
+		return "", path, false
+	}
+	slug, rest, _ = strings.Cut(strings.TrimPrefix(path, TenantPathPrefix), "/")
+	if slug == "" {
+		return "", path, false
+	}
+	return strings.ToLower(slug), "/" + rest, true
+}
+
+// stripTenantPrefix returns u addressed to rest. The slug segment of the raw
+// path may differ from the slug in case or escaping, so it is cut at its own
+// end; a raw path that no longer encodes rest is dropped for the decoded one.
+func stripTenantPrefix(u *url.URL, rest string) *url.URL {
+	stripped := *u
+	stripped.Path = rest
+	stripped.RawPath = ""
+	if raw, ok := strings.CutPrefix(u.RawPath, TenantPathPrefix); ok {
+		_, rawRest, _ := strings.Cut(raw, "/")
+		stripped.RawPath = "/" + rawRest
+		if stripped.EscapedPath() != stripped.RawPath {
+			stripped.RawPath = ""
+		}
+	}
+	return &stripped
+}
+
+// slugFromHost returns the label in front of baseDomain. Deeper subdomains
+// and reserved labels name no organization.
+func slugFromHost(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	label, ok := strings.CutSuffix(host, "."+baseDomain)
+	if !ok || label == "" || strings.Contains(label, ".") || reservedSubdomains[label] {
+		return ""
+	}
+	return label
+}
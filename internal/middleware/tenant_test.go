@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	orgService "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/organization/service"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+)
+
+// fakeTenants resolves the slugs it holds and fails with err when set.
+type fakeTenants struct {
+	slugs map[string]uuid.UUID
+	err   error
+}
+
+func (f fakeTenants) ResolveSlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	if f.err != nil {
+		return uuid.Nil, f.err
+	}
+	id, ok := f.slugs[slug]
+	if !ok {
+		return uuid.Nil, orgService.ErrOrganizationNotFound
+	}
+	return id, nil
+}
+
+func TestSlugFromHost(t *testing.T) {
+	tests := []struct {
+		host, baseDomain, want string
+	}{
+		{"smanegeri1.lms.example", "lms.example", "smanegeri1"},
+		{"SMANegeri1.LMS.example:8443", "lms.example", "smanegeri1"},
+		{"smanegeri1.lms.example.", "lms.example", "smanegeri1"},
+		{"lms.example", "lms.example", ""},
+		{"www.lms.example", "lms.example", ""},
+		{"a.b.lms.example", "lms.example", ""},
+		{"evil-lms.example", "lms.example", ""},
+		{"smanegeri1.lms.example", "", ""},
+		{"localhost:8000", "lms.example", ""},
+	}
+	for _, tt := range tests {
+		if got := slugFromHost(tt.host, tt.baseDomain); got != tt.want {
+			t.Errorf("slugFromHost(%q, %q) = %q, want %q", tt.host, tt.baseDomain, got, tt.want)
+		}
+	}
+}
+
+func TestSlugFromPath(t *testing.T) {
+	tests := []struct {
+		path, slug, rest string
+		ok               bool
+	}{
+		{"/o/smanegeri1/api/v1/auth/login", "smanegeri1", "/api/v1/auth/login", true},
+		{"/o/smanegeri1", "smanegeri1", "/", true},
+		{"/o/", "", "/o/", false},
+		{"/api/v1/auth/login", "", "/api/v1/auth/login", false},
+		{"/organizations/x", "", "/organizations/x", false},
+	}
+	for _, tt := range tests {
+		slug, rest, ok := slugFromPath(tt.path)
+		if slug != tt.slug || rest != tt.rest || ok != tt.ok {
+			t.Errorf("slugFromPath(%q) = %q, %q, %v, want %q, %q, %v", tt.path, slug, rest, ok, tt.slug, tt.rest, tt.ok)
+		}
+	}
+}
+
+func TestResolveTenant(t *testing.T) {
+	sma := uuid.New()
+	tenants := fakeTenants{slugs: map[string]uuid.UUID{"sma": sma, "smp": uuid.New()}}
+
+	tests := []struct {
+		name          string
+		tenants       fakeTenants
+		host, target  string
+		code          int
+		tenant        uuid.UUID
+		path, rawPath string
+	}{
+		{"Path prefix", tenants, "api.lms.example", "/o/sma/api/v1/auth/login", http.StatusOK, sma, "/api/v1/auth/login", ""},
+		{"Mixed case slug", tenants, "", "/o/SMA/api/v1/files/a%2Fb", http.StatusOK, sma, "/api/v1/files/a/b", "/api/v1/files/a%2Fb"},
+		{"Escaped slug", tenants, "", "/o/sm%61/api/v1/files/a%2Fb", http.StatusOK, sma, "/api/v1/files/a/b", "/api/v1/files/a%2Fb"},
+		{"Subdomain", tenants, "sma.lms.example", "/api/v1/auth/login", http.StatusOK, sma, "/api/v1/auth/login", ""},
+		{"Host and path agree", tenants, "sma.lms.example", "/o/sma/api/v1/auth/login", http.StatusOK, sma, "/api/v1/auth/login", ""},
+		{"Host and path differ", tenants, "sma.lms.example", "/o/smp/api/v1/auth/login", http.StatusBadRequest, uuid.Nil, "", ""},
+		{"Unknown slug", tenants, "", "/o/nope/api/v1/auth/login", http.StatusNotFound, uuid.Nil, "", ""},
+		{"Resolver down", fakeTenants{err: errors.New("connection refused")}, "", "/o/sma/api/v1/auth/login", http.StatusInternalServerError, uuid.Nil, "", ""},
+		{"No tenant", tenants, "lms.example", "/api/v1/auth/login", http.StatusOK, uuid.Nil, "/api/v1/auth/login", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			h := ResolveTenant(tt.tenants, "lms.example")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.code {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.code)
+			}
+			if tt.code != http.StatusOK {
+				if got != nil {
+					t.Error("next handler was called")
+				}
+				return
+			}
+
+			tenantID, ok := auth.GetTenantID(got.Context())
+			if ok != (tt.tenant != uuid.Nil) || tenantID != tt.tenant {
+				t.Errorf("tenant = %v, %v, want %v", tenantID, ok, tt.tenant)
+			}
+			if got.URL.Path != tt.path || got.URL.RawPath != tt.rawPath {
+				t.Errorf("path = %q (raw %q), want %q (raw %q)", got.URL.Path, got.URL.RawPath, tt.path, tt.rawPath)
+			}
+		})
+	}
+}
+
+func TestRequireTenantMatch(t *testing.T) {
+	own, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name   string
+		tenant uuid.UUID
+		orgID  uuid.UUID
+		want   int
+	}{
+		{"Same organization", own, own, http.StatusOK},
+		{"Token of another organization", own, other, http.StatusForbidden},
+		{"No tenant in the address", uuid.Nil, other, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := RequireTenantMatch(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			ctx := context.WithValue(context.Background(), auth.OrgIDKey, tt.orgID)
+			if tt.tenant != uuid.Nil {
+				ctx = context.WithValue(ctx, auth.TenantIDKey, tt.tenant)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/courses", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
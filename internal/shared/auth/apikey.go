@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidAPIKey = errors.New("invalid or expired api key")
+
+// APIKeyPrincipal is who an API key authenticates as.
+type APIKeyPrincipal struct {
+	KeyID          uuid.UUID
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	Scopes         map[string][]string
+}
+
+// APIKeyAuthenticator resolves a presented API key. Implementations return
+// ErrInvalidAPIKey for unknown, expired and revoked keys alike.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*APIKeyPrincipal, error)
+}
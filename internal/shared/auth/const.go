@@ -11,6 +11,11 @@ type contextKey string
 const (
 	UserIDKey contextKey = "userID"
 	OrgIDKey contextKey = "orgID"
+	SessionIDKey contextKey = "sessionID"
+	ActorIDKey contextKey = "actorID"
+	APIKeyIDKey contextKey = "apiKeyID"
+	ScopesKey contextKey = "scopes"
+	TenantIDKey contextKey = "tenantID"
 )
 
 func GetUserID(ctx context.Context) (uuid.UUID, bool) {
@@ -21,4 +26,37 @@ func GetUserID(ctx context.Context) (uuid.UUID, bool) {
 func GetOrgID(ctx context.Context) (uuid.UUID, bool) {
 	orgID, ok := ctx.Value(OrgIDKey).(uuid.UUID)
 	return orgID, ok
-}
\ No newline at end of file
+}
+
+func GetSessionID(ctx context.Context) (uuid.UUID, bool) {
+	sessionID, ok := ctx.Value(SessionIDKey).(uuid.UUID)
+	return sessionID, ok
+}
+
+// GetActorID returns the real user behind an impersonation token. It is
+// absent for regular tokens.
+func GetActorID(ctx context.Context) (uuid.UUID, bool) {
+	actorID, ok := ctx.Value(ActorIDKey).(uuid.UUID)
+	return actorID, ok
+}
+
+// GetAPIKeyID returns the key a request authenticated with. It is absent for
+// requests that carry a JWT.
+func GetAPIKeyID(ctx context.Context) (uuid.UUID, bool) {
+	keyID, ok := ctx.Value(APIKeyIDKey).(uuid.UUID)
+	return keyID, ok
+}
+
+// GetScopes returns the permissions an API key was limited to.
+func GetScopes(ctx context.Context) (map[string][]string, bool) {
+	scopes, ok := ctx.Value(ScopesKey).(map[string][]string)
+	return scopes, ok
+}
+
+// GetTenantID returns the organization named by the request's host or path
+// prefix. Unlike GetOrgID it is set before authentication and does not
+// scope database access on its own.
+func GetTenantID(ctx context.Context) (uuid.UUID, bool) {
+	tenantID, ok := ctx.Value(TenantIDKey).(uuid.UUID)
+	return tenantID, ok
+}
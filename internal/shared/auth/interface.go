@@ -8,8 +8,18 @@ import (
 )
 
 type TokenProvider interface {
-	GenerateToken(userID uuid.UUID, orgID uuid.UUID) (string, error)
+	GenerateToken(userID uuid.UUID, orgID uuid.UUID, sessionID uuid.UUID) (string, error)
+	GenerateImpersonationToken(userID, orgID, actorID uuid.UUID, ttl time.Duration) (*TokenPair, error)
 	ValidateToken(token string) (*CustomClaims, error)
-	BlacklistToken(ctx context.Context, token string, expiration time.Duration) error
-    IsBlacklisted(ctx context.Context, token string) (bool, error)
-}
\ No newline at end of file
+	BlacklistToken(ctx context.Context, claims *CustomClaims) error
+    IsBlacklisted(ctx context.Context, claims *CustomClaims) (bool, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID) error
+	IsRevokedForUser(ctx context.Context, claims *CustomClaims) (bool, error)
+
+	GenerateTokenPair(ctx context.Context, userID uuid.UUID, orgID uuid.UUID) (*TokenPair, error)
+	RotateRefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+
+	JWKS() *JWKS
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,45 +13,102 @@ import (
 )
 
 type jwtProvider struct {
-	secretKey []byte
+	keys *KeySet
 	expiryDuration time.Duration
+	refreshExpiryDuration time.Duration
 	redis *redis.Client
 }
 
-func NewJWTProvider(secret string, expiry time.Duration, redisClient *redis.Client) TokenProvider {
+func NewJWTProvider(keys *KeySet, expiry time.Duration, refreshExpiry time.Duration, redisClient *redis.Client) TokenProvider {
 	return &jwtProvider{
-		secretKey: []byte(secret),
+		keys: keys,
 		expiryDuration: expiry,
+		refreshExpiryDuration: refreshExpiry,
 		redis: redisClient,
 	}
 }
 
+// CustomClaims identify the user and the login session a token belongs to.
+// The registered jti claim (ID) names the token itself.
 type CustomClaims struct {
 	UserID         uuid.UUID `json:"user_id"`
 	OrganizationID uuid.UUID `json:"org_id"`
+	SessionID      uuid.UUID `json:"sid"`
+	// Actor is set on impersonation tokens and names who is really acting.
+	Actor *Actor `json:"act,omitempty"`
+	// IssuedAtNanos is iat in nanoseconds, precise enough to tell a token
+	// issued right after RevokeUserTokens from one issued before it.
+	IssuedAtNanos int64 `json:"iat_ns,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (j *jwtProvider) GenerateToken(userID uuid.UUID, orgID uuid.UUID) (string, error) {
-	claims := CustomClaims {
+// Actor is the RFC 8693 "act" claim.
+type Actor struct {
+	Subject uuid.UUID `json:"sub"`
+}
+
+func (j *jwtProvider) GenerateToken(userID uuid.UUID, orgID uuid.UUID, sessionID uuid.UUID) (string, error) {
+	return j.sign(j.newClaims(userID, orgID, sessionID, j.expiryDuration))
+}
+
+// GenerateImpersonationToken issues an access token for userID on behalf of
+// actorID. It gets its own session and no refresh token, and lives for ttl
+// but never longer than a regular access token.
+func (j *jwtProvider) GenerateImpersonationToken(userID, orgID, actorID uuid.UUID, ttl time.Duration) (*TokenPair, error) {
+	if ttl <= 0 || ttl > j.expiryDuration {
+		ttl = j.expiryDuration
+	}
+
+	claims := j.newClaims(userID, orgID, uuid.New(), ttl)
+	claims.Actor = &Actor{Subject: actorID}
+
+	token, err := j.sign(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{AccessToken: token, ExpiresIn: ttl, SessionID: claims.SessionID}, nil
+}
+
+func (j *jwtProvider) newClaims(userID, orgID, sessionID uuid.UUID, ttl time.Duration) CustomClaims {
+	now := time.Now()
+	return CustomClaims {
 		UserID: userID,
 		OrganizationID: orgID,
+		SessionID: sessionID,
+		IssuedAtNanos: now.UnixNano(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiryDuration)),
-			IssuedAt: jwt.NewNumericDate(time.Now()),
+			ID: uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt: jwt.NewNumericDate(now),
 		},
 	}
+}
+
+func (j *jwtProvider) sign(claims CustomClaims) (string, error) {
+	key, err := j.keys.SigningKey(time.Now())
+	if err != nil {
+		return "", err
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secretKey)
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.Private)
 }
 
 func (j *jwtProvider) ValidateToken(tokenString string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := t.Header["kid"].(string)
+		key, err := j.keys.VerificationKey(kid, time.Now(), j.expiryDuration)
+		if err != nil {
+			return nil, err
+		}
+		// Pin the algorithm to the key so a token cannot pick how it is checked.
+		if t.Method.Alg() != key.Method.Alg() {
             return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
         }
-        return j.secretKey, nil
+        return key.Public, nil
 	})
 
 	if err != nil {
@@ -69,13 +127,105 @@ func (j *jwtProvider) ValidateToken(tokenString string) (*CustomClaims, error) {
 	return claims, nil
 }
 
-func (j *jwtProvider) BlacklistToken(ctx context.Context, token string, expiration time.Duration) error {
-    key := "blacklist:" + token
-    return j.redis.Set(ctx, key, "true", expiration).Err()
+// BlacklistToken rejects the token identified by claims until it would have
+// expired anyway, so the entry never outlives the token.
+func (j *jwtProvider) BlacklistToken(ctx context.Context, claims *CustomClaims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	return j.redis.Set(ctx, "blacklist:"+claims.ID, "true", ttl).Err()
+}
+
+// IsBlacklisted reports whether the token itself or the session it belongs
+// to has been revoked.
+func (j *jwtProvider) IsBlacklisted(ctx context.Context, claims *CustomClaims) (bool, error) {
+	keys := []string{"revoked_session:" + claims.SessionID.String()}
+	if claims.ID != "" {
+		keys = append(keys, "blacklist:"+claims.ID)
+	}
+	exists, err := j.redis.Exists(ctx, keys...).Result()
+	return exists > 0, err
+}
+
+// RevokeSession ends a single login session: its refresh token family stops
+// rotating and access tokens already issued to it are rejected until they
+// expire.
+func (j *jwtProvider) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	pipe := j.redis.TxPipeline()
+	pipe.Del(ctx, refreshFamilyKey(sessionID.String()))
+	pipe.Set(ctx, "revoked_session:"+sessionID.String(), "true", j.expiryDuration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RevokeUserTokens invalidates every access and refresh token issued to the
+// user up to now. Tokens issued afterwards, even within the same second, are
+// unaffected.
+func (j *jwtProvider) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	return j.redis.Set(ctx, revokedBeforeKey(userID), time.Now().UnixNano(), j.refreshExpiryDuration).Err()
+}
+
+// revokedBeforeKey holds the revocation time in nanoseconds. Entries under
+// the older revoked_before:<user> key are in seconds; they are still honoured
+// until they expire.
+func revokedBeforeKey(userID uuid.UUID) string {
+	return "revoked_before_ns:" + userID.String()
+}
+
+// IsRevokedForUser also checks the actor of an impersonation token, so that
+// deactivating the support account ends its impersonations too.
+func (j *jwtProvider) IsRevokedForUser(ctx context.Context, claims *CustomClaims) (bool, error) {
+	if claims.IssuedAt == nil {
+		return false, nil
+	}
+	issuedAt := claims.IssuedAtNanos
+	if issuedAt == 0 {
+		issuedAt = claims.IssuedAt.UnixNano()
+	}
+	revoked, err := j.issuedBeforeRevocation(ctx, claims.UserID, issuedAt)
+	if err != nil || revoked || claims.Actor == nil {
+		return revoked, err
+	}
+	return j.issuedBeforeRevocation(ctx, claims.Actor.Subject, issuedAt)
+}
+
+// issuedBeforeRevocation takes issuedAt in nanoseconds.
+func (j *jwtProvider) issuedBeforeRevocation(ctx context.Context, userID uuid.UUID, issuedAt int64) (bool, error) {
+	vals, err := j.redis.MGet(ctx, revokedBeforeKey(userID), "revoked_before:"+userID.String()).Result()
+	if err != nil {
+		return false, err
+	}
+	if v, ok := vals[0].(string); ok {
+		revokedBefore, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		if issuedAt < revokedBefore {
+			return true, nil
+		}
+	}
+	if v, ok := vals[1].(string); ok {
+		revokedBefore, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		if issuedAt/int64(time.Second) <= revokedBefore {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
-func (j *jwtProvider) IsBlacklisted(ctx context.Context, token string) (bool, error) {
-    key := "blacklist:" + token
-    exists, err := j.redis.Exists(ctx, key).Result()
-    return exists > 0, err
-}
\ No newline at end of file
+func (j *jwtProvider) JWKS() *JWKS {
+	set := &JWKS{Keys: []JWK{}}
+	for _, k := range j.keys.PublicKeys(time.Now(), j.expiryDuration) {
+		if jwk, ok := toJWK(k); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRevokeUserTokens(t *testing.T) {
+	ctx := context.Background()
+	j, _ := newTestProvider(t)
+	userID, orgID := uuid.New(), uuid.New()
+
+	before, err := j.GenerateTokenPair(ctx, userID, orgID)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	if err := j.RevokeUserTokens(ctx, userID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+	// A password reset signs the user in again within the same second.
+	after, err := j.GenerateTokenPair(ctx, userID, orgID)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	isRevoked := func(accessToken string) bool {
+		t.Helper()
+		claims, err := j.ValidateToken(accessToken)
+		if err != nil {
+			t.Fatalf("ValidateToken() error = %v", err)
+		}
+		revoked, err := j.IsRevokedForUser(ctx, claims)
+		if err != nil {
+			t.Fatalf("IsRevokedForUser() error = %v", err)
+		}
+		return revoked
+	}
+
+	if !isRevoked(before.AccessToken) {
+		t.Error("access token issued before the revocation is still accepted")
+	}
+	if _, err := j.RotateRefreshToken(ctx, before.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("refresh token issued before the revocation: error = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	if isRevoked(after.AccessToken) {
+		t.Error("access token issued right after the revocation is rejected")
+	}
+	if _, err := j.RotateRefreshToken(ctx, after.RefreshToken); err != nil {
+		t.Errorf("refresh token issued right after the revocation: error = %v", err)
+	}
+}
+
+func TestRevokeUserTokensImpersonation(t *testing.T) {
+	ctx := context.Background()
+	j, _ := newTestProvider(t)
+	actorID := uuid.New()
+
+	pair, err := j.GenerateImpersonationToken(uuid.New(), uuid.New(), actorID, 0)
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken() error = %v", err)
+	}
+	if err := j.RevokeUserTokens(ctx, actorID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+
+	claims, err := j.ValidateToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if revoked, err := j.IsRevokedForUser(ctx, claims); err != nil || !revoked {
+		t.Errorf("IsRevokedForUser() = %v, %v, want the actor's revocation to apply", revoked, err)
+	}
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// SigningKey is one entry of a KeySet. A key signs new tokens from NotBefore
+// until the next key's NotBefore, and keeps verifying for one token lifetime
+// after that so tokens it already signed stay valid until they expire.
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	Private   any
+	Public    any
+	NotBefore time.Time
+	// ExpiresAt, when set, hard-stops both signing and verification.
+	ExpiresAt time.Time
+}
+
+func (k *SigningKey) asymmetric() bool {
+	_, hmac := k.Method.(*jwt.SigningMethodHMAC)
+	return !hmac
+}
+
+// KeySet holds the keys a provider signs and verifies with, ordered by
+// NotBefore. Rotation is scheduled by adding a key with a future NotBefore.
+type KeySet struct {
+	keys []*SigningKey
+}
+
+func NewKeySet(keys ...*SigningKey) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("key set is empty")
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if seen[k.ID] {
+			return nil, fmt.Errorf("duplicate key id %q", k.ID)
+		}
+		seen[k.ID] = true
+	}
+
+	sorted := append([]*SigningKey(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].NotBefore.Before(sorted[j].NotBefore)
+	})
+	return &KeySet{keys: sorted}, nil
+}
+
+// NewHMACKeySet wraps a shared secret as a single HS256 key without a kid,
+// which is how tokens were signed before key sets existed.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{keys: []*SigningKey{{
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}}}
+}
+
+// SigningKey returns the newest key that is active at now.
+func (ks *KeySet) SigningKey(now time.Time) (*SigningKey, error) {
+	for i := len(ks.keys) - 1; i >= 0; i-- {
+		k := ks.keys[i]
+		if k.NotBefore.After(now) {
+			continue
+		}
+		if !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt) {
+			continue
+		}
+		return k, nil
+	}
+	return nil, errors.New("no active signing key")
+}
+
+// VerificationKey returns the key with the given kid if tokens signed by it
+// can still be valid at now. grace is the longest lifetime of a token.
+func (ks *KeySet) VerificationKey(kid string, now time.Time, grace time.Duration) (*SigningKey, error) {
+	for i, k := range ks.keys {
+		if k.ID != kid {
+			continue
+		}
+		if !ks.verifiable(i, now, grace) {
+			return nil, ErrUnknownSigningKey
+		}
+		return k, nil
+	}
+	return nil, ErrUnknownSigningKey
+}
+
+// PublicKeys lists the asymmetric keys verifiers should know about at now:
+// keys still within their verification window plus scheduled ones, so that
+// caches are warm before a rotation happens.
+func (ks *KeySet) PublicKeys(now time.Time, grace time.Duration) []*SigningKey {
+	var keys []*SigningKey
+	for i, k := range ks.keys {
+		if k.asymmetric() && ks.verifiable(i, now, grace) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (ks *KeySet) verifiable(i int, now time.Time, grace time.Duration) bool {
+	k := ks.keys[i]
+	if !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt) {
+		return false
+	}
+	// The key stops signing once a later key activates.
+	for _, next := range ks.keys[i+1:] {
+		if next.NotBefore.After(k.NotBefore) && !next.NotBefore.After(now) {
+			return now.Before(next.NotBefore.Add(grace))
+		}
+	}
+	return true
+}
+
+// keySetFile is the on-disk manifest read by LoadKeySet:
+//
+//	{"keys": [
+//	  {"kid": "2026-09", "private_key_file": "2026-09.pem", "not_before": "2026-09-01T00:00:00Z"},
+//	  {"kid": "2026-10", "private_key_file": "2026-10.pem", "not_before": "2026-10-01T00:00:00Z"}
+//	]}
+//
+// Paths are relative to the manifest. The algorithm follows from the key type
+// (RSA -> RS256, Ed25519 -> EdDSA) unless "alg" pins it.
+type keySetFile struct {
+	Keys []struct {
+		ID             string    `json:"kid"`
+		Algorithm      string    `json:"alg"`
+		PrivateKeyFile string    `json:"private_key_file"`
+		NotBefore      time.Time `json:"not_before"`
+		ExpiresAt      time.Time `json:"expires_at"`
+	} `json:"keys"`
+}
+
+func LoadKeySet(path string) (*KeySet, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key set: %w", err)
+	}
+
+	var manifest keySetFile
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse key set: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	keys := make([]*SigningKey, 0, len(manifest.Keys))
+	for _, entry := range manifest.Keys {
+		if entry.ID == "" {
+			return nil, errors.New("key set entry without kid")
+		}
+
+		keyPath := entry.PrivateKeyFile
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(dir, keyPath)
+		}
+		pemBytes, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %q: %w", entry.ID, err)
+		}
+
+		key, err := ParsePrivateKey(entry.ID, pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Algorithm != "" && entry.Algorithm != key.Method.Alg() {
+			return nil, fmt.Errorf("key %q is %s but alg is %s", entry.ID, key.Method.Alg(), entry.Algorithm)
+		}
+
+		key.NotBefore = entry.NotBefore
+		key.ExpiresAt = entry.ExpiresAt
+		keys = append(keys, key)
+	}
+
+	return NewKeySet(keys...)
+}
+
+// ParsePrivateKey reads a PEM encoded RSA or Ed25519 private key (PKCS#8, or
+// PKCS#1 for RSA).
+func ParsePrivateKey(kid string, pemBytes []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("key %q is not PEM encoded", kid)
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %q: %w", kid, err)
+	}
+
+	switch priv := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Private: priv, Public: &priv.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: priv, Public: priv.Public()}, nil
+	default:
+		return nil, fmt.Errorf("key %q has unsupported type %T", kid, parsed)
+	}
+}
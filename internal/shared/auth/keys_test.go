@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func newEd25519Key(t *testing.T, kid string, notBefore time.Time) *SigningKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: priv, Public: pub, NotBefore: notBefore}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	grace := time.Hour
+
+	ks, err := NewKeySet(
+		newEd25519Key(t, "new", base.Add(24*time.Hour)),
+		newEd25519Key(t, "old", base),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		wantSigner   string
+		wantVerifies []string
+		wantRejects  []string
+	}{
+		{
+			name:         "Before rotation: old signs, new is published early",
+			now:          base.Add(time.Hour),
+			wantSigner:   "old",
+			wantVerifies: []string{"old", "new"},
+		},
+		{
+			name:         "Within grace: new signs, old still verifies",
+			now:          base.Add(24*time.Hour + 30*time.Minute),
+			wantSigner:   "new",
+			wantVerifies: []string{"old", "new"},
+		},
+		{
+			name:         "After grace: old is retired",
+			now:          base.Add(24*time.Hour + grace),
+			wantSigner:   "new",
+			wantVerifies: []string{"new"},
+			wantRejects:  []string{"old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := ks.SigningKey(tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signer.ID != tt.wantSigner {
+				t.Errorf("SigningKey() = %s, want %s", signer.ID, tt.wantSigner)
+			}
+			for _, kid := range tt.wantVerifies {
+				if _, err := ks.VerificationKey(kid, tt.now, grace); err != nil {
+					t.Errorf("VerificationKey(%s) error = %v", kid, err)
+				}
+			}
+			for _, kid := range tt.wantRejects {
+				if _, err := ks.VerificationKey(kid, tt.now, grace); err == nil {
+					t.Errorf("VerificationKey(%s) accepted a retired key", kid)
+				}
+			}
+			if got := len(ks.PublicKeys(tt.now, grace)); got != len(tt.wantVerifies) {
+				t.Errorf("PublicKeys() returned %d keys, want %d", got, len(tt.wantVerifies))
+			}
+		})
+	}
+}
+
+func TestJWTProvider_AsymmetricRoundTrip(t *testing.T) {
+	ks, err := NewKeySet(newEd25519Key(t, "k1", time.Now().Add(-time.Minute)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := NewJWTProvider(ks, time.Hour, 24*time.Hour, nil)
+
+	userID, orgID, sessionID := uuid.New(), uuid.New(), uuid.New()
+	token, err := provider.GenerateToken(userID, orgID, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := provider.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != userID || claims.SessionID != sessionID || claims.ID == "" {
+		t.Errorf("ValidateToken() claims = %+v", claims)
+	}
+
+	// A token signed with the HMAC secret must not verify against the key set.
+	hmacToken, _ := NewJWTProvider(NewHMACKeySet("secret"), time.Hour, time.Hour, nil).GenerateToken(userID, orgID, sessionID)
+	if _, err := provider.ValidateToken(hmacToken); err == nil {
+		t.Error("ValidateToken() accepted a token signed by a different key")
+	}
+
+	if jwks := provider.JWKS(); len(jwks.Keys) != 1 || jwks.Keys[0].KeyID != "k1" || jwks.Keys[0].Curve != "Ed25519" {
+		t.Errorf("JWKS() = %+v", jwks)
+	}
+}
+
+func TestJWTProvider_ImpersonationToken(t *testing.T) {
+	provider := NewJWTProvider(NewHMACKeySet("secret"), 15*time.Minute, time.Hour, nil)
+
+	userID, orgID, actorID := uuid.New(), uuid.New(), uuid.New()
+	tokens, err := provider.GenerateImpersonationToken(userID, orgID, actorID, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.ExpiresIn != 15*time.Minute || tokens.RefreshToken != "" {
+		t.Errorf("GenerateImpersonationToken() = %+v, want a 15m access token only", tokens)
+	}
+
+	claims, err := provider.ValidateToken(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != userID || claims.SessionID != tokens.SessionID || claims.Actor == nil || claims.Actor.Subject != actorID {
+		t.Errorf("ValidateToken() claims = %+v, want act.sub %s", claims, actorID)
+	}
+}
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	redis "github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+)
+
+// TokenPair is what a login or refresh hands back to the client. SessionID is
+// the refresh token family, which doubles as the login session.
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	ExpiresIn        time.Duration
+	RefreshExpiresIn time.Duration
+	SessionID        uuid.UUID
+}
+
+// Refresh tokens are opaque random strings. Redis only ever sees their
+// SHA-256 digest, stored as a hash next to the blacklist keys:
+//
+//	refresh:<digest>         -> user_id, org_id, family_id, issued_at_ns[, used_at]
+//	refresh_family:<family>  -> expiry of the family in Unix nanoseconds
+//
+// Every rotation marks the presented token as used and issues a new one in the
+// same family, never outliving the expiry fixed at login. Presenting a used
+// token again revokes the session, which invalidates every refresh token that
+// descends from the original login and the access tokens issued to it.
+func refreshKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "refresh:" + hex.EncodeToString(sum[:])
+}
+
+func refreshFamilyKey(familyID string) string {
+	return "refresh_family:" + familyID
+}
+
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (j *jwtProvider) GenerateTokenPair(ctx context.Context, userID uuid.UUID, orgID uuid.UUID) (*TokenPair, error) {
+	return j.issuePair(ctx, userID, orgID, uuid.New(), time.Now().Add(j.refreshExpiryDuration))
+}
+
+func (j *jwtProvider) issuePair(ctx context.Context, userID uuid.UUID, orgID uuid.UUID, sessionID uuid.UUID, familyExpiresAt time.Time) (*TokenPair, error) {
+	familyID := sessionID.String()
+	refreshExpiresIn := time.Until(familyExpiresAt)
+	if refreshExpiresIn <= 0 {
+		return nil, ErrInvalidRefreshToken
+	}
+	accessToken, err := j.GenerateToken(userID, orgID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	key := refreshKey(refreshToken)
+	pipe := j.redis.TxPipeline()
+	pipe.HSet(ctx, key,
+		"user_id", userID.String(),
+		"org_id", orgID.String(),
+		"family_id", familyID,
+		"issued_at_ns", time.Now().UnixNano(),
+	)
+	pipe.Expire(ctx, key, refreshExpiresIn)
+	pipe.Set(ctx, refreshFamilyKey(familyID), familyExpiresAt.UnixNano(), refreshExpiresIn)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresIn:        j.expiryDuration,
+		RefreshExpiresIn: refreshExpiresIn,
+		SessionID:        sessionID,
+	}, nil
+}
+
+func (j *jwtProvider) RotateRefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	key := refreshKey(refreshToken)
+
+	fields, err := j.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	familyID := fields["family_id"]
+	sessionID, err := uuid.Parse(familyID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	familyExpiresAt, err := j.familyExpiry(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// HSETNX makes "mark as used" atomic, so two concurrent rotations of the
+	// same token cannot both succeed.
+	fresh, err := j.redis.HSetNX(ctx, key, "used_at", time.Now().Unix()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+	if !fresh {
+		// Someone holds a copy of the token, so the access tokens issued to
+		// the session are no more trustworthy than its refresh tokens.
+		if err := j.RevokeSession(ctx, sessionID); err != nil {
+			return nil, fmt.Errorf("failed to revoke reused session: %w", err)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	userID, err := uuid.Parse(fields["user_id"])
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	orgID, err := uuid.Parse(fields["org_id"])
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Tokens stored before issued_at_ns existed only carry seconds.
+	issuedAt, err := strconv.ParseInt(fields["issued_at_ns"], 10, 64)
+	if err != nil {
+		seconds, _ := strconv.ParseInt(fields["issued_at"], 10, 64)
+		issuedAt = seconds * int64(time.Second)
+	}
+	revoked, err := j.issuedBeforeRevocation(ctx, userID, issuedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return j.issuePair(ctx, userID, orgID, sessionID, familyExpiresAt)
+}
+
+// familyExpiry returns when the family stops rotating. Families stored
+// before the expiry was recorded hold "active" and expire with their key.
+func (j *jwtProvider) familyExpiry(ctx context.Context, familyID string) (time.Time, error) {
+	key := refreshFamilyKey(familyID)
+	val, err := j.redis.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return time.Time{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load refresh token family: %w", err)
+	}
+	if nanos, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(0, nanos), nil
+	}
+
+	ttl, err := j.redis.PTTL(ctx, key).Result()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load refresh token family: %w", err)
+	}
+	if ttl <= 0 {
+		return time.Time{}, ErrInvalidRefreshToken
+	}
+	return time.Now().Add(ttl), nil
+}
+
+func (j *jwtProvider) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	familyID, err := j.redis.HGet(ctx, refreshKey(refreshToken), "family_id").Result()
+	if err == redis.Nil {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	return j.redis.Del(ctx, refreshFamilyKey(familyID)).Err()
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	redis "github.com/redis/go-redis/v9"
+)
+
+// newTestProvider returns a provider backed by an in-memory Redis.
+func newTestProvider(t *testing.T) (*jwtProvider, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	provider := NewJWTProvider(NewHMACKeySet("test-secret"), 15*time.Minute, 24*time.Hour, client)
+	return provider.(*jwtProvider), mr
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	j, _ := newTestProvider(t)
+	userID, orgID := uuid.New(), uuid.New()
+
+	first, err := j.GenerateTokenPair(ctx, userID, orgID)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	second, err := j.RotateRefreshToken(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("rotation returned the same refresh token")
+	}
+	if second.SessionID != first.SessionID {
+		t.Errorf("rotated session = %s, want the family %s", second.SessionID, first.SessionID)
+	}
+	claims, err := j.ValidateToken(second.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != userID || claims.OrganizationID != orgID || claims.SessionID != first.SessionID {
+		t.Errorf("claims = %+v, want user %s in org %s", claims, userID, orgID)
+	}
+
+	third, err := j.RotateRefreshToken(ctx, second.RefreshToken)
+	if err != nil {
+		t.Fatalf("second RotateRefreshToken() error = %v", err)
+	}
+	if third.SessionID != first.SessionID {
+		t.Errorf("session changed on the second rotation")
+	}
+}
+
+func TestRotateRefreshTokenReuse(t *testing.T) {
+	ctx := context.Background()
+	j, _ := newTestProvider(t)
+
+	first, err := j.GenerateTokenPair(ctx, uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	second, err := j.RotateRefreshToken(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+
+	if _, err := j.RotateRefreshToken(ctx, first.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("reusing the old token: error = %v, want ErrRefreshTokenReused", err)
+	}
+	// The reuse revokes the whole family, including the token handed out
+	// by the legitimate rotation.
+	if _, err := j.RotateRefreshToken(ctx, second.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("rotating the newest token after reuse: error = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	// So do the access tokens of the session.
+	claims, err := j.ValidateToken(second.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if blacklisted, err := j.IsBlacklisted(ctx, claims); err != nil || !blacklisted {
+		t.Errorf("IsBlacklisted() = %v, %v after reuse, want true", blacklisted, err)
+	}
+}
+
+func TestRotateRefreshTokenFamilyExpiry(t *testing.T) {
+	ctx := context.Background()
+	j, mr := newTestProvider(t)
+
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		wantErr   error
+	}{
+		{"Rotation keeps the login's expiry", time.Minute, nil},
+		{"Family past its expiry", -time.Second, ErrInvalidRefreshToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := j.GenerateTokenPair(ctx, uuid.New(), uuid.New())
+			if err != nil {
+				t.Fatalf("GenerateTokenPair() error = %v", err)
+			}
+			// Pretend the login happened almost a full refresh lifetime ago.
+			expiresAt := time.Now().Add(tt.expiresIn).UnixNano()
+			family := refreshFamilyKey(pair.SessionID.String())
+			ttl := mr.TTL(family)
+			mr.Set(family, strconv.FormatInt(expiresAt, 10))
+			mr.SetTTL(family, ttl)
+
+			rotated, err := j.RotateRefreshToken(ctx, pair.RefreshToken)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RotateRefreshToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if rotated.RefreshExpiresIn > tt.expiresIn {
+				t.Errorf("RefreshExpiresIn = %v, want at most %v", rotated.RefreshExpiresIn, tt.expiresIn)
+			}
+			if got := mr.TTL(family); got > tt.expiresIn {
+				t.Errorf("family TTL = %v, want at most %v", got, tt.expiresIn)
+			}
+		})
+	}
+}
+
+func TestRotateRefreshTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	j, mr := newTestProvider(t)
+
+	if _, err := j.RotateRefreshToken(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("unknown token: error = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	pair, err := j.GenerateTokenPair(ctx, uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	mr.FastForward(25 * time.Hour)
+	if _, err := j.RotateRefreshToken(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expired token: error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	j, _ := newTestProvider(t)
+
+	first, err := j.GenerateTokenPair(ctx, uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	second, err := j.RotateRefreshToken(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+
+	if err := j.RevokeRefreshToken(ctx, second.RefreshToken); err != nil {
+		t.Fatalf("RevokeRefreshToken() error = %v", err)
+	}
+	if _, err := j.RotateRefreshToken(ctx, second.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("rotating a revoked token: error = %v, want ErrInvalidRefreshToken", err)
+	}
+	if err := j.RevokeRefreshToken(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("revoking an unknown token: error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
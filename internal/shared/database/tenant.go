@@ -0,0 +1,204 @@
+// Package database keeps a request's queries inside its organization.
+//
+// Tenant tables carry row-level security policies (see migrations 000025
+// and 000027) that only admit rows of the organization named by the
+// app.org_id setting, and only for the lms_tenant role. TenantDB runs every
+// statement of a request that has an organization in its context as that
+// role, with the setting applied through SET LOCAL, so a query that forgets
+// its organization_id filter still cannot see another tenant's rows.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/google/uuid"
+)
+
+// TenantRole is the Postgres role scoped statements run as. It owns no
+// tables, so the policies apply to it even when the application connects as
+// a table owner or superuser.
+const TenantRole = "lms_tenant"
+
+// DB is what repositories need from the database. Rows and Row are the
+// parts of *sql.Rows and *sql.Row they use.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) Row
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+type Row interface {
+	Scan(dest ...any) error
+	Err() error
+}
+
+type unscopedKey struct{}
+
+// Unscoped returns a context whose statements run on the pool, outside the
+// organization of the request. It is only for work that spans organizations
+// by design: signing in, switching organization, a user's own sessions and
+// finding an account by email address.
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unscopedKey{}, true)
+}
+
+// scope returns the organization statements under ctx are confined to.
+func scope(ctx context.Context) (uuid.UUID, bool) {
+	if unscoped, _ := ctx.Value(unscopedKey{}).(bool); unscoped {
+		return uuid.Nil, false
+	}
+	return auth.GetOrgID(ctx)
+}
+
+type TenantDB struct {
+	db *sql.DB
+}
+
+// NewTenantDB wraps db. Statements whose context has no organization, such
+// as seeders and background jobs, or is Unscoped go straight to the pool and
+// are not subject to the policies.
+func NewTenantDB(db *sql.DB) DB {
+	return &TenantDB{db: db}
+}
+
+func (t *TenantDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	orgID, ok := scope(ctx)
+	if !ok {
+		return t.db.ExecContext(ctx, query, args...)
+	}
+
+	tx, err := t.begin(ctx, orgID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return res, tx.Commit()
+}
+
+// QueryContext keeps a scoped statement's transaction open until the rows
+// are closed. Queries may modify data through RETURNING, so closing commits.
+func (t *TenantDB) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	orgID, ok := scope(ctx)
+	if !ok {
+		rows, err := t.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		return rows, nil
+	}
+
+	tx, err := t.begin(ctx, orgID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &scopedRows{Rows: rows, tx: tx}, nil
+}
+
+func (t *TenantDB) QueryRowContext(ctx context.Context, query string, args ...any) Row {
+	if _, ok := scope(ctx); !ok {
+		return t.db.QueryRowContext(ctx, query, args...)
+	}
+
+	rows, err := t.QueryContext(ctx, query, args...)
+	return &scopedRow{rows: rows, err: err}
+}
+
+// BeginTx starts a transaction that is scoped for its whole lifetime.
+func (t *TenantDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	orgID, ok := scope(ctx)
+	if !ok {
+		return t.db.BeginTx(ctx, opts)
+	}
+	return t.begin(ctx, orgID, opts)
+}
+
+func (t *TenantDB) begin(ctx context.Context, orgID uuid.UUID, opts *sql.TxOptions) (*sql.Tx, error) {
+	tx, err := t.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`SELECT set_config('role', $1, true), set_config('app.org_id', $2, true)`,
+		TenantRole, orgID.String())
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to scope transaction to organization: %w", err)
+	}
+
+	return tx, nil
+}
+
+type scopedRows struct {
+	*sql.Rows
+	tx     *sql.Tx
+	closed bool
+}
+
+func (r *scopedRows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if err := r.Rows.Close(); err != nil {
+		r.tx.Rollback()
+		return err
+	}
+	if err := r.Rows.Err(); err != nil {
+		r.tx.Rollback()
+		return nil
+	}
+	return r.tx.Commit()
+}
+
+// scopedRow mirrors *sql.Row: errors surface on Scan, and a missing row is
+// sql.ErrNoRows.
+type scopedRow struct {
+	rows Rows
+	err  error
+}
+
+func (r *scopedRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+	return r.rows.Close()
+}
+
+func (r *scopedRow) Err() error {
+	return r.err
+}
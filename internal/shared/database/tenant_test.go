@@ -0,0 +1,441 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	attachmentPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/attachment/repository/postgres"
+	cohortPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/cohort/repository/postgres"
+	coursePostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/course/repository/postgres"
+	userPostgres "github.com/chimera-foundation/chimera-lms-be-v2/internal/features/user/repository/postgres"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/auth"
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/database"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// tenant is one organization's fixture rows.
+type tenant struct {
+	orgID, userID, courseID, cohortID, attachmentID uuid.UUID
+	email                                           string
+
+	roleID, sessionID, apiKeyID, loginEventID, impersonationID, recoveryCodeID, invitationID uuid.UUID
+	providerID, identityID                                                                   uuid.UUID
+}
+
+// openTestDB connects to the database named by TEST_DB_URI, which must be
+// migrated. The tests are skipped without it.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	uri := os.Getenv("TEST_DB_URI")
+	if uri == "" {
+		t.Skip("TEST_DB_URI is not set")
+	}
+
+	db, err := sql.Open("pgx", uri)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("failed to ping database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// createTenant inserts an organization with a user, a course, a cohort, an
+// attachment and a row in every identity table through the unscoped pool.
+func createTenant(t *testing.T, db *sql.DB) tenant {
+	t.Helper()
+
+	tn := tenant{
+		orgID: uuid.New(), userID: uuid.New(), courseID: uuid.New(), cohortID: uuid.New(), attachmentID: uuid.New(),
+		roleID: uuid.New(), sessionID: uuid.New(), apiKeyID: uuid.New(), loginEventID: uuid.New(),
+		impersonationID: uuid.New(), recoveryCodeID: uuid.New(), invitationID: uuid.New(),
+		providerID: uuid.New(), identityID: uuid.New(),
+	}
+	tn.email = tn.userID.String() + "@rls.test"
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO organizations (id, name, slug) VALUES ($1, $2, $3)`,
+			[]any{tn.orgID, "RLS test " + tn.orgID.String(), "rls-" + tn.orgID.String()}},
+		{`INSERT INTO users (id, organization_id, email, password_hash) VALUES ($1, $2, $3, 'x')`,
+			[]any{tn.userID, tn.orgID, tn.email}},
+		{`INSERT INTO organization_memberships (user_id, organization_id) VALUES ($1, $2)`,
+			[]any{tn.userID, tn.orgID}},
+		{`INSERT INTO roles (id, organization_id, name) VALUES ($1, $2, 'RLS role')`,
+			[]any{tn.roleID, tn.orgID}},
+		{`INSERT INTO user_roles (user_id, organization_id, role_id) VALUES ($1, $2, $3)`,
+			[]any{tn.userID, tn.orgID, tn.roleID}},
+		{`INSERT INTO user_sessions (id, user_id, organization_id, expires_at) VALUES ($1, $2, $3, now() + interval '1 hour')`,
+			[]any{tn.sessionID, tn.userID, tn.orgID}},
+		{`INSERT INTO api_keys (id, organization_id, service_account_id, name, prefix, key_hash) VALUES ($1, $2, $3, 'RLS key', $4, 'x')`,
+			[]any{tn.apiKeyID, tn.orgID, tn.userID, tn.apiKeyID.String()}},
+		{`INSERT INTO login_history (id, user_id, organization_id, email, event) VALUES ($1, $2, $3, $4, 'success')`,
+			[]any{tn.loginEventID, tn.userID, tn.orgID, tn.email}},
+		{`INSERT INTO impersonation_events (id, organization_id, actor_id, user_id, session_id, action) VALUES ($1, $2, $3, $3, $4, 'start')`,
+			[]any{tn.impersonationID, tn.orgID, tn.userID, tn.sessionID}},
+		{`INSERT INTO organization_identity_providers (id, organization_id, issuer, client_id) VALUES ($1, $2, 'https://idp.rls.test', 'lms')`,
+			[]any{tn.providerID, tn.orgID}},
+		{`INSERT INTO user_identities (id, user_id, provider_id, subject) VALUES ($1, $2, $3, 'rls-subject')`,
+			[]any{tn.identityID, tn.userID, tn.providerID}},
+		{`INSERT INTO user_recovery_codes (id, user_id, code_hash) VALUES ($1, $2, 'x')`,
+			[]any{tn.recoveryCodeID, tn.userID}},
+		{`INSERT INTO membership_invitations (id, organization_id, email, expires_at) VALUES ($1, $2, $3, now() + interval '1 hour')`,
+			[]any{tn.invitationID, tn.orgID, tn.email}},
+		{`INSERT INTO courses (id, organization_id, title) VALUES ($1, $2, 'Course')`,
+			[]any{tn.courseID, tn.orgID}},
+		{`INSERT INTO cohorts (id, organization_id, name) VALUES ($1, $2, 'Cohort')`,
+			[]any{tn.cohortID, tn.orgID}},
+		{`INSERT INTO attachments (id, organization_id, uploaded_by, file_name, file_url, file_size, mime_type)
+		  VALUES ($1, $2, $3, 'a.pdf', '/a.pdf', 1, 'application/pdf')`,
+			[]any{tn.attachmentID, tn.orgID, tn.userID}},
+	}
+	for _, s := range statements {
+		if _, err := db.Exec(s.query, s.args...); err != nil {
+			t.Fatalf("failed to create fixture: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM attachments WHERE id = $1`, tn.attachmentID)
+		db.Exec(`DELETE FROM cohorts WHERE id = $1`, tn.cohortID)
+		db.Exec(`DELETE FROM courses WHERE id = $1`, tn.courseID)
+		// Sessions, keys, history, audit rows, recovery codes, identity
+		// links and memberships go with the user; roles, the provider and
+		// invitations with the organization.
+		db.Exec(`DELETE FROM user_roles WHERE organization_id = $1`, tn.orgID)
+		db.Exec(`DELETE FROM organization_memberships WHERE organization_id = $1`, tn.orgID)
+		db.Exec(`DELETE FROM users WHERE id = $1`, tn.userID)
+		db.Exec(`DELETE FROM organizations WHERE id = $1`, tn.orgID)
+	})
+	return tn
+}
+
+func scopedTo(orgID uuid.UUID) context.Context {
+	return context.WithValue(context.Background(), auth.OrgIDKey, orgID)
+}
+
+// The lookups below filter by ID only, so the policies alone keep the other
+// organization's rows out.
+func TestTenantIsolationRepositories(t *testing.T) {
+	db := openTestDB(t)
+	own, other := createTenant(t, db), createTenant(t, db)
+
+	tdb := database.NewTenantDB(db)
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	courses := coursePostgres.NewCourseRepository(tdb)
+	cohorts := cohortPostgres.NewCohortRepository(tdb, log)
+	attachments := attachmentPostgres.NewAttachmentRepoPostgres(tdb, log)
+
+	tests := []struct {
+		name string
+		get  func(ctx context.Context, id uuid.UUID) (bool, error)
+		id   uuid.UUID
+	}{
+		{"Course", func(ctx context.Context, id uuid.UUID) (bool, error) {
+			c, err := courses.GetByID(ctx, id)
+			return c != nil, err
+		}, other.courseID},
+		{"Cohort", func(ctx context.Context, id uuid.UUID) (bool, error) {
+			c, err := cohorts.GetByID(ctx, id)
+			return c != nil, err
+		}, other.cohortID},
+		{"Attachment", func(ctx context.Context, id uuid.UUID) (bool, error) {
+			a, err := attachments.GetByID(ctx, id)
+			return a != nil, err
+		}, other.attachmentID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := tt.get(scopedTo(own.orgID), tt.id)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if found {
+				t.Errorf("GetByID() found a row of another organization")
+			}
+		})
+	}
+
+	t.Run("Own attachment", func(t *testing.T) {
+		a, err := attachments.GetByID(scopedTo(own.orgID), own.attachmentID)
+		if err != nil || a == nil {
+			t.Fatalf("GetByID() = %v, %v, want own attachment", a, err)
+		}
+	})
+}
+
+func TestTenantIsolationStatements(t *testing.T) {
+	db := openTestDB(t)
+	own, other := createTenant(t, db), createTenant(t, db)
+
+	tdb := database.NewTenantDB(db)
+	ctx := scopedTo(own.orgID)
+
+	countCourses := func(ctx context.Context) int {
+		t.Helper()
+		var n int
+		err := tdb.QueryRowContext(ctx, `SELECT COUNT(*) FROM courses WHERE id IN ($1, $2)`,
+			own.courseID, other.courseID).Scan(&n)
+		if err != nil {
+			t.Fatalf("count courses: %v", err)
+		}
+		return n
+	}
+
+	t.Run("Query without organization filter", func(t *testing.T) {
+		if n := countCourses(ctx); n != 1 {
+			t.Errorf("scoped count = %d, want 1", n)
+		}
+		if n := countCourses(context.Background()); n != 2 {
+			t.Errorf("unscoped count = %d, want 2", n)
+		}
+	})
+
+	t.Run("Rows", func(t *testing.T) {
+		rows, err := tdb.QueryContext(ctx, `SELECT organization_id FROM cohorts WHERE id IN ($1, $2)`,
+			own.cohortID, other.cohortID)
+		if err != nil {
+			t.Fatalf("QueryContext() error = %v", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var orgID uuid.UUID
+			if err := rows.Scan(&orgID); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if orgID != own.orgID {
+				t.Errorf("got a cohort of organization %s", orgID)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("Err() = %v", err)
+		}
+	})
+
+	t.Run("Update of another organization", func(t *testing.T) {
+		res, err := tdb.ExecContext(ctx, `UPDATE courses SET title = 'Taken' WHERE id = $1`, other.courseID)
+		if err != nil {
+			t.Fatalf("ExecContext() error = %v", err)
+		}
+		if n, _ := res.RowsAffected(); n != 0 {
+			t.Errorf("updated %d rows of another organization", n)
+		}
+	})
+
+	t.Run("Insert into another organization", func(t *testing.T) {
+		id := uuid.New()
+		_, err := tdb.ExecContext(ctx, `INSERT INTO courses (id, organization_id, title) VALUES ($1, $2, 'Planted')`,
+			id, other.orgID)
+		if err == nil {
+			db.Exec(`DELETE FROM courses WHERE id = $1`, id)
+			t.Fatal("ExecContext() inserted a row for another organization")
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		tx, err := tdb.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("BeginTx() error = %v", err)
+		}
+		defer tx.Rollback()
+
+		var n int
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM attachments WHERE id IN ($1, $2)`,
+			own.attachmentID, other.attachmentID).Scan(&n)
+		if err != nil {
+			t.Fatalf("count attachments: %v", err)
+		}
+		if n != 1 {
+			t.Errorf("count in transaction = %d, want 1", n)
+		}
+	})
+
+	t.Run("Missing row", func(t *testing.T) {
+		var id uuid.UUID
+		err := tdb.QueryRowContext(ctx, `SELECT id FROM courses WHERE id = $1`, other.courseID).Scan(&id)
+		if err != sql.ErrNoRows {
+			t.Errorf("Scan() error = %v, want sql.ErrNoRows", err)
+		}
+	})
+}
+
+// Each identity table holds one row per fixture organization; a scoped
+// count must only see the current organization's.
+func TestTenantIsolationIdentityTables(t *testing.T) {
+	db := openTestDB(t)
+	own, other := createTenant(t, db), createTenant(t, db)
+
+	tdb := database.NewTenantDB(db)
+	ctx := scopedTo(own.orgID)
+
+	tests := []struct {
+		table, column string
+		own, other    uuid.UUID
+	}{
+		{"users", "id", own.userID, other.userID},
+		{"organization_memberships", "user_id", own.userID, other.userID},
+		{"user_roles", "user_id", own.userID, other.userID},
+		{"roles", "id", own.roleID, other.roleID},
+		{"user_sessions", "id", own.sessionID, other.sessionID},
+		{"api_keys", "id", own.apiKeyID, other.apiKeyID},
+		{"login_history", "id", own.loginEventID, other.loginEventID},
+		{"impersonation_events", "id", own.impersonationID, other.impersonationID},
+		{"organization_identity_providers", "organization_id", own.orgID, other.orgID},
+		{"user_identities", "id", own.identityID, other.identityID},
+		{"user_recovery_codes", "id", own.recoveryCodeID, other.recoveryCodeID},
+		{"membership_invitations", "id", own.invitationID, other.invitationID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			query := `SELECT COUNT(*) FROM ` + tt.table + ` WHERE ` + tt.column + ` IN ($1, $2)`
+			count := func(ctx context.Context) int {
+				t.Helper()
+				var n int
+				if err := tdb.QueryRowContext(ctx, query, tt.own, tt.other).Scan(&n); err != nil {
+					t.Fatalf("count: %v", err)
+				}
+				return n
+			}
+
+			if n := count(ctx); n != 1 {
+				t.Errorf("scoped count = %d, want 1", n)
+			}
+			if n := count(database.Unscoped(ctx)); n != 2 {
+				t.Errorf("unscoped count = %d, want 2", n)
+			}
+		})
+	}
+
+	t.Run("Built-in roles", func(t *testing.T) {
+		var n int
+		err := tdb.QueryRowContext(ctx, `SELECT COUNT(*) FROM roles WHERE organization_id IS NULL`).Scan(&n)
+		if err != nil {
+			t.Fatalf("count built-in roles: %v", err)
+		}
+		var want int
+		db.QueryRow(`SELECT COUNT(*) FROM roles WHERE organization_id IS NULL`).Scan(&want)
+		if n != want {
+			t.Errorf("scoped count = %d, want %d", n, want)
+		}
+	})
+
+	t.Run("Session in another organization", func(t *testing.T) {
+		id := uuid.New()
+		_, err := tdb.ExecContext(ctx, `
+			INSERT INTO user_sessions (id, user_id, organization_id, expires_at)
+			VALUES ($1, $2, $3, now() + interval '1 hour')`, id, own.userID, other.orgID)
+		if err == nil {
+			db.Exec(`DELETE FROM user_sessions WHERE id = $1`, id)
+			t.Fatal("ExecContext() started a session in another organization")
+		}
+	})
+
+	t.Run("Identity link to another organization's provider", func(t *testing.T) {
+		id := uuid.New()
+		_, err := tdb.ExecContext(ctx, `
+			INSERT INTO user_identities (id, user_id, provider_id, subject)
+			VALUES ($1, $2, $3, 'planted')`, id, own.userID, other.providerID)
+		if err == nil {
+			db.Exec(`DELETE FROM user_identities WHERE id = $1`, id)
+			t.Fatal("ExecContext() linked an identity of another organization")
+		}
+	})
+
+	t.Run("Guest", func(t *testing.T) {
+		if _, err := db.Exec(`INSERT INTO organization_memberships (user_id, organization_id) VALUES ($1, $2)`,
+			other.userID, own.orgID); err != nil {
+			t.Fatalf("failed to add guest: %v", err)
+		}
+		defer db.Exec(`DELETE FROM organization_memberships WHERE user_id = $1 AND organization_id = $2`, other.userID, own.orgID)
+
+		var n int
+		err := tdb.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE id = $1`, other.userID).Scan(&n)
+		if err != nil {
+			t.Fatalf("count guest: %v", err)
+		}
+		if n != 1 {
+			t.Errorf("guest is not visible in the organization")
+		}
+	})
+}
+
+// Lookups by email cross organizations only when asked to.
+func TestTenantIsolationEmailLookup(t *testing.T) {
+	db := openTestDB(t)
+	own, other := createTenant(t, db), createTenant(t, db)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	users := userPostgres.NewUserRepo(database.NewTenantDB(db), log)
+	ctx := scopedTo(own.orgID)
+
+	u, err := users.GetByEmail(ctx, other.email)
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if u != nil {
+		t.Errorf("scoped GetByEmail() found a user of another organization")
+	}
+
+	u, err = users.GetByEmail(database.Unscoped(ctx), other.email)
+	if err != nil {
+		t.Fatalf("unscoped GetByEmail() error = %v", err)
+	}
+	if u == nil || u.ID != other.userID {
+		t.Errorf("unscoped GetByEmail() = %v, want user %s", u, other.userID)
+	}
+}
+
+// A guest changing their own account, such as their password or second
+// factor, writes through the organization they are signed in to. Their
+// roles elsewhere must survive it.
+func TestTenantIsolationGuestUpdate(t *testing.T) {
+	db := openTestDB(t)
+	own, other := createTenant(t, db), createTenant(t, db)
+
+	if _, err := db.Exec(`INSERT INTO organization_memberships (user_id, organization_id) VALUES ($1, $2)`,
+		other.userID, own.orgID); err != nil {
+		t.Fatalf("failed to add guest: %v", err)
+	}
+	defer db.Exec(`DELETE FROM organization_memberships WHERE user_id = $1 AND organization_id = $2`, other.userID, own.orgID)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	users := userPostgres.NewUserRepo(database.NewTenantDB(db), log)
+	ctx := scopedTo(own.orgID)
+
+	guest, err := users.GetByID(ctx, other.userID)
+	if err != nil || guest == nil {
+		t.Fatalf("GetByID() = %v, %v, want the guest", guest, err)
+	}
+	guest.FirstName = "Renamed"
+	if err := users.Update(ctx, guest); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	var firstName string
+	var roles int
+	db.QueryRow(`SELECT first_name FROM users WHERE id = $1`, other.userID).Scan(&firstName)
+	db.QueryRow(`SELECT COUNT(*) FROM user_roles WHERE user_id = $1 AND organization_id = $2`, other.userID, other.orgID).Scan(&roles)
+	if firstName != "Renamed" {
+		t.Errorf("first name = %q, want the update", firstName)
+	}
+	if roles != 1 {
+		t.Errorf("home roles = %d, want 1", roles)
+	}
+}
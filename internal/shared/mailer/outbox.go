@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMailer writes every message as an .eml file instead of delivering it.
+// It is meant for local development and tests.
+type OutboxMailer struct {
+	dir  string
+	from string
+}
+
+func NewOutboxMailer(dir, from string) (*OutboxMailer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory %s: %w", dir, err)
+	}
+	return &OutboxMailer{dir: dir, from: from}, nil
+}
+
+func (m *OutboxMailer) Send(_ context.Context, msg Message) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.NewString())
+	fullPath := filepath.Join(m.dir, name)
+
+	if err := os.WriteFile(fullPath, render(m.from, msg), 0644); err != nil {
+		return fmt.Errorf("failed to write mail %s: %w", fullPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+// Package oidc implements the relying-party side of OpenID Connect: provider
+// discovery, the authorization code flow with PKCE, and ID token validation.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+// Config identifies this application to one identity provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Metadata is the subset of the discovery document the flow needs.
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Claims are the ID token claims used to identify a user.
+type Claims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// Client talks to identity providers. Discovery documents and key sets are
+// cached per issuer; keys are refetched when a token names an unknown kid.
+type Client struct {
+	http     *http.Client
+	cacheTTL time.Duration
+
+	mu        sync.Mutex
+	providers map[string]*providerCache
+}
+
+type providerCache struct {
+	metadata  *Metadata
+	keys      map[string]any
+	fetchedAt time.Time
+}
+
+func NewClient(httpClient *http.Client, cacheTTL time.Duration) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{
+		http:      httpClient,
+		cacheTTL:  cacheTTL,
+		providers: make(map[string]*providerCache),
+	}
+}
+
+func (c *Client) Discover(ctx context.Context, issuer string) (*Metadata, error) {
+	p, err := c.provider(ctx, issuer, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.metadata, nil
+}
+
+// AuthCodeURL builds the URL the browser is sent to. The PKCE challenge is
+// always S256.
+func (c *Client) AuthCodeURL(ctx context.Context, cfg Config, state, nonce, codeChallenge string) (string, error) {
+	meta, err := c.Discover(ctx, cfg.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", cfg.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the validated ID token
+// claims. nonce must match the value sent with the authorization request.
+func (c *Client) Exchange(ctx context.Context, cfg Config, code, codeVerifier, nonce string) (*Claims, error) {
+	meta, err := c.Discover(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", cfg.RedirectURL)
+	form.Set("client_id", cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if cfg.ClientSecret != "" {
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %d: %s", res.StatusCode, body)
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	return c.VerifyIDToken(ctx, cfg, tokens.IDToken, nonce)
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of
+// an ID token.
+func (c *Client) VerifyIDToken(ctx context.Context, cfg Config, rawIDToken, nonce string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, cfg.Issuer, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "EdDSA"}),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+func (c *Client) key(ctx context.Context, issuer, kid string) (any, error) {
+	p, err := c.provider(ctx, issuer, false)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := p.lookup(kid); ok {
+		return k, nil
+	}
+
+	// The provider may have rotated its keys since they were cached.
+	p, err = c.provider(ctx, issuer, true)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := p.lookup(kid); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *providerCache) lookup(kid string) (any, bool) {
+	if kid != "" {
+		k, ok := p.keys[kid]
+		return k, ok
+	}
+	// Tokens without a kid are only unambiguous when there is one key.
+	if len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+func (c *Client) provider(ctx context.Context, issuer string, refresh bool) (*providerCache, error) {
+	c.mu.Lock()
+	cached, ok := c.providers[issuer]
+	c.mu.Unlock()
+	if ok && !refresh && time.Since(cached.fetchedAt) < c.cacheTTL {
+		return cached, nil
+	}
+
+	meta := &Metadata{}
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := c.getJSON(ctx, wellKnown, meta); err != nil {
+		return nil, fmt.Errorf("discovery failed for %s: %w", issuer, err)
+	}
+	if meta.Issuer != issuer {
+		return nil, fmt.Errorf("discovery issuer %q does not match %q", meta.Issuer, issuer)
+	}
+
+	var set jsonWebKeySet
+	if err := c.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch keys for %s: %w", issuer, err)
+	}
+
+	p := &providerCache{metadata: meta, keys: set.publicKeys(), fetchedAt: time.Now()}
+	c.mu.Lock()
+	c.providers[issuer] = p
+	c.mu.Unlock()
+	return p, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", url, res.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(v)
+}
@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/chimera-foundation/chimera-lms-be-v2/internal/shared/oidc/oidctest"
+)
+
+func TestClient_AuthorizationCodeFlow(t *testing.T) {
+	provider, err := oidctest.NewProvider("", "lms", oidctest.User{
+		Subject: "abc", Email: "ada@example.edu", EmailVerified: true, GivenName: "Ada",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(provider)
+	defer srv.Close()
+	provider.Issuer = srv.URL
+
+	cfg := Config{Issuer: srv.URL, ClientID: "lms", RedirectURL: "http://app.local/sso/callback"}
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	client := NewClient(srv.Client(), time.Hour)
+	ctx := context.Background()
+
+	authorize := func(t *testing.T, verifier, nonce string) string {
+		t.Helper()
+		authURL, err := client.AuthCodeURL(ctx, cfg, "state-1", nonce, CodeChallenge(verifier))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := noRedirect.Get(authURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		loc, _ := url.Parse(res.Header.Get("Location"))
+		if loc.Query().Get("state") != "state-1" {
+			t.Fatalf("state not echoed: %s", loc)
+		}
+		return loc.Query().Get("code")
+	}
+
+	t.Run("Success: code exchange yields verified claims", func(t *testing.T) {
+		verifier, _ := RandomString()
+		code := authorize(t, verifier, "n-1")
+
+		claims, err := client.Exchange(ctx, cfg, code, verifier, "n-1")
+		if err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		if claims.Subject != "abc" || claims.Email != "ada@example.edu" || !claims.EmailVerified {
+			t.Errorf("Exchange() claims = %+v", claims)
+		}
+	})
+
+	t.Run("Failure: wrong PKCE verifier", func(t *testing.T) {
+		verifier, _ := RandomString()
+		code := authorize(t, verifier, "n-2")
+
+		if _, err := client.Exchange(ctx, cfg, code, "not-the-verifier", "n-2"); err == nil {
+			t.Error("Exchange() accepted a wrong code verifier")
+		}
+	})
+
+	t.Run("Failure: nonce mismatch", func(t *testing.T) {
+		verifier, _ := RandomString()
+		code := authorize(t, verifier, "n-3")
+
+		_, err := client.Exchange(ctx, cfg, code, verifier, "other")
+		if !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("Exchange() error = %v, want ErrInvalidIDToken", err)
+		}
+	})
+
+	t.Run("Failure: wrong audience", func(t *testing.T) {
+		verifier, _ := RandomString()
+		code := authorize(t, verifier, "n-4")
+
+		other := cfg
+		other.ClientID = "someone-else"
+		_, err := client.Exchange(ctx, other, code, verifier, "n-4")
+		if err == nil {
+			t.Error("Exchange() accepted a token for another client")
+		}
+	})
+}
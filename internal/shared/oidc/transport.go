@@ -0,0 +1,66 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrUnsafeURL is returned for provider URLs the server refuses to fetch.
+var ErrUnsafeURL = errors.New("identity provider URL is not allowed")
+
+// cgnat is the carrier-grade NAT range, which netip does not count as private.
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewPublicHTTPClient returns a client for issuer URLs supplied by
+// organization admins. It only speaks https and refuses to connect to
+// loopback, private and link-local addresses. The address check runs on the
+// resolved IP at dial time, so DNS names pointing inwards and redirects are
+// refused too. allowInsecure lifts both rules for local development
+// against cmd/mockidp.
+func NewPublicHTTPClient(allowInsecure bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if allowInsecure {
+		return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   refusePrivateAddress,
+	}
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return &http.Client{Timeout: 10 * time.Second, Transport: httpsOnly{transport}}
+}
+
+type httpsOnly struct {
+	next http.RoundTripper
+}
+
+func (t httpsOnly) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %s is not https", ErrUnsafeURL, req.URL.Redacted())
+	}
+	return t.next.RoundTrip(req)
+}
+
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnsafeURL, address)
+	}
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s is not a public address", ErrUnsafeURL, addrPort.Addr())
+	}
+	return nil
+}
+
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !cgnat.Contains(ip)
+}
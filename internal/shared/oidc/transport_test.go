@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+		{"224.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublic(netip.MustParseAddr(tt.ip)); got != tt.want {
+			t.Errorf("isPublic(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestPublicHTTPClientRefusesUnsafeURLs(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	plain := httptest.NewServer(ok)
+	defer plain.Close()
+	tls := httptest.NewTLSServer(ok)
+	defer tls.Close()
+
+	client := NewClient(NewPublicHTTPClient(false), time.Hour)
+	for _, issuer := range []string{plain.URL, tls.URL, "http://example.com"} {
+		if _, err := client.Discover(context.Background(), issuer); !errors.Is(err, ErrUnsafeURL) {
+			t.Errorf("Discover(%s) error = %v, want ErrUnsafeURL", issuer, err)
+		}
+	}
+
+	res, err := NewPublicHTTPClient(true).Get(plain.URL)
+	if err != nil {
+		t.Fatalf("insecure client: %v", err)
+	}
+	res.Body.Close()
+}
@@ -0,0 +1,352 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// Attributes is a resource flattened for filtering: lower-cased attribute
+// paths ("username", "name.givenname", "emails.value") to their values.
+type Attributes map[string][]string
+
+// Filter is a parsed filter expression (RFC 7644 section 3.4.2.2).
+type Filter interface {
+	Match(attrs Attributes) bool
+}
+
+// ParseFilter supports attribute comparisons (eq, ne, co, sw, ew, gt, ge,
+// lt, le, pr) combined with and, or, not and parentheses. Comparisons are
+// case-insensitive, as every attribute served here has caseExact=false.
+// Value paths such as emails[type eq "work"] are not supported.
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, invalidFilter("unexpected %q", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+// Equality returns the attribute and lower-cased value of a filter that is
+// a single eq comparison, such as userName eq "bjensen", so callers can look
+// it up directly instead of matching every resource.
+func Equality(f Filter) (attribute, value string, ok bool) {
+	c, ok := f.(comparison)
+	if !ok || c.op != "eq" || c.null {
+		return "", "", false
+	}
+	return c.attribute, c.value, true
+}
+
+// Path is a PATCH target: an attribute, optionally narrowed to the values
+// matching a filter, e.g. members[value eq "2819c223"].
+type Path struct {
+	Attribute    string
+	Filter       Filter
+	SubAttribute string
+}
+
+func ParsePath(s string) (*Path, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '[')
+	if open < 0 {
+		return &Path{Attribute: normalizeAttribute(s)}, nil
+	}
+
+	closing := strings.LastIndexByte(s, ']')
+	if closing < open {
+		return nil, NewError(http.StatusBadRequest, ErrInvalidPath, "unbalanced brackets in path")
+	}
+	f, err := ParseFilter(s[open+1 : closing])
+	if err != nil {
+		return nil, NewError(http.StatusBadRequest, ErrInvalidPath, err.Error())
+	}
+	return &Path{
+		Attribute:    normalizeAttribute(s[:open]),
+		Filter:       f,
+		SubAttribute: strings.ToLower(strings.TrimPrefix(s[closing+1:], ".")),
+	}, nil
+}
+
+// normalizeAttribute lower-cases a path and drops a core schema prefix such
+// as "urn:ietf:params:scim:schemas:core:2.0:User:".
+func normalizeAttribute(attr string) string {
+	attr = strings.TrimSpace(attr)
+	if strings.HasPrefix(strings.ToLower(attr), "urn:") {
+		if i := strings.LastIndexByte(attr, ':'); i >= 0 {
+			attr = attr[i+1:]
+		}
+	}
+	return strings.ToLower(attr)
+}
+
+func invalidFilter(format string, args ...any) *Error {
+	return Errorf(http.StatusBadRequest, ErrInvalidFilter, format, args...)
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, invalidFilter("unterminated string")
+			}
+			var str string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &str); err != nil {
+				return nil, invalidFilter("invalid string %s", s[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: str})
+			i = end + 1
+		case c == '[' || c == ']':
+			return nil, invalidFilter("value paths are not supported")
+		default:
+			end := i
+			for end < len(s) && !unicode.IsSpace(rune(s[end])) && !strings.ContainsRune(`()"[]`, rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[i:end]})
+			i = end
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, invalidFilter("empty filter")
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peekWord(word string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord && strings.EqualFold(p.tokens[p.pos].text, word)
+}
+
+func (p *parser) or() (Filter, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("or") {
+		p.pos++
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Filter, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("and") {
+		p.pos++
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) factor() (Filter, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, invalidFilter("unexpected end of filter")
+	}
+
+	if p.peekWord("not") {
+		p.pos++
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOpen {
+			return nil, invalidFilter("not must be followed by a parenthesized expression")
+		}
+		f, err := p.group()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{f}, nil
+	}
+	if p.tokens[p.pos].kind == tokenOpen {
+		return p.group()
+	}
+	return p.comparison()
+}
+
+func (p *parser) group() (Filter, error) {
+	p.pos++ // (
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenClose {
+		return nil, invalidFilter("missing closing parenthesis")
+	}
+	p.pos++
+	return f, nil
+}
+
+func (p *parser) comparison() (Filter, error) {
+	attr := p.tokens[p.pos]
+	if attr.kind != tokenWord {
+		return nil, invalidFilter("expected an attribute, got %q", attr.text)
+	}
+	p.pos++
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenWord {
+		return nil, invalidFilter("expected an operator after %s", attr.text)
+	}
+	op := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+
+	c := comparison{attribute: normalizeAttribute(attr.text), op: op}
+	switch op {
+	case "pr":
+		return c, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, invalidFilter("unknown operator %q", op)
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, invalidFilter("expected a value after %s %s", attr.text, op)
+	}
+	value := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case value.kind == tokenString:
+		c.value = strings.ToLower(value.text)
+	case value.kind == tokenWord && strings.EqualFold(value.text, "null"):
+		c.null = true
+	case value.kind == tokenWord:
+		// true, false and numbers compare by their text.
+		c.value = strings.ToLower(value.text)
+	default:
+		return nil, invalidFilter("expected a value, got %q", value.text)
+	}
+	if c.null && op != "eq" && op != "ne" {
+		return nil, invalidFilter("null can only be compared with eq or ne")
+	}
+	return c, nil
+}
+
+type comparison struct {
+	attribute string
+	op        string
+	value     string
+	null      bool
+}
+
+func (c comparison) Match(attrs Attributes) bool {
+	var values []string
+	for _, v := range attrs[c.attribute] {
+		if v != "" {
+			values = append(values, strings.ToLower(v))
+		}
+	}
+
+	if c.op == "pr" {
+		return len(values) > 0
+	}
+	if c.null {
+		return (len(values) == 0) == (c.op == "eq")
+	}
+	if c.op == "ne" {
+		for _, v := range values {
+			if v == c.value {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, v := range values {
+		if c.matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparison) matches(v string) bool {
+	switch c.op {
+	case "eq":
+		return v == c.value
+	case "co":
+		return strings.Contains(v, c.value)
+	case "sw":
+		return strings.HasPrefix(v, c.value)
+	case "ew":
+		return strings.HasSuffix(v, c.value)
+	case "gt":
+		return v > c.value
+	case "ge":
+		return v >= c.value
+	case "lt":
+		return v < c.value
+	case "le":
+		return v <= c.value
+	}
+	return false
+}
+
+type andFilter struct{ left, right Filter }
+
+func (f andFilter) Match(attrs Attributes) bool {
+	return f.left.Match(attrs) && f.right.Match(attrs)
+}
+
+type orFilter struct{ left, right Filter }
+
+func (f orFilter) Match(attrs Attributes) bool {
+	return f.left.Match(attrs) || f.right.Match(attrs)
+}
+
+type notFilter struct{ inner Filter }
+
+func (f notFilter) Match(attrs Attributes) bool {
+	return !f.inner.Match(attrs)
+}
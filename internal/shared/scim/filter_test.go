@@ -0,0 +1,127 @@
+package scim
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	active := true
+	user := &User{
+		ID:       "2819c223",
+		UserName: "Ada@Example.edu",
+		Name:     &Name{GivenName: "Ada", FamilyName: "Lovelace"},
+		Emails:   []Email{{Value: "ada@example.edu", Primary: true}, {Value: "ada@home.example"}},
+		Active:   &active,
+	}
+	attrs := user.Attributes()
+
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{"eq is case-insensitive", `userName eq "ada@example.edu"`, true},
+		{"eq mismatch", `userName eq "bob@example.edu"`, false},
+		{"operator is case-insensitive", `userName EQ "ada@example.edu"`, true},
+		{"schema prefix is dropped", `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "ada@example.edu"`, true},
+		{"sub-attribute", `name.familyName sw "love"`, true},
+		{"multi-valued matches any value", `emails.value eq "ada@home.example"`, true},
+		{"ne excludes every value", `emails ne "ada@home.example"`, false},
+		{"boolean", `active eq true`, true},
+		{"present", `name.givenName pr`, true},
+		{"absent", `externalId pr`, false},
+		{"null", `externalId eq null`, true},
+		{"and", `name.givenName eq "Ada" and active eq false`, false},
+		{"or", `name.givenName eq "Bob" or id eq "2819c223"`, true},
+		{"not with grouping", `not (userName co "example") or (id ew "23" and active eq true)`, true},
+		{"escaped quote", `name.givenName eq "A\"da"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error = %v", tt.filter, err)
+			}
+			if got := f.Match(attrs); got != tt.want {
+				t.Errorf("ParseFilter(%q).Match() = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEquality(t *testing.T) {
+	tests := []struct {
+		filter    string
+		attribute string
+		value     string
+		ok        bool
+	}{
+		{`userName eq "Ada@Example.edu"`, "username", "ada@example.edu", true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:externalId EQ "a1"`, "externalid", "a1", true},
+		{`userName co "ada"`, "", "", false},
+		{`externalId eq null`, "", "", false},
+		{`userName eq "ada@example.edu" and active eq true`, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error = %v", tt.filter, err)
+			}
+			attribute, value, ok := Equality(f)
+			if attribute != tt.attribute || value != tt.value || ok != tt.ok {
+				t.Errorf("Equality() = %q, %q, %v, want %q, %q, %v", attribute, value, ok, tt.attribute, tt.value, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName like "a"`,
+		`userName eq`,
+		`userName eq "unterminated`,
+		`(userName eq "a"`,
+		`not userName eq "a"`,
+		`emails[type eq "work"]`,
+		`userName gt null`,
+		`userName eq "a" extra`,
+	} {
+		_, err := ParseFilter(filter)
+		var scimErr *Error
+		if !errors.As(err, &scimErr) || scimErr.ScimType != ErrInvalidFilter {
+			t.Errorf("ParseFilter(%q) error = %v, want invalidFilter", filter, err)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := ParsePath(`members[value eq "2819c223"]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.Attribute != "members" || path.Filter == nil {
+		t.Fatalf("ParsePath() = %+v", path)
+	}
+	if !path.Filter.Match(Attributes{"value": {"2819c223"}}) || path.Filter.Match(Attributes{"value": {"other"}}) {
+		t.Error("ParsePath() filter does not select the member by value")
+	}
+
+	path, err = ParsePath(`emails[type eq "work"].value`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.Attribute != "emails" || path.SubAttribute != "value" {
+		t.Errorf("ParsePath() = %+v", path)
+	}
+
+	path, err = ParsePath(`name.givenName`)
+	if err != nil || path.Attribute != "name.givenname" || path.Filter != nil {
+		t.Errorf("ParsePath() = %+v, %v", path, err)
+	}
+}
@@ -0,0 +1,247 @@
+// Package scim holds the SCIM 2.0 (RFC 7643/7644) wire types shared by the
+// provisioning endpoints: resources, list and patch messages, errors and the
+// filter language.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	UserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	ContentType = "application/scim+json"
+)
+
+// scimType values from RFC 7644 section 3.12.
+const (
+	ErrInvalidFilter = "invalidFilter"
+	ErrInvalidPath   = "invalidPath"
+	ErrInvalidValue  = "invalidValue"
+	ErrInvalidSyntax = "invalidSyntax"
+	ErrNoTarget      = "noTarget"
+	ErrMutability    = "mutability"
+	ErrUniqueness    = "uniqueness"
+)
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Ref points from one resource to another, e.g. a group member or one of a
+// user's groups.
+type Ref struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type User struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Groups      []Ref    `json:"groups,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary email, or the first one when none is
+// marked primary.
+func (u *User) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Attributes flattens the user for filter matching.
+func (u *User) Attributes() Attributes {
+	attrs := Attributes{
+		"id":          {u.ID},
+		"username":    {u.UserName},
+		"displayname": {u.DisplayName},
+		"externalid":  {u.ExternalID},
+	}
+	if u.Name != nil {
+		attrs["name.givenname"] = []string{u.Name.GivenName}
+		attrs["name.familyname"] = []string{u.Name.FamilyName}
+		attrs["name.formatted"] = []string{u.Name.Formatted}
+	}
+	if u.Active != nil {
+		attrs["active"] = []string{strconv.FormatBool(*u.Active)}
+	}
+	for _, e := range u.Emails {
+		attrs["emails"] = append(attrs["emails"], e.Value)
+		attrs["emails.value"] = append(attrs["emails.value"], e.Value)
+	}
+	for _, g := range u.Groups {
+		attrs["groups"] = append(attrs["groups"], g.Value)
+		attrs["groups.value"] = append(attrs["groups.value"], g.Value)
+		attrs["groups.display"] = append(attrs["groups.display"], g.Display)
+	}
+	addMeta(attrs, u.Meta)
+	return attrs
+}
+
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Ref    `json:"members"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// Attributes flattens the group for filter matching.
+func (g *Group) Attributes() Attributes {
+	attrs := Attributes{
+		"id":          {g.ID},
+		"displayname": {g.DisplayName},
+		"externalid":  {g.ExternalID},
+	}
+	for _, m := range g.Members {
+		attrs["members"] = append(attrs["members"], m.Value)
+		attrs["members.value"] = append(attrs["members.value"], m.Value)
+		attrs["members.display"] = append(attrs["members.display"], m.Display)
+	}
+	addMeta(attrs, g.Meta)
+	return attrs
+}
+
+func addMeta(attrs Attributes, meta *Meta) {
+	if meta == nil {
+		return
+	}
+	if meta.Created != nil {
+		attrs["meta.created"] = []string{meta.Created.UTC().Format(time.RFC3339)}
+	}
+	if meta.LastModified != nil {
+		attrs["meta.lastmodified"] = []string{meta.LastModified.UTC().Format(time.RFC3339)}
+	}
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+func NewListResponse(resources []any, total, startIndex int) *ListResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+	return &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+type PatchRequest struct {
+	Schemas    []string    `json:"schemas"`
+	Operations []Operation `json:"Operations"`
+}
+
+// Operation is one PATCH step. Op is "add", "replace" or "remove"; some
+// identity providers capitalize it, so compare case-insensitively.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is both the SCIM error response body and a Go error.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+
+	code int
+}
+
+func NewError(code int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(code),
+		ScimType: scimType,
+		Detail:   detail,
+		code:     code,
+	}
+}
+
+func Errorf(code int, scimType, format string, args ...any) *Error {
+	return NewError(code, scimType, fmt.Sprintf(format, args...))
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func (e *Error) Code() int {
+	return e.code
+}
+
+func WriteJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func WriteError(w http.ResponseWriter, err *Error) {
+	WriteJSON(w, err.code, err)
+}
+
+// ParseBool accepts JSON booleans as well as the "True"/"False" strings some
+// identity providers send.
+func ParseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, NewError(http.StatusBadRequest, ErrInvalidValue, "expected a boolean")
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, NewError(http.StatusBadRequest, ErrInvalidValue, "expected a boolean")
+	}
+	return b, nil
+}
@@ -0,0 +1,99 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func buildXLSX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Roster" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>name</t></si>
+  <si><t>email</t></si>
+  <si><r><t>Ada </t></r><r><t>Lovelace</t></r></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+    <row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3" t="inlineStr"><is><t>ada@example.com</t></is></c><c r="D3"><v>42</v></c></row>
+  </sheetData>
+</worksheet>`,
+	})
+
+	got, err := Read("roster.XLSX", data)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	want := [][]string{
+		{"name", "email"},
+		nil,
+		{"Ada Lovelace", "", "ada@example.com", "42"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %q, want %q", got, want)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	got, err := Read("roster.csv", []byte("\ufeffname,email\nAda Lovelace, ada@example.com\nshort\n"))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	want := [][]string{
+		{"name", "email"},
+		{"Ada Lovelace", "ada@example.com"},
+		{"short"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %q, want %q", got, want)
+	}
+}
+
+func TestReadUnsupported(t *testing.T) {
+	if _, err := Read("roster.xls", nil); err != ErrUnsupportedFormat {
+		t.Errorf("Read() error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "Z9": 25, "AA10": 26, "AB2": 27}
+	for ref, want := range tests {
+		got, err := columnIndex(ref)
+		if err != nil || got != want {
+			t.Errorf("columnIndex(%q) = %d, %v; want %d", ref, got, err, want)
+		}
+	}
+}
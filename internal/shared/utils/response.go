@@ -56,6 +56,18 @@ func UnprocessableEntity(w http.ResponseWriter, message string) {
 	base(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", nil, message)
 }
 
+// Invalid responds 422 with data describing what failed validation, such as a
+// per-row report, alongside the message.
+func Invalid(w http.ResponseWriter, data any, message string) {
+	base(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", data, message)
+}
+
+// Error responds with a caller-chosen status string, for failures that clients
+// need to tell apart from the generic ones above.
+func Error(w http.ResponseWriter, code int, status string, message string) {
+	base(w, code, status, nil, message)
+}
+
 // --- Server Error Helpers ---
 
 func InternalServerError(w http.ResponseWriter, message string) {
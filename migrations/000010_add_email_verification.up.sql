@@ -0,0 +1,5 @@
+-- Track when a user's email address was verified
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamp WITH TIME ZONE;
+
+-- Existing accounts predate verification, treat them as verified
+UPDATE "users" SET "email_verified_at" = "created_at" WHERE "email_verified_at" IS NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE "organizations" DROP COLUMN IF EXISTS "mfa_required_roles";
+
+DROP INDEX IF EXISTS idx_user_recovery_codes_user;
+DROP TABLE IF EXISTS "user_recovery_codes";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "totp_enabled_at";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "totp_secret";
@@ -0,0 +1,19 @@
+-- 1. TOTP enrollment state per user. A secret without totp_enabled_at is a
+-- pending enrollment that has not been confirmed with a code yet.
+ALTER TABLE "users"
+ADD COLUMN "totp_secret" varchar,
+ADD COLUMN "totp_enabled_at" timestamp WITH TIME ZONE;
+
+-- 2. One-time recovery codes, stored as SHA-256 digests
+CREATE TABLE "user_recovery_codes" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamp WITH TIME ZONE,
+  "created_at" timestamp WITH TIME ZONE DEFAULT (now())
+);
+
+CREATE INDEX idx_user_recovery_codes_user ON user_recovery_codes (user_id) WHERE used_at IS NULL;
+
+-- 3. Role names for which an organization requires two-factor authentication
+ALTER TABLE "organizations" ADD COLUMN "mfa_required_roles" varchar[] NOT NULL DEFAULT '{}';
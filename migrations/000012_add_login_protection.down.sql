@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_login_history_org;
+DROP INDEX IF EXISTS idx_login_history_user;
+DROP TABLE IF EXISTS "login_history";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "locked_until";
@@ -0,0 +1,17 @@
+-- 1. Temporary account lockout after too many failed logins
+ALTER TABLE "users" ADD COLUMN "locked_until" timestamp WITH TIME ZONE;
+
+-- 2. Login history (successes, failures, lockouts and unlocks)
+CREATE TABLE "login_history" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" uuid REFERENCES "users"("id") ON DELETE CASCADE,
+  "organization_id" uuid REFERENCES "organizations"("id"),
+  "email" varchar NOT NULL,
+  "event" varchar NOT NULL,
+  "ip_address" varchar,
+  "user_agent" text,
+  "created_at" timestamp WITH TIME ZONE DEFAULT (now())
+);
+
+CREATE INDEX idx_login_history_user ON login_history (user_id, created_at DESC);
+CREATE INDEX idx_login_history_org ON login_history (organization_id, created_at DESC);
@@ -0,0 +1,15 @@
+-- One row per login. The id is also the refresh token family id and the
+-- "sid" claim of every access token issued to the session.
+CREATE TABLE "user_sessions" (
+  "id" uuid PRIMARY KEY,
+  "user_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "organization_id" uuid NOT NULL REFERENCES "organizations"("id"),
+  "user_agent" varchar,
+  "ip_address" varchar,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (now()),
+  "last_seen_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (now()),
+  "expires_at" timestamp WITH TIME ZONE NOT NULL,
+  "revoked_at" timestamp WITH TIME ZONE
+);
+
+CREATE INDEX idx_user_sessions_user ON user_sessions (user_id) WHERE revoked_at IS NULL;
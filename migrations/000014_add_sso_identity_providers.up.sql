@@ -0,0 +1,24 @@
+-- 1. One OpenID Connect provider per organization
+CREATE TABLE "organization_identity_providers" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "organization_id" uuid UNIQUE NOT NULL REFERENCES "organizations"("id") ON DELETE CASCADE,
+  "issuer" varchar NOT NULL,
+  "client_id" varchar NOT NULL,
+  "client_secret" varchar,
+  "default_role" varchar NOT NULL DEFAULT 'student',
+  "auto_provision" boolean NOT NULL DEFAULT false,
+  "allowed_domains" varchar[] NOT NULL DEFAULT '{}',
+  "is_enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp WITH TIME ZONE DEFAULT (now()),
+  "updated_at" timestamp WITH TIME ZONE DEFAULT (now())
+);
+
+-- 2. Links an IdP subject to a local user so later logins survive email changes
+CREATE TABLE "user_identities" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "provider_id" uuid NOT NULL REFERENCES "organization_identity_providers"("id") ON DELETE CASCADE,
+  "subject" varchar NOT NULL,
+  "created_at" timestamp WITH TIME ZONE DEFAULT (now()),
+  UNIQUE ("provider_id", "subject")
+);
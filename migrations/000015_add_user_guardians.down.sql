@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_guardian;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "guardian_id";
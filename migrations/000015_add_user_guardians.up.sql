@@ -0,0 +1,4 @@
+-- Guardian (parent) accounts are regular users; a child points at its guardian.
+ALTER TABLE "users" ADD COLUMN "guardian_id" uuid REFERENCES "users"("id") ON DELETE SET NULL;
+
+CREATE INDEX idx_users_guardian ON users (guardian_id) WHERE guardian_id IS NOT NULL;
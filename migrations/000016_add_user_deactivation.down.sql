@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_org_name;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deactivated_at";
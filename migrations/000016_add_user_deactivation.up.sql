@@ -0,0 +1,4 @@
+-- Deactivated accounts keep their data but cannot sign in.
+ALTER TABLE "users" ADD COLUMN "deactivated_at" timestamp WITH TIME ZONE;
+
+CREATE INDEX idx_users_org_name ON users (organization_id, last_name, first_name) WHERE deleted_at IS NULL;
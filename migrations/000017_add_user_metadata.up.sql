@@ -0,0 +1,2 @@
+-- Profile details users maintain themselves (address, blood type).
+ALTER TABLE "users" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
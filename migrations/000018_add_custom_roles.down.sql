@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_roles_org_name;
+
+DELETE FROM user_roles WHERE role_id IN (SELECT id FROM roles WHERE organization_id IS NOT NULL);
+DELETE FROM roles WHERE organization_id IS NOT NULL;
+
+ALTER TABLE "roles"
+DROP COLUMN IF EXISTS "description",
+DROP COLUMN IF EXISTS "organization_id";
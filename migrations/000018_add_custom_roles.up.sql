@@ -0,0 +1,8 @@
+-- Roles without an organization are the built-in ones shared by every
+-- organization; the rest are custom roles defined by one organization.
+ALTER TABLE "roles"
+ADD COLUMN "organization_id" uuid REFERENCES "organizations"("id") ON DELETE CASCADE,
+ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_roles_org_name ON roles (organization_id, lower(name))
+WHERE organization_id IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,21 @@
+-- Audit trail of support staff acting as other users: one "start" row per
+-- issued impersonation token and one "write" row per state-changing request
+-- made with it.
+CREATE TABLE "impersonation_events" (
+  "id" uuid PRIMARY KEY,
+  "organization_id" uuid NOT NULL REFERENCES "organizations"("id"),
+  "actor_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "session_id" uuid NOT NULL,
+  "action" varchar NOT NULL,
+  "method" varchar,
+  "path" varchar,
+  "status" int,
+  "reason" varchar,
+  "request_id" varchar,
+  "ip_address" varchar,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_impersonation_events_org ON impersonation_events (organization_id, created_at DESC);
+CREATE INDEX idx_impersonation_events_session ON impersonation_events (session_id);
@@ -0,0 +1,21 @@
+-- Service accounts are users that cannot sign in with a password and
+-- authenticate with API keys instead.
+ALTER TABLE "users" ADD COLUMN "is_service_account" bool NOT NULL DEFAULT false;
+
+CREATE TABLE "api_keys" (
+  "id" uuid PRIMARY KEY,
+  "organization_id" uuid NOT NULL REFERENCES "organizations"("id"),
+  "service_account_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "name" varchar NOT NULL,
+  "prefix" varchar NOT NULL,
+  "key_hash" varchar NOT NULL,
+  "scopes" jsonb NOT NULL DEFAULT '{}',
+  "expires_at" timestamp WITH TIME ZONE,
+  "last_used_at" timestamp WITH TIME ZONE,
+  "revoked_at" timestamp WITH TIME ZONE,
+  "created_by" uuid REFERENCES "users"("id") ON DELETE SET NULL,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys (prefix);
+CREATE INDEX idx_api_keys_service_account ON api_keys (service_account_id);
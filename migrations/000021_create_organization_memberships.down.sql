@@ -0,0 +1,10 @@
+-- Only roles held in the home organization survive the rollback.
+DELETE FROM user_roles ur
+USING users u
+WHERE u.id = ur.user_id AND ur.organization_id <> u.organization_id;
+
+ALTER TABLE "user_roles" DROP CONSTRAINT IF EXISTS user_roles_pkey;
+ALTER TABLE "user_roles" DROP COLUMN IF EXISTS "organization_id";
+ALTER TABLE "user_roles" ADD PRIMARY KEY (user_id, role_id);
+
+DROP TABLE IF EXISTS "organization_memberships";
@@ -0,0 +1,26 @@
+-- A user's home organization stays in users.organization_id; memberships
+-- list every organization the user can work in, the home one included.
+CREATE TABLE "organization_memberships" (
+  "user_id" uuid NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "organization_id" uuid NOT NULL REFERENCES "organizations"("id") ON DELETE CASCADE,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (now()),
+  PRIMARY KEY (user_id, organization_id)
+);
+
+CREATE INDEX idx_organization_memberships_org ON organization_memberships (organization_id);
+
+INSERT INTO organization_memberships (user_id, organization_id)
+SELECT id, organization_id FROM users WHERE organization_id IS NOT NULL;
+
+-- Roles are held per organization.
+ALTER TABLE "user_roles" ADD COLUMN "organization_id" uuid REFERENCES "organizations"("id") ON DELETE CASCADE;
+
+UPDATE user_roles ur SET organization_id = u.organization_id
+FROM users u
+WHERE u.id = ur.user_id;
+
+DELETE FROM user_roles WHERE organization_id IS NULL;
+
+ALTER TABLE "user_roles" ALTER COLUMN "organization_id" SET NOT NULL;
+ALTER TABLE "user_roles" DROP CONSTRAINT IF EXISTS user_roles_pkey;
+ALTER TABLE "user_roles" ADD PRIMARY KEY (user_id, organization_id, role_id);
@@ -0,0 +1,2 @@
+ALTER TABLE "organizations" DROP COLUMN IF EXISTS "suspension_reason";
+ALTER TABLE "organizations" DROP COLUMN IF EXISTS "suspended_at";
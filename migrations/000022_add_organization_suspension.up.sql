@@ -0,0 +1,4 @@
+-- Suspended organizations keep their data, but nobody can sign in to them or
+-- use their API keys until a system administrator reactivates them.
+ALTER TABLE "organizations" ADD COLUMN "suspended_at" timestamp WITH TIME ZONE;
+ALTER TABLE "organizations" ADD COLUMN "suspension_reason" text;
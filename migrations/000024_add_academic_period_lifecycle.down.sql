@@ -0,0 +1,6 @@
+ALTER TABLE "education_levels" DROP COLUMN IF EXISTS "sequence";
+DROP INDEX IF EXISTS "idx_academic_periods_previous";
+DROP INDEX IF EXISTS "idx_academic_periods_one_active";
+ALTER TABLE "academic_periods" DROP CONSTRAINT IF EXISTS "academic_periods_status_check";
+ALTER TABLE "academic_periods" DROP COLUMN IF EXISTS "previous_period_id";
+ALTER TABLE "academic_periods" DROP COLUMN IF EXISTS "status";
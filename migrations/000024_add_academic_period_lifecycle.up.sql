@@ -0,0 +1,31 @@
+-- Academic periods move planned -> active -> closed -> archived. is_active is
+-- kept in step with status for older readers.
+ALTER TABLE "academic_periods" ADD COLUMN "status" varchar NOT NULL DEFAULT 'planned';
+ALTER TABLE "academic_periods" ADD COLUMN "previous_period_id" uuid REFERENCES "academic_periods"("id");
+
+-- Where several periods were flagged active, the latest one stays active.
+UPDATE "academic_periods" p SET "status" = 'active'
+WHERE p."id" = (
+  SELECT q."id" FROM "academic_periods" q
+  WHERE q."organization_id" = p."organization_id" AND q."is_active" AND q."deleted_at" IS NULL
+  ORDER BY q."start_date" DESC NULLS LAST, q."created_at" DESC
+  LIMIT 1
+);
+UPDATE "academic_periods" SET "status" = 'closed'
+WHERE "status" = 'planned' AND ("is_active" OR "end_date" < CURRENT_DATE);
+UPDATE "academic_periods" SET "is_active" = ("status" = 'active');
+
+ALTER TABLE "academic_periods" ADD CONSTRAINT "academic_periods_status_check"
+  CHECK ("status" IN ('planned', 'active', 'closed', 'archived'));
+
+CREATE UNIQUE INDEX "idx_academic_periods_one_active"
+ON "academic_periods" ("organization_id")
+WHERE "status" = 'active' AND "deleted_at" IS NULL;
+
+CREATE UNIQUE INDEX "idx_academic_periods_previous"
+ON "academic_periods" ("previous_period_id")
+WHERE "deleted_at" IS NULL;
+
+-- Position of a level in the organization's progression, used to promote
+-- students at rollover; levels without one are never promoted automatically.
+ALTER TABLE "education_levels" ADD COLUMN "sequence" int;
@@ -0,0 +1,52 @@
+DROP POLICY IF EXISTS "tenant_isolation" ON "progress_trackers";
+ALTER TABLE "progress_trackers" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "submissions";
+ALTER TABLE "submissions" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "enrollments";
+ALTER TABLE "enrollments" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "program_courses";
+ALTER TABLE "program_courses" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "contents";
+ALTER TABLE "contents" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "lessons";
+ALTER TABLE "lessons" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "modules";
+ALTER TABLE "modules" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "section_members";
+ALTER TABLE "section_members" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "cohort_members";
+ALTER TABLE "cohort_members" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "sections";
+ALTER TABLE "sections" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "tenant_isolation" ON "attachments";
+ALTER TABLE "attachments" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "events";
+ALTER TABLE "events" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "cohorts";
+ALTER TABLE "cohorts" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "assessments";
+ALTER TABLE "assessments" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "courses";
+ALTER TABLE "courses" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "programs";
+ALTER TABLE "programs" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "subjects";
+ALTER TABLE "subjects" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "education_levels";
+ALTER TABLE "education_levels" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "academic_periods";
+ALTER TABLE "academic_periods" DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS app_current_org();
+
+-- Revokes the grants and default privileges given to the role in this
+-- database; the role itself is dropped when nothing else uses it.
+DROP OWNED BY lms_tenant;
+DO $$
+BEGIN
+  DROP ROLE IF EXISTS lms_tenant;
+EXCEPTION WHEN dependent_objects_still_exist THEN
+  RAISE NOTICE 'lms_tenant is still used by another database';
+END
+$$;
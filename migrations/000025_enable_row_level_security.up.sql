@@ -0,0 +1,100 @@
+-- Tenant isolation in the database. Requests scoped to an organization run
+-- as lms_tenant with app.org_id set for the transaction (see
+-- internal/shared/database). lms_tenant owns no tables, so the policies below
+-- apply to it even when the application connects as the owner or a
+-- superuser; connections that never switch role are unaffected.
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'lms_tenant') THEN
+    CREATE ROLE lms_tenant NOLOGIN;
+  END IF;
+END
+$$;
+
+GRANT lms_tenant TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO lms_tenant;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO lms_tenant;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO lms_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO lms_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO lms_tenant;
+
+-- NULL when the setting is missing or empty, which matches no row.
+CREATE FUNCTION app_current_org() RETURNS uuid
+LANGUAGE sql STABLE AS $$
+  SELECT NULLIF(current_setting('app.org_id', true), '')::uuid
+$$;
+
+-- Tables that carry their organization.
+ALTER TABLE "academic_periods" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "academic_periods" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "education_levels" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "education_levels" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "subjects" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "subjects" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "programs" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "programs" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "courses" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "courses" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "assessments" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "assessments" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "cohorts" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "cohorts" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "events" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "events" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "attachments" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "attachments" USING ("organization_id" = app_current_org());
+
+-- Tables that belong to one of the above. The parent lookups are filtered by
+-- the parent's own policy.
+ALTER TABLE "sections" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "sections"
+  USING (EXISTS (SELECT 1 FROM "cohorts" c WHERE c."id" = "sections"."cohort_id"));
+
+ALTER TABLE "cohort_members" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "cohort_members"
+  USING (EXISTS (SELECT 1 FROM "cohorts" c WHERE c."id" = "cohort_members"."cohort_id"));
+
+ALTER TABLE "section_members" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "section_members"
+  USING (EXISTS (SELECT 1 FROM "sections" s WHERE s."id" = "section_members"."section_id"));
+
+ALTER TABLE "modules" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "modules"
+  USING (EXISTS (SELECT 1 FROM "courses" c WHERE c."id" = "modules"."course_id"));
+
+ALTER TABLE "lessons" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "lessons"
+  USING (EXISTS (SELECT 1 FROM "modules" m WHERE m."id" = "lessons"."module_id"));
+
+ALTER TABLE "contents" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "contents"
+  USING (EXISTS (SELECT 1 FROM "lessons" l WHERE l."id" = "contents"."lesson_id")
+      OR EXISTS (SELECT 1 FROM "assessments" a WHERE a."id" = "contents"."assessment_id"));
+
+ALTER TABLE "program_courses" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "program_courses"
+  USING (EXISTS (SELECT 1 FROM "programs" p WHERE p."id" = "program_courses"."program_id"));
+
+ALTER TABLE "enrollments" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "enrollments"
+  USING (EXISTS (SELECT 1 FROM "courses" c WHERE c."id" = "enrollments"."course_id"));
+
+ALTER TABLE "submissions" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "submissions"
+  USING (EXISTS (SELECT 1 FROM "assessments" a WHERE a."id" = "submissions"."assessment_id"));
+
+ALTER TABLE "progress_trackers" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "progress_trackers"
+  USING (EXISTS (SELECT 1 FROM "enrollments" e WHERE e."id" = "progress_trackers"."enrollment_id"));
+
+-- Users, roles, memberships, sessions and the other identity tables stay
+-- unrestricted: sign-in and organization switching read them before or
+-- across organizations, and their repositories filter by organization.
@@ -0,0 +1 @@
+ALTER TABLE "organization_identity_providers" DROP COLUMN IF EXISTS "configured_by";
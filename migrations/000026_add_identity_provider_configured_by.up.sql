@@ -0,0 +1,6 @@
+-- The administrator who last saved the provider. A first SSO login only
+-- links an existing account that this administrator could manage, so
+-- providers saved before this column existed link no existing accounts
+-- until they are saved again.
+ALTER TABLE "organization_identity_providers"
+ADD COLUMN "configured_by" uuid REFERENCES "users"("id") ON DELETE SET NULL;
@@ -0,0 +1,20 @@
+DROP POLICY IF EXISTS "tenant_isolation" ON "user_recovery_codes";
+ALTER TABLE "user_recovery_codes" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "organization_identity_providers";
+ALTER TABLE "organization_identity_providers" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "impersonation_events";
+ALTER TABLE "impersonation_events" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "login_history";
+ALTER TABLE "login_history" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "api_keys";
+ALTER TABLE "api_keys" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "user_sessions";
+ALTER TABLE "user_sessions" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "roles";
+ALTER TABLE "roles" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "user_roles";
+ALTER TABLE "user_roles" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "organization_memberships";
+ALTER TABLE "organization_memberships" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "users";
+ALTER TABLE "users" DISABLE ROW LEVEL SECURITY;
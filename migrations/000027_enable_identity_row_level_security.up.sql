@@ -0,0 +1,45 @@
+-- Tenant isolation for the identity tables, on top of 000025. Signing in,
+-- switching organization, a user's own sessions and lookups by email span
+-- organizations by design and run unscoped (database.Unscoped); every other
+-- scoped statement only sees the current organization's accounts.
+
+-- An account is visible in its home organization and in every organization
+-- it is a member of.
+ALTER TABLE "users" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "users"
+  USING ("organization_id" = app_current_org()
+      OR EXISTS (SELECT 1 FROM "organization_memberships" m
+                 WHERE m."user_id" = "users"."id" AND m."organization_id" = app_current_org()));
+
+ALTER TABLE "organization_memberships" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "organization_memberships" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "user_roles" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "user_roles" USING ("organization_id" = app_current_org());
+
+-- Built-in roles have no organization and are shared, but only custom roles
+-- of the current organization may be written.
+ALTER TABLE "roles" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "roles"
+  USING ("organization_id" IS NULL OR "organization_id" = app_current_org())
+  WITH CHECK ("organization_id" = app_current_org());
+
+ALTER TABLE "user_sessions" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "user_sessions" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "api_keys" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "api_keys" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "login_history" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "login_history" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "impersonation_events" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "impersonation_events" USING ("organization_id" = app_current_org());
+
+ALTER TABLE "organization_identity_providers" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "organization_identity_providers" USING ("organization_id" = app_current_org());
+
+-- Recovery codes follow their user.
+ALTER TABLE "user_recovery_codes" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "user_recovery_codes"
+  USING (EXISTS (SELECT 1 FROM "users" u WHERE u."id" = "user_recovery_codes"."user_id"));
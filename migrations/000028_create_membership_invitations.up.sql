@@ -0,0 +1,19 @@
+-- Organizations invite accounts by email instead of attaching them directly;
+-- the membership only exists once the account's owner accepts. Inviting the
+-- same email again replaces the pending invitation.
+CREATE TABLE "membership_invitations" (
+  "id" uuid PRIMARY KEY,
+  "organization_id" uuid NOT NULL REFERENCES "organizations"("id") ON DELETE CASCADE,
+  "email" varchar NOT NULL,
+  "role_ids" uuid[] NOT NULL DEFAULT '{}',
+  "invited_by" uuid REFERENCES "users"("id") ON DELETE SET NULL,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (now()),
+  "expires_at" timestamp WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_membership_invitations_org_email ON membership_invitations (organization_id, lower(email));
+CREATE INDEX idx_membership_invitations_email ON membership_invitations (lower(email));
+
+-- Invitees read their invitations across organizations, unscoped.
+ALTER TABLE "membership_invitations" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "membership_invitations" USING ("organization_id" = app_current_org());
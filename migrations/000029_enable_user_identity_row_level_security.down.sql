@@ -0,0 +1,2 @@
+DROP POLICY IF EXISTS "tenant_isolation" ON "user_identities";
+ALTER TABLE "user_identities" DISABLE ROW LEVEL SECURITY;
@@ -0,0 +1,6 @@
+-- Identity links were left out of 000027. A link belongs to the organization
+-- whose provider issued the subject.
+ALTER TABLE "user_identities" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "user_identities"
+  USING (EXISTS (SELECT 1 FROM "organization_identity_providers" p
+                 WHERE p."id" = "user_identities"."provider_id" AND p."organization_id" = app_current_org()));